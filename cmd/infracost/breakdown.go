@@ -23,7 +23,7 @@ func breakdownCmd(ctx *config.RunContext) *cobra.Command {
       infracost breakdown --path plan.json`,
 		ValidArgs: []string{"--", "-"},
 		RunE: func(cmd *cobra.Command, args []string) error {
-			if err := checkAPIKey(ctx.Config.APIKey, ctx.Config.PricingAPIEndpoint, ctx.Config.DefaultPricingAPIEndpoint); err != nil {
+			if err := checkAPIKey(ctx.Config.APIKey, ctx.Config.PricingAPIEndpoint, ctx.Config.DefaultPricingAPIEndpoint); err != nil && !ctx.Config.UsePricingBundle() {
 				return err
 			}
 
@@ -40,6 +40,8 @@ func breakdownCmd(ctx *config.RunContext) *cobra.Command {
 				return err
 			}
 
+			defer useHoursPerMonth(ctx.Config)()
+
 			return runMain(cmd, ctx)
 		},
 	}
@@ -48,8 +50,10 @@ func breakdownCmd(ctx *config.RunContext) *cobra.Command {
 
 	cmd.Flags().String("out-file", "", "Save output to a file, helpful with format flag")
 	cmd.Flags().Bool("terraform-use-state", false, "Use Terraform state instead of generating a plan. Applicable with --terraform-force-cli")
-	newEnumFlag(cmd, "format", "table", "Output format", []string{"json", "table", "html"})
-	cmd.Flags().StringSlice("fields", []string{"monthlyQuantity", "unit", "monthlyCost"}, "Comma separated list of output fields: all,price,monthlyQuantity,unit,hourlyCost,monthlyCost.\nSupported by table and html output formats")
+	newEnumFlag(cmd, "format", "table", "Output format", []string{"json", "table", "html", "csv"})
+	cmd.Flags().StringSlice("fields", []string{"monthlyQuantity", "unit", "monthlyCost"}, "Comma separated list of output fields: all,price,monthlyQuantity,unit,hourlyCost,monthlyCost,dailyCost,annualCost,monthlyCarbon.\nSupported by table, html and json output formats, all doesn't include monthlyCarbon")
+	cmd.Flags().StringSlice("group-by", nil, "Group and sum the costs by module, resource_type, provider, region or tag:<key>. Can be repeated")
+	cmd.Flags().Int("forecast-months", 0, "Number of months to forecast the costs for using the usage growth functions of the usage file")
 
 	// This is deprecated and will show a warning if used without --terraform-force-cli
 	_ = cmd.Flags().MarkHidden("terraform-use-state")
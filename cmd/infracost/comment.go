@@ -47,6 +47,8 @@ func commentCmd(ctx *config.RunContext) *cobra.Command {
 		_ = subCmd.Flags().MarkHidden("show-changed")
 		subCmd.Flags().Bool("skip-no-diff", false, "Skip posting comment if there are no resource changes. Only applies to update, hide-and-new, and delete-and-new behaviors")
 		_ = subCmd.Flags().MarkHidden("skip-no-diff")
+		subCmd.Flags().String("fx-rates-file", "", "Path to a YAML or CSV exchange rates file used to combine files in different currencies")
+		_ = subCmd.MarkFlagFilename("fx-rates-file", "yml", "csv")
 	}
 
 	cmd.AddCommand(cmds...)
@@ -62,7 +64,12 @@ func buildCommentBody(cmd *cobra.Command, ctx *config.RunContext, paths []string
 		return nil, hasDiff, err
 	}
 
-	combined, err := output.Combine(inputs)
+	rates, err := loadFXRates(cmd, ctx)
+	if err != nil {
+		return nil, hasDiff, err
+	}
+
+	combined, err := output.Combine(inputs, rates)
 	if errors.As(err, &clierror.WarningError{}) {
 		ui.PrintWarningf(cmd.ErrOrStderr(), err.Error())
 	} else if err != nil {
@@ -84,6 +91,10 @@ func buildCommentBody(cmd *cobra.Command, ctx *config.RunContext, paths []string
 		}
 	}
 
+	budgetCheck := output.CheckBudgets(combined)
+	printBudgetWarnings(cmd.ErrOrStderr(), budgetCheck)
+	guardrailCheck = guardrailCheck.Merge(budgetCheck)
+
 	var policyChecks output.PolicyCheck
 	policyPaths, _ := cmd.Flags().GetStringArray("policy-path")
 	if len(policyPaths) > 0 {
@@ -117,6 +128,9 @@ func buildCommentBody(cmd *cobra.Command, ctx *config.RunContext, paths []string
 	if len(guardrailCheck.BlockingFailures) > 0 {
 		return b, hasDiff, guardrailCheck.BlockingFailures
 	}
+	if combined.TagPolicy.HasFailed() {
+		return b, hasDiff, clierror.NewTagPolicyError(len(combined.TagPolicy.Violations))
+	}
 
 	return b, hasDiff, nil
 }
@@ -22,6 +22,12 @@ func TestCommentBitbucketCommit(t *testing.T) {
 		nil)
 }
 
+func TestCommentBitbucketPriceChanges(t *testing.T) {
+	GoldenFileCommandTest(t, testutil.CalcGoldenFileTestdataDirName(),
+		[]string{"comment", "bitbucket", "--bitbucket-token", "abc", "--repo", "test/test", "--pull-request", "5", "--path", "./testdata/price_change_diff.json", "--dry-run"},
+		nil)
+}
+
 func TestCommentBitbucketExcludeDetails(t *testing.T) {
 	GoldenFileCommandTest(t, testutil.CalcGoldenFileTestdataDirName(),
 		[]string{"comment", "bitbucket", "--bitbucket-token", "abc", "--repo", "test/test", "--pull-request", "5", "--path", "./testdata/terraform_v0.14_breakdown.json", "--exclude-cli-output", "--dry-run"},
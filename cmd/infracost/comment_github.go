@@ -8,6 +8,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/infracost/infracost/internal/apiclient"
+	"github.com/infracost/infracost/internal/clierror"
 	"github.com/infracost/infracost/internal/comment"
 	"github.com/infracost/infracost/internal/config"
 	"github.com/infracost/infracost/internal/logging"
@@ -85,11 +86,14 @@ func commentGitHubCmd(ctx *config.RunContext) *cobra.Command {
 			})
 			var policyFailure output.PolicyCheckFailures
 			var guardrailFailure output.GuardrailFailures
+			var tagPolicyFailure *clierror.TagPolicyError
 			if err != nil {
 				if v, ok := err.(output.PolicyCheckFailures); ok {
 					policyFailure = v
 				} else if v, ok := err.(output.GuardrailFailures); ok {
 					guardrailFailure = v
+				} else if v, ok := err.(*clierror.TagPolicyError); ok {
+					tagPolicyFailure = v
 				} else {
 					return err
 				}
@@ -128,6 +132,10 @@ func commentGitHubCmd(ctx *config.RunContext) *cobra.Command {
 				cmd.Printf("\n")
 				return guardrailFailure
 			}
+			if tagPolicyFailure != nil {
+				cmd.Printf("\n")
+				return tagPolicyFailure
+			}
 
 			return nil
 		},
@@ -8,6 +8,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/infracost/infracost/internal/apiclient"
+	"github.com/infracost/infracost/internal/clierror"
 	"github.com/infracost/infracost/internal/comment"
 	"github.com/infracost/infracost/internal/config"
 	"github.com/infracost/infracost/internal/logging"
@@ -84,11 +85,14 @@ func commentGitLabCmd(ctx *config.RunContext) *cobra.Command {
 			})
 			var policyFailure output.PolicyCheckFailures
 			var guardrailFailure output.GuardrailFailures
+			var tagPolicyFailure *clierror.TagPolicyError
 			if err != nil {
 				if v, ok := err.(output.PolicyCheckFailures); ok {
 					policyFailure = v
 				} else if v, ok := err.(output.GuardrailFailures); ok {
 					guardrailFailure = v
+				} else if v, ok := err.(*clierror.TagPolicyError); ok {
+					tagPolicyFailure = v
 				} else {
 					return err
 				}
@@ -125,6 +129,9 @@ func commentGitLabCmd(ctx *config.RunContext) *cobra.Command {
 			if guardrailFailure != nil {
 				return guardrailFailure
 			}
+			if tagPolicyFailure != nil {
+				return tagPolicyFailure
+			}
 
 			return nil
 		},
@@ -0,0 +1,182 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/output"
+	"github.com/infracost/infracost/internal/prices"
+	"github.com/infracost/infracost/internal/providers"
+	"github.com/infracost/infracost/internal/providers/terraform"
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/infracost/infracost/internal/ui"
+	"github.com/infracost/infracost/internal/usage"
+)
+
+func compareRegionsCmd(ctx *config.RunContext) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "compare-regions",
+		Short: "Compare the monthly costs of a project in different regions",
+		Long: `Compare the monthly costs of a project in different regions.
+
+The project is parsed once and its resources are priced in each region, in the
+same way as the INFRACOST_AWS_OVERRIDE_REGION, INFRACOST_AZURE_OVERRIDE_REGION and
+INFRACOST_GOOGLE_OVERRIDE_REGION environment variables override their region.
+Only the resources of the provider that a region belongs to are priced in the
+region, the resources of other providers are shown as not compared.`,
+		Example: `  Compare the costs of a Terraform directory in three AWS regions:
+
+      infracost compare-regions --path /code --regions us-east-1,eu-west-1,ap-southeast-2`,
+		ValidArgs: []string{"--", "-"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := checkAPIKey(ctx.Config.APIKey, ctx.Config.PricingAPIEndpoint, ctx.Config.DefaultPricingAPIEndpoint); err != nil && !ctx.Config.UsePricingBundle() {
+				return err
+			}
+
+			regions, _ := cmd.Flags().GetStringSlice("regions")
+			for i, r := range regions {
+				regions[i] = strings.TrimSpace(r)
+			}
+			if len(regions) == 0 {
+				ui.PrintUsage(cmd)
+				return errors.New("--regions must contain at least one region")
+			}
+			for _, r := range regions {
+				if terraform.RegionProvider(r) == "" {
+					ui.PrintUsage(cmd)
+					return fmt.Errorf("--regions contains %q, which is not an AWS, Azure or Google region", r)
+				}
+			}
+
+			err := loadRunFlags(ctx.Config, cmd)
+			if err != nil {
+				return err
+			}
+
+			err = checkRunConfig(cmd.ErrOrStderr(), ctx.Config)
+			if err != nil {
+				ui.PrintUsage(cmd)
+				return err
+			}
+
+			defer useHoursPerMonth(ctx.Config)()
+
+			breakdowns, err := compareRegions(ctx, regions)
+			if err != nil {
+				return err
+			}
+
+			b, err := output.ToRegionMatrix(breakdowns)
+			if err != nil {
+				return err
+			}
+
+			cmd.Println(string(b))
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringP("path", "p", "", "Path to the Terraform directory or JSON/plan file")
+	cmd.Flags().String("config-file", "", "Path to Infracost config file. Cannot be used with path, terraform* or usage-file flags")
+	cmd.Flags().String("usage-file", "", "Path to Infracost usage file that specifies values for usage-based resources")
+	cmd.Flags().StringSlice("terraform-var-file", nil, "Load variable files, similar to Terraform's -var-file flag. Provided files must be relative to the --path flag")
+	cmd.Flags().StringSlice("terraform-var", nil, "Set value for an input variable, similar to Terraform's -var flag")
+	cmd.Flags().String("terraform-workspace", "", "Terraform workspace to use. Applicable when path is a Terraform directory")
+	cmd.Flags().StringSlice("regions", nil, "Comma separated list of regions to compare")
+	cmd.Flags().Bool("no-cache", false, "Don't attempt to cache Terraform plans or Cloud Pricing API queries")
+	cmd.Flags().String("discounts-file", "", "Path to a discount rules file that applies negotiated discounts to the costs")
+	cmd.Flags().String("price-overrides-file", "", "Path to a price overrides file that sets custom prices for cost components")
+	cmd.Flags().String("fx-rates-file", "", "Path to a YAML or CSV exchange rates file used to convert prices from USD to the currency")
+	cmd.Flags().Float64("hours-per-month", 0, "Number of hours in a month used to calculate monthly costs from hourly prices (default 730)")
+
+	_ = cmd.MarkFlagRequired("regions")
+	_ = cmd.MarkFlagFilename("path", "json", "tf")
+	_ = cmd.MarkFlagFilename("config-file", "yml")
+	_ = cmd.MarkFlagFilename("usage-file", "yml")
+	_ = cmd.MarkFlagFilename("discounts-file", "yml")
+	_ = cmd.MarkFlagFilename("price-overrides-file", "yml")
+	_ = cmd.MarkFlagFilename("fx-rates-file", "yml", "csv")
+
+	return cmd
+}
+
+// compareRegions loads the projects once and then prices their resources in
+// each of the regions.
+func compareRegions(ctx *config.RunContext, regions []string) ([]output.RegionBreakdown, error) {
+	var projectContexts []*config.ProjectContext
+	var projects []*schema.Project
+
+	for _, projectCfg := range ctx.Config.Projects {
+		projectCtx := config.NewProjectContext(ctx, projectCfg, nil)
+
+		provider, err := providers.Detect(projectCtx, false)
+		if v, ok := err.(*providers.ValidationError); ok {
+			if v.Warn() == nil {
+				return nil, err
+			}
+
+			ui.PrintWarning(ctx.ErrWriter, *v.Warn())
+		} else if err != nil {
+			return nil, fmt.Errorf("Could not detect path type for %s: %w", projectCfg.Path, err)
+		}
+
+		usageFile := usage.NewBlankUsageFile()
+		if projectCfg.UsageFile != "" {
+			usageFile, err = usage.LoadUsageFile(projectCfg.UsageFile)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		loaded, err := provider.LoadResources(usageFile.ToUsageDataMap())
+		if err != nil {
+			return nil, err
+		}
+
+		for _, p := range loaded {
+			projectContexts = append(projectContexts, projectCtx)
+			projects = append(projects, p)
+		}
+	}
+
+	breakdowns := make([]output.RegionBreakdown, 0, len(regions))
+
+	for _, region := range regions {
+		regionProjects := make([]*schema.Project, len(projects))
+		notCompared := make([][]string, len(projects))
+		for i, p := range projects {
+			regionProjects[i], notCompared[i] = terraform.ProjectInRegion(projectContexts[i], p, region)
+		}
+
+		schema.BuildResources(regionProjects, nil)
+
+		err := prices.PopulatePrices(ctx, regionProjects...)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, p := range regionProjects {
+			schema.CalculateCosts(p)
+		}
+
+		root, err := output.ToOutputFormat(regionProjects)
+		if err != nil {
+			return nil, err
+		}
+		root.Currency = ctx.Config.Currency
+
+		b := output.RegionBreakdown{Region: region, Root: root, NotCompared: make(map[string][]string)}
+		for i := range root.Projects {
+			b.NotCompared[root.Projects[i].LabelWithMetadata()] = notCompared[i]
+		}
+
+		breakdowns = append(breakdowns, b)
+	}
+
+	return breakdowns, nil
+}
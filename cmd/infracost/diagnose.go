@@ -0,0 +1,129 @@
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/hcl"
+	"github.com/infracost/infracost/internal/providers/terraform"
+	"github.com/infracost/infracost/internal/ui"
+)
+
+func diagnoseCmd(ctx *config.RunContext) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "diagnose",
+		Short: "Show the resource attributes that could not be evaluated",
+		Long: `Show the resource attributes of a Terraform directory that could not be evaluated.
+
+Attributes are listed with their file:line range and the chain of references that
+could not be resolved. Attributes that are defaulted were given a mock value, e.g.
+because a variable has no value or a data source has no stub. Attributes that are
+unknown are only known after apply, e.g. because they reference an attribute that
+is computed by the provider. These are the usual reasons for a Terraform directory
+costing less than its plan.`,
+		Example: `  Show the attributes that could not be evaluated in a Terraform directory:
+
+      infracost diagnose --path /code --terraform-var-file my.tfvars`,
+		ValidArgs: []string{"--", "-"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			err := loadRunFlags(ctx.Config, cmd)
+			if err != nil {
+				return err
+			}
+
+			return diagnose(ctx, cmd.OutOrStdout())
+		},
+	}
+
+	cmd.Flags().StringP("path", "p", "", "Path to the Terraform directory")
+	cmd.Flags().String("config-file", "", "Path to Infracost config file. Cannot be used with path or terraform* flags")
+	cmd.Flags().StringSlice("terraform-var-file", nil, "Load variable files, similar to Terraform's -var-file flag. Provided files must be relative to the --path flag")
+	cmd.Flags().StringSlice("terraform-var", nil, "Set value for an input variable, similar to Terraform's -var flag")
+	cmd.Flags().String("terraform-workspace", "", "Terraform workspace to use")
+
+	_ = cmd.MarkFlagDirname("path")
+	_ = cmd.MarkFlagFilename("config-file", "yml")
+
+	return cmd
+}
+
+// diagnose evaluates the Terraform directories of the projects and writes the
+// evaluation diagnostics of each of them to w.
+func diagnose(ctx *config.RunContext, w io.Writer) error {
+	var total int
+
+	for i, projectCfg := range ctx.Config.Projects {
+		projectCtx := config.NewProjectContext(ctx, projectCfg, nil)
+
+		provider, err := terraform.NewHCLProvider(projectCtx, &terraform.HCLProviderConfig{SuppressLogging: true})
+		if err != nil {
+			return fmt.Errorf("Could not load Terraform directory %s: %w", projectCfg.Path, err)
+		}
+
+		modules, err := provider.Modules()
+		if err != nil {
+			return fmt.Errorf("Could not evaluate Terraform directory %s: %w", projectCfg.Path, err)
+		}
+
+		for j, module := range modules {
+			if i != 0 || j != 0 {
+				fmt.Fprintln(w, "──────────────────────────────────")
+			}
+
+			diagnostics := evaluationDiagnostics(module)
+			total += len(diagnostics)
+
+			writeDiagnostics(w, module.RootPath, diagnostics)
+		}
+	}
+
+	if total == 0 {
+		fmt.Fprintln(w, "All resource attributes were evaluated.")
+		return nil
+	}
+
+	fmt.Fprintf(w, "%d resource attributes could not be fully evaluated.\n", total)
+	return nil
+}
+
+// evaluationDiagnostics returns the diagnostics of the WarningEvaluationDiagnostics warning of the module.
+func evaluationDiagnostics(module *hcl.Module) []hcl.AttributeDiagnostic {
+	for _, warning := range module.Warnings {
+		if warning.Code != hcl.WarningEvaluationDiagnostics {
+			continue
+		}
+
+		if diagnostics, ok := warning.Data.([]hcl.AttributeDiagnostic); ok {
+			return diagnostics
+		}
+	}
+
+	return nil
+}
+
+func writeDiagnostics(w io.Writer, path string, diagnostics []hcl.AttributeDiagnostic) {
+	fmt.Fprintf(w, "%s %s\n\n", ui.BoldString("Project:"), path)
+
+	if len(diagnostics) == 0 {
+		fmt.Fprint(w, "  No diagnostics\n\n")
+		return
+	}
+
+	var address string
+	for _, d := range diagnostics {
+		if d.Address != address {
+			address = d.Address
+			fmt.Fprintf(w, "  %s\n", ui.BoldString(address))
+		}
+
+		fmt.Fprintf(w, "    %s (%s) %s: %s\n", d.Attribute, d.Location(), d.Status, d.Reason)
+		if len(d.References) > 0 {
+			fmt.Fprintf(w, "      %s\n", d.ReferenceChain())
+		}
+	}
+
+	fmt.Fprintln(w)
+}
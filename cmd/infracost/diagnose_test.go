@@ -0,0 +1,22 @@
+package main_test
+
+import (
+	"os"
+	"path"
+	"testing"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/testutil"
+)
+
+func TestDiagnose(t *testing.T) {
+	testPath := path.Join("./testdata", testutil.CalcGoldenFileTestdataDirName())
+	// the module loader writes a modules manifest to the path
+	t.Cleanup(func() {
+		_ = os.RemoveAll(path.Join(testPath, config.InfracostDir))
+	})
+
+	GoldenFileCommandTest(t, testutil.CalcGoldenFileTestdataDirName(),
+		[]string{"diagnose", "--path", testPath},
+		nil)
+}
@@ -33,7 +33,7 @@ func diffCmd(ctx *config.RunContext) *cobra.Command {
       infracost diff --path plan.json`,
 		ValidArgs: []string{"--", "-"},
 		RunE: func(cmd *cobra.Command, args []string) error {
-			if err := checkAPIKey(ctx.Config.APIKey, ctx.Config.PricingAPIEndpoint, ctx.Config.DefaultPricingAPIEndpoint); err != nil {
+			if err := checkAPIKey(ctx.Config.APIKey, ctx.Config.PricingAPIEndpoint, ctx.Config.DefaultPricingAPIEndpoint); err != nil && !ctx.Config.UsePricingBundle() {
 				return err
 			}
 
@@ -54,6 +54,8 @@ func diffCmd(ctx *config.RunContext) *cobra.Command {
 				return err
 			}
 
+			defer useHoursPerMonth(ctx.Config)()
+
 			return runDiff(cmd, ctx)
 		},
 	}
@@ -63,6 +65,7 @@ func diffCmd(ctx *config.RunContext) *cobra.Command {
 	cmd.Flags().String("compare-to", "", "Path to Infracost JSON file to compare against")
 	newEnumFlag(cmd, "format", "diff", "Output format", []string{"json", "diff"})
 	cmd.Flags().String("out-file", "", "Save output to a file")
+	cmd.Flags().Duration("replacement-overlap", 0, "How long both copies of resources replaced with create_before_destroy run for, e.g. 2h. Used to estimate a one-off overlap cost")
 
 	return cmd
 }
@@ -91,7 +94,12 @@ func runCompare(cmd *cobra.Command, ctx *config.RunContext, current output.Root)
 		return fmt.Errorf("Error loading %s used by --compare-to flag. %s", ctx.Config.CompareTo, err)
 	}
 
-	combined, err := output.CompareTo(current, prior)
+	rates, err := ctx.Config.LoadFXRates()
+	if err != nil {
+		return err
+	}
+
+	combined, err := output.CompareTo(current, prior, rates)
 	if err != nil {
 		return err
 	}
@@ -55,9 +55,19 @@ func Run(modifyCtx func(*config.RunContext), args *[]string) {
 	updateMessageChan := make(chan *update.Info)
 
 	defer func() {
+		exitCode := 1
+
 		if appErr != nil {
 			if v, ok := appErr.(*clierror.PanicError); ok {
 				handleUnexpectedErr(ctx, v)
+			} else if v, ok := appErr.(*clierror.PartialResultsError); ok {
+				// The output has been written, so this is only a warning
+				ui.PrintWarning(ctx.ErrWriter, v.Error())
+				exitCode = clierror.PartialResultsExitCode
+			} else if v, ok := appErr.(*clierror.TagPolicyError); ok {
+				// The output has been written and lists the violations
+				ui.PrintError(ctx.ErrWriter, v.Error())
+				exitCode = clierror.TagPolicyExitCode
 			} else {
 				handleCLIError(ctx, appErr)
 			}
@@ -71,8 +81,10 @@ func Run(modifyCtx func(*config.RunContext), args *[]string) {
 
 		handleUpdateMessage(updateMessageChan)
 
-		if appErr != nil || unexpectedErr != nil {
+		if unexpectedErr != nil {
 			ctx.Exit(1)
+		} else if appErr != nil {
+			ctx.Exit(exitCode)
 		}
 	}()
 
@@ -183,6 +195,9 @@ func newRootCmd(ctx *config.RunContext) *cobra.Command {
 	rootCmd.AddCommand(outputCmd(ctx))
 	rootCmd.AddCommand(uploadCmd(ctx))
 	rootCmd.AddCommand(commentCmd(ctx))
+	rootCmd.AddCommand(pricesCmd(ctx))
+	rootCmd.AddCommand(compareRegionsCmd(ctx))
+	rootCmd.AddCommand(diagnoseCmd(ctx))
 	rootCmd.AddCommand(completionCmd())
 	rootCmd.AddCommand(figAutocompleteCmd())
 
@@ -238,7 +253,7 @@ func startUpdateCheck(ctx *config.RunContext, c chan *update.Info) {
 }
 
 func loadCloudSettings(ctx *config.RunContext) {
-	if ctx.Config.IsSelfHosted() || (ctx.Config.EnableCloud != nil && !*ctx.Config.EnableCloud) {
+	if ctx.Config.IsSelfHosted() || ctx.Config.UsePricingBundle() || (ctx.Config.EnableCloud != nil && !*ctx.Config.EnableCloud) {
 		return
 	}
 
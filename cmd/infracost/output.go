@@ -11,6 +11,7 @@ import (
 	"github.com/infracost/infracost/internal/apiclient"
 	"github.com/infracost/infracost/internal/clierror"
 	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/fxrates"
 	"github.com/infracost/infracost/internal/output"
 	"github.com/infracost/infracost/internal/ui"
 )
@@ -21,6 +22,7 @@ var (
 		"diff",
 		"json",
 		"html",
+		"csv",
 		"github-comment",
 		"gitlab-comment",
 		"azure-repos-comment",
@@ -93,7 +95,12 @@ func outputCmd(ctx *config.RunContext) *cobra.Command {
 				return err
 			}
 
-			combined, err := output.Combine(inputs)
+			rates, err := loadFXRates(cmd, ctx)
+			if err != nil {
+				return err
+			}
+
+			combined, err := output.Combine(inputs, rates)
 			if errors.As(err, &clierror.WarningError{}) {
 				if format == "json" {
 					ui.PrintWarningf(cmd.ErrOrStderr(), err.Error())
@@ -101,11 +108,19 @@ func outputCmd(ctx *config.RunContext) *cobra.Command {
 			} else if err != nil {
 				return err
 			}
+
+			if rates != nil && ctx.Config.Currency != "" {
+				combined, err = output.ConvertCurrency(combined, rates, ctx.Config.Currency)
+				if err != nil {
+					return err
+				}
+			}
 			combined.IsCIRun = ctx.IsCIRun()
 			combined.Metadata.InfracostCommand = "output"
 
 			includeAllFields := "all"
-			validFields := []string{"price", "monthlyQuantity", "unit", "hourlyCost", "monthlyCost"}
+			allFields := []string{"price", "monthlyQuantity", "unit", "hourlyCost", "monthlyCost", "dailyCost", "annualCost"}
+			validFields := []string{"price", "monthlyQuantity", "unit", "hourlyCost", "monthlyCost", "dailyCost", "annualCost", "monthlyCarbon"}
 
 			fields := []string{"monthlyQuantity", "unit", "monthlyCost"}
 			if cmd.Flags().Changed("fields") {
@@ -113,7 +128,7 @@ func outputCmd(ctx *config.RunContext) *cobra.Command {
 				if len(fields) == 0 {
 					ui.PrintWarningf(cmd.ErrOrStderr(), "fields is empty, using defaults: %s", cmd.Flag("fields").DefValue)
 				} else if len(fields) == 1 && fields[0] == includeAllFields {
-					fields = validFields
+					fields = allFields
 				} else {
 					vf := []string{}
 					for _, f := range fields {
@@ -133,13 +148,18 @@ func outputCmd(ctx *config.RunContext) *cobra.Command {
 				Fields:            fields,
 				CurrencyFormat:    ctx.Config.CurrencyFormat,
 			}
+			opts.GroupBy, _ = cmd.Flags().GetStringSlice("group-by")
+			if err := output.ValidateGroupBy(opts.GroupBy); err != nil {
+				ui.PrintUsage(cmd)
+				return err
+			}
 			opts.ShowSkipped, _ = cmd.Flags().GetBool("show-skipped")
 			opts.ShowAllProjects, _ = cmd.Flags().GetBool("show-all-projects")
 
-			validFieldsFormats := []string{"table", "html"}
+			validFieldsFormats := []string{"table", "html", "json", "github-comment", "gitlab-comment", "azure-repos-comment", "bitbucket-comment", "bitbucket-comment-summary"}
 
 			if cmd.Flags().Changed("fields") && !contains(validFieldsFormats, format) {
-				ui.PrintWarning(cmd.ErrOrStderr(), "fields is only supported for table and html output formats")
+				ui.PrintWarning(cmd.ErrOrStderr(), "fields is only supported for table, html, json and comment output formats")
 			}
 
 			if ctx.IsCloudUploadEnabled() {
@@ -177,10 +197,13 @@ func outputCmd(ctx *config.RunContext) *cobra.Command {
 	cmd.Flags().StringArrayP("path", "p", []string{}, "Path to Infracost JSON files, glob patterns need quotes")
 	cmd.Flags().StringP("out-file", "o", "", "Save output to a file, helpful with format flag")
 
-	cmd.Flags().String("format", "table", "Output format: json, diff, table, html, github-comment, gitlab-comment, azure-repos-comment, bitbucket-comment, bitbucket-comment-summary, slack-message")
+	cmd.Flags().String("format", "table", "Output format: json, diff, table, html, csv, github-comment, gitlab-comment, azure-repos-comment, bitbucket-comment, bitbucket-comment-summary, slack-message")
 	cmd.Flags().Bool("show-all-projects", false, "Show all projects in the table of the comment output")
 	cmd.Flags().Bool("show-skipped", false, "List unsupported and free resources")
-	cmd.Flags().StringSlice("fields", []string{"monthlyQuantity", "unit", "monthlyCost"}, "Comma separated list of output fields: all,price,monthlyQuantity,unit,hourlyCost,monthlyCost.\nSupported by table and html output formats")
+	cmd.Flags().String("fx-rates-file", "", "Path to a YAML or CSV exchange rates file used to combine files in different currencies")
+	_ = cmd.MarkFlagFilename("fx-rates-file", "yml", "csv")
+	cmd.Flags().StringSlice("fields", []string{"monthlyQuantity", "unit", "monthlyCost"}, "Comma separated list of output fields: all,price,monthlyQuantity,unit,hourlyCost,monthlyCost,dailyCost,annualCost,monthlyCarbon.\nSupported by table, html, json and comment output formats, all doesn't include monthlyCarbon")
+	cmd.Flags().StringSlice("group-by", nil, "Group and sum the costs by module, resource_type, provider, region or tag:<key>. Can be repeated")
 
 	_ = cmd.MarkFlagRequired("path")
 	_ = cmd.MarkFlagFilename("path", "json")
@@ -218,3 +241,13 @@ func contains(arr []string, e string) bool {
 	}
 	return false
 }
+
+// loadFXRates returns the exchange rates from the --fx-rates-file flag or the
+// INFRACOST_FX_RATES_FILE environment variable, or nil if neither is set.
+func loadFXRates(cmd *cobra.Command, ctx *config.RunContext) (*fxrates.Rates, error) {
+	if cmd.Flags().Changed("fx-rates-file") {
+		ctx.Config.FXRatesFile, _ = cmd.Flags().GetString("fx-rates-file")
+	}
+
+	return ctx.Config.LoadFXRates()
+}
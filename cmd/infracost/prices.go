@@ -0,0 +1,190 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/infracost/infracost/internal/apiclient"
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/providers"
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/infracost/infracost/internal/ui"
+	"github.com/infracost/infracost/internal/usage"
+)
+
+func pricesCmd(ctx *config.RunContext) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "prices",
+		Short: "Manage offline price bundles",
+		Long:  "Manage offline price bundles",
+		Example: `  Export the prices used by a Terraform directory:
+
+      infracost prices export --path /code --out-file prices.json
+
+  Use the exported prices without network access:
+
+      INFRACOST_PRICING_BUNDLE_FILE=prices.json infracost breakdown --path /code`,
+		ValidArgs: []string{"--", "-"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+
+	cmd.AddCommand(pricesExportCmd(ctx))
+
+	return cmd
+}
+
+func pricesExportCmd(ctx *config.RunContext) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export prices to a bundle file that can be used offline",
+		Long: `Export prices to a bundle file that can be used offline.
+
+Either export every product and price used by a set of projects, using --path or
+--config-file, or a whole slice of the Cloud Pricing API, using --vendor and --region.`,
+		Example: `  Export the prices used by a Terraform directory:
+
+      infracost prices export --path /code --out-file prices.json
+
+  Export the prices used by all projects in a config file, in USD and EUR:
+
+      infracost prices export --config-file infracost.yml --currencies USD,EUR --out-file prices.json
+
+  Export all AWS EC2 prices in us-east-1:
+
+      infracost prices export --vendor aws --region us-east-1 --service AmazonEC2 --out-file prices.json`,
+		ValidArgs: []string{"--", "-"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if ctx.Config.UsePricingBundle() {
+				return errors.New("Cannot export prices when INFRACOST_PRICING_BUNDLE_FILE is set")
+			}
+
+			if err := checkAPIKey(ctx.Config.APIKey, ctx.Config.PricingAPIEndpoint, ctx.Config.DefaultPricingAPIEndpoint); err != nil {
+				return err
+			}
+
+			currencies, _ := cmd.Flags().GetStringSlice("currencies")
+			for i, c := range currencies {
+				currencies[i] = strings.ToUpper(c)
+			}
+			if len(currencies) == 0 {
+				return errors.New("--currencies must contain at least one currency")
+			}
+
+			bundle := apiclient.NewPriceBundle(currencies)
+			c := apiclient.NewPricingAPIClient(ctx)
+
+			vendor, _ := cmd.Flags().GetString("vendor")
+			if vendor != "" {
+				err := exportProductSlice(cmd, c, bundle, vendor)
+				if err != nil {
+					return err
+				}
+			} else {
+				err := loadRunFlags(ctx.Config, cmd)
+				if err != nil {
+					return err
+				}
+
+				err = exportProjectPrices(ctx, c, bundle)
+				if err != nil {
+					return err
+				}
+			}
+
+			outFile, _ := cmd.Flags().GetString("out-file")
+			err := bundle.WriteToPath(outFile)
+			if err != nil {
+				return errors.Wrap(err, "Unable to save price bundle")
+			}
+
+			cmd.PrintErrf("Exported %d products to %s\n", len(bundle.Products), outFile)
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringP("path", "p", "", "Path to the Terraform directory or JSON/plan file")
+	cmd.Flags().String("config-file", "", "Path to Infracost config file. Cannot be used with path, terraform* or usage-file flags")
+	cmd.Flags().String("usage-file", "", "Path to Infracost usage file that specifies values for usage-based resources")
+	cmd.Flags().StringSlice("terraform-var-file", nil, "Load variable files, similar to Terraform's -var-file flag. Provided files must be relative to the --path flag")
+	cmd.Flags().StringSlice("terraform-var", nil, "Set value for an input variable, similar to Terraform's -var flag")
+	cmd.Flags().String("terraform-workspace", "", "Terraform workspace to use. Applicable when path is a Terraform directory")
+
+	cmd.Flags().String("vendor", "", "Export all products for a vendor instead of a project: aws, azure, google")
+	cmd.Flags().String("region", "", "Region of the products to export. Applicable with --vendor")
+	cmd.Flags().String("service", "", "Service of the products to export, e.g. AmazonEC2. Applicable with --vendor")
+	cmd.Flags().StringSlice("currencies", []string{"USD"}, "Comma separated list of currencies to export prices in")
+	cmd.Flags().String("out-file", "", "Save the price bundle to this file")
+
+	_ = cmd.MarkFlagRequired("out-file")
+	_ = cmd.MarkFlagFilename("path", "json", "tf")
+	_ = cmd.MarkFlagFilename("config-file", "yml")
+	_ = cmd.MarkFlagFilename("usage-file", "yml")
+
+	return cmd
+}
+
+func exportProductSlice(cmd *cobra.Command, c *apiclient.PricingAPIClient, bundle *apiclient.PriceBundle, vendor string) error {
+	if cmd.Flags().Changed("path") || cmd.Flags().Changed("config-file") {
+		return errors.New("--vendor cannot be used with --path or --config-file")
+	}
+
+	filter := &schema.ProductFilter{VendorName: &vendor}
+
+	if region, _ := cmd.Flags().GetString("region"); region != "" {
+		filter.Region = &region
+	}
+
+	if service, _ := cmd.Flags().GetString("service"); service != "" {
+		filter.Service = &service
+	}
+
+	return c.RecordProducts(filter, bundle)
+}
+
+func exportProjectPrices(ctx *config.RunContext, c *apiclient.PricingAPIClient, bundle *apiclient.PriceBundle) error {
+	resources := make([]*schema.Resource, 0)
+
+	for _, projectCfg := range ctx.Config.Projects {
+		projectCtx := config.NewProjectContext(ctx, projectCfg, nil)
+
+		provider, err := providers.Detect(projectCtx, true)
+		if v, ok := err.(*providers.ValidationError); ok {
+			if v.Warn() == nil {
+				return err
+			}
+
+			ui.PrintWarning(ctx.ErrWriter, *v.Warn())
+		} else if err != nil {
+			return fmt.Errorf("Could not detect path type for %s: %w", projectCfg.Path, err)
+		}
+
+		usageFile := usage.NewBlankUsageFile()
+		if projectCfg.UsageFile != "" {
+			usageFile, err = usage.LoadUsageFile(projectCfg.UsageFile)
+			if err != nil {
+				return err
+			}
+		}
+
+		projects, err := provider.LoadResources(usageFile.ToUsageDataMap())
+		if err != nil {
+			return err
+		}
+
+		schema.BuildResources(projects, nil)
+
+		for _, project := range projects {
+			resources = append(resources, project.AllResources()...)
+			resources = append(resources, project.UsageScenarioResources()...)
+			resources = append(resources, project.ForecastResources()...)
+		}
+	}
+
+	return c.RecordQueries(resources, bundle)
+}
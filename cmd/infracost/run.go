@@ -34,6 +34,9 @@ import (
 	"github.com/infracost/infracost/internal/usage"
 )
 
+// maxForecastMonths is the maximum number of months the costs can be forecast for.
+const maxForecastMonths = 120
+
 type projectJob struct {
 	index      int
 	projectCfg *config.Project
@@ -70,7 +73,12 @@ func addRunFlags(cmd *cobra.Command) {
 	cmd.Flags().String("git-diff-target", "master", "Show only costs that have git changes compared to the provided branch. Use the name of the current branch to fetch changes from the last two commits")
 	_ = cmd.Flags().MarkHidden("git-diff-target")
 
-	cmd.Flags().Bool("no-cache", false, "Don't attempt to cache Terraform plans")
+	cmd.Flags().Bool("no-cache", false, "Don't attempt to cache Terraform plans or Cloud Pricing API queries")
+	cmd.Flags().Bool("allow-partial-prices", false, "Output costs even if some prices can't be retrieved, the costs are then a lower bound")
+	cmd.Flags().String("discounts-file", "", "Path to a discount rules file that applies negotiated discounts to the costs")
+	cmd.Flags().String("price-overrides-file", "", "Path to a price overrides file that sets custom prices for cost components")
+	cmd.Flags().String("fx-rates-file", "", "Path to a YAML or CSV exchange rates file used to convert prices from USD to the currency")
+	cmd.Flags().Float64("hours-per-month", 0, "Number of hours in a month used to calculate monthly costs from hourly prices (default 730)")
 
 	cmd.Flags().Bool("show-skipped", false, "List unsupported and free resources")
 
@@ -79,6 +87,9 @@ func addRunFlags(cmd *cobra.Command) {
 	_ = cmd.MarkFlagFilename("path", "json", "tf")
 	_ = cmd.MarkFlagFilename("config-file", "yml")
 	_ = cmd.MarkFlagFilename("usage-file", "yml")
+	_ = cmd.MarkFlagFilename("discounts-file", "yml")
+	_ = cmd.MarkFlagFilename("price-overrides-file", "yml")
+	_ = cmd.MarkFlagFilename("fx-rates-file", "yml", "csv")
 
 	_ = cmd.Flags().MarkHidden("terraform-force-cli")
 	// These are deprecated and will show a warning if used without --terraform-force-cli
@@ -121,6 +132,11 @@ func runMain(cmd *cobra.Command, runCtx *config.RunContext) error {
 		hclProjects = append(hclProjects, projectResult.projectOut.hclProjects...)
 	}
 
+	err = pr.populatePrices(projects)
+	if err != nil {
+		return err
+	}
+
 	wg := &sync.WaitGroup{}
 	var hclR *output.Root
 	if len(hclProjects) > 0 {
@@ -133,19 +149,32 @@ func runMain(cmd *cobra.Command, runCtx *config.RunContext) error {
 	if err != nil {
 		return err
 	}
+	r.Currency = runCtx.Config.Currency
 
 	if pr.prior != nil {
-		r, err = output.CompareTo(r, *pr.prior)
+		rates, err := runCtx.Config.LoadFXRates()
+		if err != nil {
+			return err
+		}
+
+		r, err = output.CompareTo(r, *pr.prior, rates)
 		if err != nil {
 			return err
 		}
 	}
 
+	r = output.WithReplacementOverlapCosts(r, runCtx.Config.ReplacementOverlap)
+
 	wg.Wait()
 	r.IsCIRun = runCtx.IsCIRun()
-	r.Currency = runCtx.Config.Currency
 	r.Metadata = output.NewMetadata(runCtx)
 
+	if runCtx.Config.TagPolicy != nil {
+		r.TagPolicy = output.CheckTagPolicy(projects, runCtx.Config.TagPolicy)
+	}
+
+	budgetCheck := output.CheckBudgets(r)
+
 	if runCtx.IsCloudUploadEnabled() {
 		dashboardClient := apiclient.NewDashboardAPIClient(runCtx)
 		result, err := dashboardClient.AddRun(runCtx, r)
@@ -169,7 +198,9 @@ func runMain(cmd *cobra.Command, runCtx *config.RunContext) error {
 		ShowSkipped:       runCtx.Config.ShowSkipped,
 		NoColor:           runCtx.Config.NoColor,
 		Fields:            runCtx.Config.Fields,
+		GroupBy:           runCtx.Config.GroupBy,
 		CurrencyFormat:    runCtx.Config.CurrencyFormat,
+		GuardrailCheck:    budgetCheck,
 	})
 	if err != nil {
 		return err
@@ -201,9 +232,37 @@ func runMain(cmd *cobra.Command, runCtx *config.RunContext) error {
 		cmd.Println(string(b))
 	}
 
+	printBudgetWarnings(cmd.ErrOrStderr(), budgetCheck)
+	if len(budgetCheck.BlockingFailures) > 0 {
+		return budgetCheck.BlockingFailures
+	}
+
+	if r.TagPolicy.HasFailed() {
+		return clierror.NewTagPolicyError(len(r.TagPolicy.Violations))
+	}
+
+	if r.IsLowerBound() {
+		return clierror.NewPartialResultsError(*r.Summary.TotalPriceUnavailableComponents)
+	}
+
 	return nil
 }
 
+// printBudgetWarnings prints the budget breaches that don't block the run as
+// warnings. Blocking breaches are returned as the error of the command.
+func printBudgetWarnings(w io.Writer, check output.GuardrailCheck) {
+	blocking := make(map[string]bool, len(check.BlockingFailures))
+	for _, f := range check.BlockingFailures {
+		blocking[f] = true
+	}
+
+	for _, f := range check.CommentableFailures {
+		if !blocking[f] {
+			ui.PrintWarningf(w, "Budget check failed: %s", f)
+		}
+	}
+}
+
 func formatHCLProjects(wg *sync.WaitGroup, ctx *config.RunContext, hclProjects []*schema.Project, hclR *output.Root) {
 	defer func() {
 		err := recover()
@@ -255,6 +314,12 @@ func newParallelRunner(cmd *cobra.Command, runCtx *config.RunContext) (*parallel
 			return nil, fmt.Errorf("Error loading %s used by --compare-to flag. %s", runCtx.Config.CompareTo, err)
 		}
 
+		// Estimate the carbon emissions if they were estimated for the prior
+		// run too, otherwise the diff would show them all as removed.
+		if snapshot.TotalMonthlyCarbon != nil && !runCtx.Config.EstimateCarbon() {
+			runCtx.Config.Fields = append(runCtx.Config.Fields, "monthlyCarbon")
+		}
+
 		prior = &snapshot
 	}
 
@@ -484,10 +549,38 @@ func (r *parallelRunner) runProjectConfig(ctx *config.ProjectContext) (*projectO
 		return nil, err
 	}
 
+	for _, project := range projects {
+		project.Commitments = usageFile.Commitments
+
+		if ctx.ProjectConfig.Budget != nil && project.Metadata != nil {
+			project.Metadata.Budget = ctx.ProjectConfig.Budget
+		}
+	}
+
 	_ = r.uploadCloudResourceIDs(projects)
 
 	r.buildResources(projects)
 
+	t2 := time.Now()
+	taken := t2.Sub(t1).Milliseconds()
+	ctx.SetContextValue("tfProjectRunTimeMs", taken)
+
+	// wait for the hcl provider to finish if it hasn't already
+	wg.Wait()
+
+	out.projects = projects
+
+	if !r.runCtx.Config.IsLogging() && !r.runCtx.Config.SkipErrLine {
+		r.cmd.PrintErrln()
+	}
+
+	return out, nil
+}
+
+// populatePrices prices all the projects of the run together, so that price
+// queries that are shared between projects are only sent once, and then
+// calculates their costs.
+func (r *parallelRunner) populatePrices(projects []*schema.Project) error {
 	spinnerOpts := ui.SpinnerOptions{
 		EnableLogging: r.runCtx.Config.IsLogging(),
 		NoColor:       r.runCtx.Config.NoColor,
@@ -496,44 +589,38 @@ func (r *parallelRunner) runProjectConfig(ctx *config.ProjectContext) (*projectO
 	spinner := ui.NewSpinner("Retrieving cloud prices to calculate costs", spinnerOpts)
 	defer spinner.Fail()
 
-	for _, project := range projects {
-		if err := prices.PopulatePrices(r.runCtx, project); err != nil {
-			spinner.Fail()
-			r.cmd.PrintErrln()
-
-			if e := unwrapped(err); errors.Is(e, apiclient.ErrInvalidAPIKey) {
-				return nil, fmt.Errorf("%v\n%s %s %s %s %s\n%s %s.\n%s %s %s",
-					e.Error(),
-					"Please check your",
-					ui.PrimaryString(config.CredentialsFilePath()),
-					"file or",
-					ui.PrimaryString("INFRACOST_API_KEY"),
-					"environment variable.",
-					"If you recently regenerated your API key, you can retrieve it from",
-					ui.PrimaryString(r.runCtx.Config.DashboardEndpoint),
-					"See",
-					ui.PrimaryString("https://infracost.io/support"),
-					"if you continue having issues.",
-				)
-			}
+	if err := prices.PopulatePrices(r.runCtx, projects...); err != nil {
+		spinner.Fail()
+		r.cmd.PrintErrln()
 
-			if e, ok := err.(*apiclient.APIError); ok {
-				return nil, fmt.Errorf("%v\n%s", e.Error(), "We have been notified of this issue.")
-			}
+		if e := unwrapped(err); errors.Is(e, apiclient.ErrInvalidAPIKey) {
+			return fmt.Errorf("%v\n%s %s %s %s %s\n%s %s.\n%s %s %s",
+				e.Error(),
+				"Please check your",
+				ui.PrimaryString(config.CredentialsFilePath()),
+				"file or",
+				ui.PrimaryString("INFRACOST_API_KEY"),
+				"environment variable.",
+				"If you recently regenerated your API key, you can retrieve it from",
+				ui.PrimaryString(r.runCtx.Config.DashboardEndpoint),
+				"See",
+				ui.PrimaryString("https://infracost.io/support"),
+				"if you continue having issues.",
+			)
+		}
 
-			return nil, err
+		if e, ok := err.(*apiclient.APIError); ok {
+			return fmt.Errorf("%v\n%s", e.Error(), "We have been notified of this issue.")
 		}
-		schema.CalculateCosts(project)
 
-		project.CalculateDiff()
+		return err
 	}
 
-	t2 := time.Now()
-	taken := t2.Sub(t1).Milliseconds()
-	ctx.SetContextValue("tfProjectRunTimeMs", taken)
+	for _, project := range projects {
+		schema.CalculateCosts(project)
 
-	// wait for the hcl provider to finish if it hasn't already
-	wg.Wait()
+		project.CalculateDiff()
+	}
 
 	spinner.Success()
 
@@ -541,13 +628,11 @@ func (r *parallelRunner) runProjectConfig(ctx *config.ProjectContext) (*projectO
 		r.populateActualCosts(projects)
 	}
 
-	out.projects = projects
-
 	if !r.runCtx.Config.IsLogging() && !r.runCtx.Config.SkipErrLine {
 		r.cmd.PrintErrln()
 	}
 
-	return out, nil
+	return nil
 }
 
 func (r *parallelRunner) uploadCloudResourceIDs(projects []*schema.Project) error {
@@ -595,6 +680,10 @@ func (r *parallelRunner) buildResources(projects []*schema.Project) {
 	}
 
 	schema.BuildResources(projects, projectPtrToUsageMap)
+
+	if r.runCtx.Config.ForecastMonths > 0 {
+		schema.BuildForecasts(projects, r.runCtx.Config.ForecastMonths, projectPtrToUsageMap)
+	}
 }
 
 func (r *parallelRunner) fetchProjectUsage(projects []*schema.Project) map[*schema.Project]map[string]*schema.UsageData {
@@ -878,22 +967,40 @@ func loadRunFlags(cfg *config.Config, cmd *cobra.Command) error {
 	}
 
 	cfg.NoCache, _ = cmd.Flags().GetBool("no-cache")
+	if cmd.Flags().Changed("allow-partial-prices") {
+		cfg.AllowPartialPrices, _ = cmd.Flags().GetBool("allow-partial-prices")
+	}
+	if cmd.Flags().Changed("discounts-file") {
+		cfg.DiscountsFile, _ = cmd.Flags().GetString("discounts-file")
+	}
+	if cmd.Flags().Changed("price-overrides-file") {
+		cfg.PriceOverridesFile, _ = cmd.Flags().GetString("price-overrides-file")
+	}
+	if cmd.Flags().Changed("fx-rates-file") {
+		cfg.FXRatesFile, _ = cmd.Flags().GetString("fx-rates-file")
+	}
+	if cmd.Flags().Changed("hours-per-month") {
+		cfg.HoursPerMonth, _ = cmd.Flags().GetFloat64("hours-per-month")
+	}
 	cfg.Format, _ = cmd.Flags().GetString("format")
 	cfg.ShowSkipped, _ = cmd.Flags().GetBool("show-skipped")
 	cfg.SyncUsageFile, _ = cmd.Flags().GetBool("sync-usage-file")
 
 	includeAllFields := "all"
-	validFields := []string{"price", "monthlyQuantity", "unit", "hourlyCost", "monthlyCost"}
-	validFieldsFormats := []string{"table", "html"}
+	allFields := []string{"price", "monthlyQuantity", "unit", "hourlyCost", "monthlyCost", "dailyCost", "annualCost"}
+	// monthlyCarbon is an estimate rather than a cost, so it isn't included in
+	// all and has to be requested explicitly
+	validFields := []string{"price", "monthlyQuantity", "unit", "hourlyCost", "monthlyCost", "dailyCost", "annualCost", "monthlyCarbon"}
+	validFieldsFormats := []string{"table", "html", "json"}
 
 	if cmd.Flags().Changed("fields") {
 		fields, _ := cmd.Flags().GetStringSlice("fields")
 		if len(fields) == 0 {
 			ui.PrintWarningf(cmd.ErrOrStderr(), "fields is empty, using defaults: %s", cmd.Flag("fields").DefValue)
 		} else if cfg.Fields != nil && !contains(validFieldsFormats, cfg.Format) {
-			ui.PrintWarning(cmd.ErrOrStderr(), "fields is only supported for table and html output formats")
+			ui.PrintWarning(cmd.ErrOrStderr(), "fields is only supported for table, html and json output formats")
 		} else if len(fields) == 1 && fields[0] == includeAllFields {
-			cfg.Fields = validFields
+			cfg.Fields = allFields
 		} else {
 			vf := []string{}
 			for _, f := range fields {
@@ -907,6 +1014,21 @@ func loadRunFlags(cfg *config.Config, cmd *cobra.Command) error {
 		}
 	}
 
+	if cmd.Flags().Changed("forecast-months") {
+		cfg.ForecastMonths, _ = cmd.Flags().GetInt("forecast-months")
+	}
+
+	if cmd.Flags().Changed("replacement-overlap") {
+		cfg.ReplacementOverlap, _ = cmd.Flags().GetDuration("replacement-overlap")
+	}
+
+	if cmd.Flags().Changed("group-by") {
+		cfg.GroupBy, _ = cmd.Flags().GetStringSlice("group-by")
+		if err := output.ValidateGroupBy(cfg.GroupBy); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -928,6 +1050,22 @@ func tfVarsToMap(vars []string) map[string]string {
 	return m
 }
 
+// useHoursPerMonth sets the number of hours in a month that hourly prices are
+// converted with to the configured hours per month, if it is set. The returned
+// function restores the previous number of hours, so it must be called once the
+// run is done to stop the value leaking into anything else priced in the
+// process.
+func useHoursPerMonth(cfg *config.Config) func() {
+	previous := schema.HourToMonthUnitMultiplier
+	if cfg.HoursPerMonth != 0 {
+		schema.HourToMonthUnitMultiplier = decimal.NewFromFloat(cfg.HoursPerMonth)
+	}
+
+	return func() {
+		schema.HourToMonthUnitMultiplier = previous
+	}
+}
+
 func checkRunConfig(warningWriter io.Writer, cfg *config.Config) error {
 	if cfg.Format == "json" && cfg.ShowSkipped {
 		ui.PrintWarning(warningWriter, "show-skipped is not needed with JSON output format as that always includes them.\n")
@@ -954,6 +1092,21 @@ func checkRunConfig(warningWriter io.Writer, cfg *config.Config) error {
 		cfg.Currency = "USD"
 	}
 
+	if cfg.HoursPerMonth != 0 {
+		// A month can't have more than 31 days of hours
+		if cfg.HoursPerMonth < 0 || cfg.HoursPerMonth > 744 {
+			return fmt.Errorf("hours-per-month must be greater than 0 and no more than 744, got %v", cfg.HoursPerMonth)
+		}
+	}
+
+	if cfg.ReplacementOverlap < 0 {
+		return fmt.Errorf("replacement-overlap must not be negative, got %s", cfg.ReplacementOverlap)
+	}
+
+	if cfg.ForecastMonths < 0 || cfg.ForecastMonths > maxForecastMonths {
+		return fmt.Errorf("forecast-months must be between 1 and %d, got %d", maxForecastMonths, cfg.ForecastMonths)
+	}
+
 	return nil
 }
 
@@ -1,12 +1,19 @@
 package main_test
 
 import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/shopspring/decimal"
 	log "github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	main "github.com/infracost/infracost/cmd/infracost"
 	"github.com/infracost/infracost/internal/config"
@@ -73,6 +80,37 @@ func TestCatchesRuntimeError(t *testing.T) {
 	})
 }
 
+func TestHoursPerMonthIsRestoredAfterRun(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var queries []interface{}
+		_ = json.NewDecoder(r.Body).Decode(&queries)
+
+		results := make([]string, len(queries))
+		for i := range queries {
+			results[i] = `{"data": {"products": [{"prices": [{"priceHash": "h", "USD": "0.1"}]}]}}`
+		}
+
+		fmt.Fprintf(w, "[%s]", strings.Join(results, ","))
+	}))
+	defer ts.Close()
+
+	outBuf := bytes.NewBuffer([]byte{})
+
+	main.Run(func(c *config.RunContext) {
+		enableCloud := false
+		c.Config.EnableCloud = &enableCloud
+		c.Config.EventsDisabled = true
+		c.Config.NoCache = true
+		c.Config.PricingAPIEndpoint = ts.URL
+		c.OutWriter = outBuf
+		c.ErrWriter = bytes.NewBuffer([]byte{})
+		c.Exit = func(code int) {}
+	}, &[]string{"breakdown", "--path", "./testdata/example_plan.json", "--format", "json", "--hours-per-month", "720"})
+
+	require.Contains(t, outBuf.String(), `"monthlyQuantity":"720"`)
+	assert.Equal(t, "730", schema.HourToMonthUnitMultiplier.String())
+}
+
 func TestAddHCLEnvVars(t *testing.T) {
 	type args struct {
 		r           output.Root
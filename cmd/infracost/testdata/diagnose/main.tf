@@ -0,0 +1,37 @@
+provider "aws" {
+  region                      = "us-east-1"
+  skip_credentials_validation = true
+  skip_requesting_account_id  = true
+  access_key                  = "mock_access_key"
+  secret_key                  = "mock_secret_key"
+}
+
+variable "env" {}
+
+locals {
+  sizes = {
+    dev  = "t3.micro"
+    prod = "m5.large"
+  }
+
+  instance_type = local.sizes[var.env]
+}
+
+data "aws_ebs_snapshot" "latest" {
+  most_recent = true
+}
+
+resource "aws_instance" "web" {
+  ami           = "ami-674cbc1e"
+  instance_type = local.instance_type
+
+  root_block_device {
+    volume_size = 50
+  }
+}
+
+resource "aws_ebs_volume" "data" {
+  availability_zone = aws_instance.web.availability_zone
+  size              = data.aws_ebs_snapshot.latest.volume_size
+  snapshot_id       = data.aws_ebs_snapshot.latest.id
+}
@@ -0,0 +1,457 @@
+package apiclient
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dlclark/regexp2"
+	"github.com/shopspring/decimal"
+	"github.com/tidwall/gjson"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+const priceBundleVersion = "0.1"
+
+var (
+	loadedBundles   = map[string]*PriceBundle{}
+	loadedBundlesMu = &sync.Mutex{}
+)
+
+// PriceBundle is a versioned snapshot of products and prices returned by the
+// Cloud Pricing API. A bundle can be written to disk with `infracost prices export`
+// and then used by the PricingAPIClient to answer price queries without network
+// access.
+type PriceBundle struct {
+	Version    string           `json:"version"`
+	CreatedAt  time.Time        `json:"createdAt"`
+	Currencies []string         `json:"currencies"`
+	Products   []*BundleProduct `json:"products"`
+
+	mu            sync.Mutex
+	productIndex  map[string]*BundleProduct
+	productGroups map[string]*bundleProductGroup
+	regexCache    map[string]*regexp2.Regexp
+}
+
+// bundleProductGroup holds the products of a bundle that have the same vendor,
+// service and region, indexed by each of their attribute key/value pairs, so
+// that a query only has to match the products that can possibly match it.
+type bundleProductGroup struct {
+	products    []*BundleProduct
+	byAttribute map[string][]*BundleProduct
+}
+
+// BundleProduct is a single Cloud Pricing API product and the prices recorded for it.
+type BundleProduct struct {
+	ProductHash   string            `json:"productHash"`
+	VendorName    string            `json:"vendorName"`
+	Service       string            `json:"service"`
+	ProductFamily string            `json:"productFamily"`
+	Region        string            `json:"region"`
+	Sku           string            `json:"sku"`
+	Attributes    map[string]string `json:"attributes"`
+	Prices        []*BundlePrice    `json:"prices"`
+}
+
+// BundlePrice is a single price of a BundleProduct. Amounts holds the price
+// for every currency that the bundle was exported with.
+type BundlePrice struct {
+	PriceHash          string            `json:"priceHash"`
+	PurchaseOption     string            `json:"purchaseOption"`
+	Unit               string            `json:"unit"`
+	Description        string            `json:"description,omitempty"`
+	StartUsageAmount   string            `json:"startUsageAmount"`
+	EndUsageAmount     string            `json:"endUsageAmount"`
+	TermLength         string            `json:"termLength,omitempty"`
+	TermPurchaseOption string            `json:"termPurchaseOption,omitempty"`
+	TermOfferingClass  string            `json:"termOfferingClass,omitempty"`
+	Amounts            map[string]string `json:"amounts"`
+}
+
+// NewPriceBundle returns an empty bundle that records prices in the given currencies.
+func NewPriceBundle(currencies []string) *PriceBundle {
+	b := &PriceBundle{
+		Version:    priceBundleVersion,
+		CreatedAt:  time.Now().UTC(),
+		Currencies: currencies,
+		Products:   []*BundleProduct{},
+	}
+	b.buildIndex()
+
+	return b
+}
+
+// LoadPriceBundle reads a bundle from path. Bundles are cached by path so that
+// multiple pricing clients in the same run share the parsed data.
+func LoadPriceBundle(path string) (*PriceBundle, error) {
+	loadedBundlesMu.Lock()
+	defer loadedBundlesMu.Unlock()
+
+	if b, ok := loadedBundles[path]; ok {
+		return b, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("Error reading price bundle %s: %w", path, err)
+	}
+
+	var b PriceBundle
+	err = json.Unmarshal(data, &b)
+	if err != nil {
+		return nil, fmt.Errorf("Error parsing price bundle %s: %w", path, err)
+	}
+
+	if b.Version != priceBundleVersion {
+		return nil, fmt.Errorf("Price bundle %s has version '%s', only version '%s' is supported", path, b.Version, priceBundleVersion)
+	}
+
+	b.buildIndex()
+	loadedBundles[path] = &b
+
+	return &b, nil
+}
+
+// WriteToPath writes the bundle as JSON to path. Products are sorted so that
+// exporting the same prices twice produces the same file.
+func (b *PriceBundle) WriteToPath(path string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	sort.Slice(b.Products, func(i, j int) bool {
+		return b.Products[i].ProductHash < b.Products[j].ProductHash
+	})
+
+	data, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return fmt.Errorf("Error marshaling price bundle: %w", err)
+	}
+
+	return os.WriteFile(path, data, 0644) // nolint:gosec
+}
+
+// HasCurrency returns true if prices were recorded in the given currency.
+func (b *PriceBundle) HasCurrency(currency string) bool {
+	for _, c := range b.Currencies {
+		if strings.EqualFold(c, currency) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// AddProducts merges the products from a Cloud Pricing API response into the bundle.
+// The response is expected to have been built using the full export query.
+func (b *PriceBundle) AddProducts(res gjson.Result) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, p := range res.Get("data.products").Array() {
+		hash := p.Get("productHash").String()
+
+		product, ok := b.productIndex[hash]
+		if !ok {
+			product = &BundleProduct{
+				ProductHash:   hash,
+				VendorName:    p.Get("vendorName").String(),
+				Service:       p.Get("service").String(),
+				ProductFamily: p.Get("productFamily").String(),
+				Region:        p.Get("region").String(),
+				Sku:           p.Get("sku").String(),
+				Attributes:    map[string]string{},
+				Prices:        []*BundlePrice{},
+			}
+			for _, attr := range p.Get("attributes").Array() {
+				product.Attributes[attr.Get("key").String()] = attr.Get("value").String()
+			}
+
+			b.Products = append(b.Products, product)
+			b.indexProduct(product)
+		}
+
+		for _, pr := range p.Get("prices").Array() {
+			product.addPrice(b.Currencies, pr)
+		}
+	}
+}
+
+func (p *BundleProduct) addPrice(currencies []string, pr gjson.Result) {
+	hash := pr.Get("priceHash").String()
+	for _, existing := range p.Prices {
+		if existing.PriceHash == hash {
+			return
+		}
+	}
+
+	amounts := make(map[string]string, len(currencies))
+	for _, c := range currencies {
+		amounts[c] = pr.Get(c).String()
+	}
+
+	p.Prices = append(p.Prices, &BundlePrice{
+		PriceHash:          hash,
+		PurchaseOption:     pr.Get("purchaseOption").String(),
+		Unit:               pr.Get("unit").String(),
+		Description:        pr.Get("description").String(),
+		StartUsageAmount:   pr.Get("startUsageAmount").String(),
+		EndUsageAmount:     pr.Get("endUsageAmount").String(),
+		TermLength:         pr.Get("termLength").String(),
+		TermPurchaseOption: pr.Get("termPurchaseOption").String(),
+		TermOfferingClass:  pr.Get("termOfferingClass").String(),
+		Amounts:            amounts,
+	})
+}
+
+// Query answers a product and price filter from the bundle. The result has the
+// same shape as a Cloud Pricing API response so it can be used interchangeably
+// with the results of a GraphQL query.
+func (b *PriceBundle) Query(productFilter *schema.ProductFilter, priceFilter *schema.PriceFilter, currency string) (gjson.Result, error) {
+	products := make([]map[string]interface{}, 0)
+
+	for _, p := range b.candidates(productFilter) {
+		ok, err := b.matchProduct(p, productFilter)
+		if err != nil {
+			return gjson.Result{}, err
+		}
+		if !ok {
+			continue
+		}
+
+		prices := make([]map[string]interface{}, 0)
+		for _, pr := range p.Prices {
+			ok, err := b.matchPrice(pr, priceFilter)
+			if err != nil {
+				return gjson.Result{}, err
+			}
+			if !ok {
+				continue
+			}
+
+			prices = append(prices, map[string]interface{}{
+				"priceHash":        pr.PriceHash,
+				currency:           pr.Amounts[currency],
+				"startUsageAmount": pr.StartUsageAmount,
+				"endUsageAmount":   pr.EndUsageAmount,
+			})
+		}
+
+		products = append(products, map[string]interface{}{"prices": prices})
+	}
+
+	data, err := json.Marshal(map[string]interface{}{
+		"data": map[string]interface{}{"products": products},
+	})
+	if err != nil {
+		return gjson.Result{}, err
+	}
+
+	return gjson.ParseBytes(data), nil
+}
+
+func (b *PriceBundle) buildIndex() {
+	b.productIndex = make(map[string]*BundleProduct, len(b.Products))
+	b.productGroups = map[string]*bundleProductGroup{}
+	for _, p := range b.Products {
+		b.indexProduct(p)
+	}
+
+	b.regexCache = map[string]*regexp2.Regexp{}
+}
+
+// indexProduct adds a product to the indexes of the bundle. The caller must
+// hold b.mu unless the bundle isn't shared yet.
+func (b *PriceBundle) indexProduct(p *BundleProduct) {
+	b.productIndex[p.ProductHash] = p
+
+	key := productGroupKey(p.VendorName, p.Service, p.Region)
+	group, ok := b.productGroups[key]
+	if !ok {
+		group = &bundleProductGroup{byAttribute: map[string][]*BundleProduct{}}
+		b.productGroups[key] = group
+	}
+
+	group.products = append(group.products, p)
+	for k, v := range p.Attributes {
+		attrKey := attributeKey(k, v)
+		group.byAttribute[attrKey] = append(group.byAttribute[attrKey], p)
+	}
+}
+
+// candidates returns the products that can match the product filter. When the
+// filter has a vendor, service and region these are the products of that group,
+// narrowed down by the most selective attribute filter with an exact value.
+// Otherwise all the products are returned. The candidates still have to be
+// matched against the full filter.
+func (b *PriceBundle) candidates(f *schema.ProductFilter) []*BundleProduct {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if f == nil || f.VendorName == nil || f.Service == nil || f.Region == nil {
+		return b.Products
+	}
+
+	group, ok := b.productGroups[productGroupKey(*f.VendorName, *f.Service, *f.Region)]
+	if !ok {
+		return nil
+	}
+
+	candidates := group.products
+	for _, attr := range f.AttributeFilters {
+		// An empty value also matches products that don't have the attribute,
+		// so those filters can't narrow down the candidates.
+		if attr.ValueRegex != nil || attr.Value == nil || *attr.Value == "" {
+			continue
+		}
+
+		products := group.byAttribute[attributeKey(attr.Key, *attr.Value)]
+		if len(products) < len(candidates) {
+			candidates = products
+		}
+	}
+
+	return candidates
+}
+
+func productGroupKey(vendorName, service, region string) string {
+	return vendorName + "/" + service + "/" + region
+}
+
+func attributeKey(key, value string) string {
+	return key + "=" + value
+}
+
+func (b *PriceBundle) matchProduct(p *BundleProduct, f *schema.ProductFilter) (bool, error) {
+	if f == nil {
+		return true, nil
+	}
+
+	if !matchStrPtr(f.VendorName, p.VendorName) ||
+		!matchStrPtr(f.Service, p.Service) ||
+		!matchStrPtr(f.ProductFamily, p.ProductFamily) ||
+		!matchStrPtr(f.Region, p.Region) ||
+		!matchStrPtr(f.Sku, p.Sku) {
+		return false, nil
+	}
+
+	for _, attr := range f.AttributeFilters {
+		value, exists := p.Attributes[attr.Key]
+
+		if attr.ValueRegex != nil {
+			if !exists {
+				return false, nil
+			}
+
+			ok, err := b.matchRegex(*attr.ValueRegex, value)
+			if err != nil || !ok {
+				return false, err
+			}
+			continue
+		}
+
+		if attr.Value != nil {
+			// The API treats an empty value as "attribute is missing or empty".
+			if *attr.Value == "" && !exists {
+				continue
+			}
+
+			if value != *attr.Value {
+				return false, nil
+			}
+		}
+	}
+
+	return true, nil
+}
+
+func (b *PriceBundle) matchPrice(pr *BundlePrice, f *schema.PriceFilter) (bool, error) {
+	if f == nil {
+		return true, nil
+	}
+
+	if !matchStrPtr(f.PurchaseOption, pr.PurchaseOption) ||
+		!matchStrPtr(f.Unit, pr.Unit) ||
+		!matchStrPtr(f.Description, pr.Description) ||
+		!matchStrPtr(f.TermLength, pr.TermLength) ||
+		!matchStrPtr(f.TermPurchaseOption, pr.TermPurchaseOption) ||
+		!matchStrPtr(f.TermOfferingClass, pr.TermOfferingClass) ||
+		!matchUsageAmount(f.StartUsageAmount, pr.StartUsageAmount) ||
+		!matchUsageAmount(f.EndUsageAmount, pr.EndUsageAmount) {
+		return false, nil
+	}
+
+	if f.DescriptionRegex != nil {
+		return b.matchRegex(*f.DescriptionRegex, pr.Description)
+	}
+
+	return true, nil
+}
+
+// matchRegex matches value against a regex in the format used by the Cloud
+// Pricing API, e.g. `/^t3\.medium$/i`. A regex without delimiters is used as is.
+func (b *PriceBundle) matchRegex(pattern string, value string) (bool, error) {
+	b.mu.Lock()
+	re, ok := b.regexCache[pattern]
+	b.mu.Unlock()
+
+	if !ok {
+		expr := pattern
+		opts := regexp2.None
+
+		if strings.HasPrefix(pattern, "/") {
+			end := strings.LastIndex(pattern, "/")
+			if end > 0 {
+				expr = pattern[1:end]
+				if strings.Contains(pattern[end+1:], "i") {
+					opts |= regexp2.IgnoreCase
+				}
+			}
+		}
+
+		var err error
+		re, err = regexp2.Compile(expr, opts)
+		if err != nil {
+			return false, fmt.Errorf("Invalid regex %s in price filter: %w", pattern, err)
+		}
+
+		b.mu.Lock()
+		b.regexCache[pattern] = re
+		b.mu.Unlock()
+	}
+
+	return re.MatchString(value)
+}
+
+func matchStrPtr(filter *string, value string) bool {
+	return filter == nil || *filter == value
+}
+
+// matchUsageAmount compares tier boundaries numerically so that filters like
+// "0" match prices recorded as "0.0000000000". "Inf" only matches itself.
+func matchUsageAmount(filter *string, value string) bool {
+	if filter == nil {
+		return true
+	}
+
+	if *filter == value {
+		return true
+	}
+
+	f, err := decimal.NewFromString(*filter)
+	if err != nil {
+		return false
+	}
+
+	v, err := decimal.NewFromString(value)
+	if err != nil {
+		return false
+	}
+
+	return f.Equal(v)
+}
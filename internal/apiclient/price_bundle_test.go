@@ -0,0 +1,235 @@
+package apiclient
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func strPtr(s string) *string {
+	return &s
+}
+
+const testBundleResponse = `{
+	"data": {
+		"products": [
+			{
+				"productHash": "p1",
+				"vendorName": "aws",
+				"service": "AmazonEC2",
+				"productFamily": "Compute Instance",
+				"region": "us-east-1",
+				"sku": "SKU1",
+				"attributes": [
+					{"key": "instanceType", "value": "t3.medium"},
+					{"key": "operatingSystem", "value": "Linux"},
+					{"key": "usagetype", "value": "BoxUsage:t3.medium"}
+				],
+				"prices": [
+					{"priceHash": "h1", "purchaseOption": "on_demand", "unit": "Hrs", "description": "$0.0416 per On Demand Linux t3.medium Instance Hour", "startUsageAmount": "0", "endUsageAmount": "Inf", "USD": "0.0416", "EUR": "0.039"},
+					{"priceHash": "h2", "purchaseOption": "reserved", "unit": "Hrs", "startUsageAmount": "0", "endUsageAmount": "Inf", "termLength": "1yr", "termPurchaseOption": "No Upfront", "termOfferingClass": "standard", "USD": "0.026", "EUR": "0.024"}
+				]
+			},
+			{
+				"productHash": "p2",
+				"vendorName": "aws",
+				"service": "AmazonS3",
+				"productFamily": "Storage",
+				"region": "us-east-1",
+				"sku": "SKU2",
+				"attributes": [
+					{"key": "storageClass", "value": "General Purpose"}
+				],
+				"prices": [
+					{"priceHash": "h3", "purchaseOption": "on_demand", "unit": "GB-Mo", "description": "First 50 TB", "startUsageAmount": "0", "endUsageAmount": "51200", "USD": "0.023", "EUR": "0.021"},
+					{"priceHash": "h4", "purchaseOption": "on_demand", "unit": "GB-Mo", "description": "Next 450 TB", "startUsageAmount": "51200.0000000000", "endUsageAmount": "512000", "USD": "0.022", "EUR": "0.020"}
+				]
+			}
+		]
+	}
+}`
+
+func testBundle(t *testing.T) *PriceBundle {
+	t.Helper()
+
+	b := NewPriceBundle([]string{"USD", "EUR"})
+	b.AddProducts(gjson.Parse(testBundleResponse))
+	// Adding the same response twice should not duplicate products or prices.
+	b.AddProducts(gjson.Parse(testBundleResponse))
+
+	require.Len(t, b.Products, 2)
+	require.Len(t, b.Products[0].Prices, 2)
+
+	return b
+}
+
+func priceHashes(res gjson.Result) []string {
+	hashes := []string{}
+	for _, p := range res.Get("data.products").Array() {
+		for _, pr := range p.Get("prices").Array() {
+			hashes = append(hashes, pr.Get("priceHash").String())
+		}
+	}
+
+	return hashes
+}
+
+func TestPriceBundleQuery(t *testing.T) {
+	b := testBundle(t)
+
+	tests := []struct {
+		name          string
+		productFilter *schema.ProductFilter
+		priceFilter   *schema.PriceFilter
+		expected      []string
+	}{
+		{
+			name: "attribute value",
+			productFilter: &schema.ProductFilter{
+				VendorName: strPtr("aws"),
+				Region:     strPtr("us-east-1"),
+				AttributeFilters: []*schema.AttributeFilter{
+					{Key: "instanceType", Value: strPtr("t3.medium")},
+				},
+			},
+			priceFilter: &schema.PriceFilter{PurchaseOption: strPtr("on_demand")},
+			expected:    []string{"h1"},
+		},
+		{
+			name: "vendor, service and region with attribute values",
+			productFilter: &schema.ProductFilter{
+				VendorName: strPtr("aws"),
+				Service:    strPtr("AmazonEC2"),
+				Region:     strPtr("us-east-1"),
+				AttributeFilters: []*schema.AttributeFilter{
+					{Key: "operatingSystem", Value: strPtr("Linux")},
+					{Key: "instanceType", Value: strPtr("t3.medium")},
+					{Key: "preInstalledSw", Value: strPtr("")},
+				},
+			},
+			expected: []string{"h1", "h2"},
+		},
+		{
+			name: "vendor, service and region with unknown attribute value",
+			productFilter: &schema.ProductFilter{
+				VendorName: strPtr("aws"),
+				Service:    strPtr("AmazonEC2"),
+				Region:     strPtr("us-east-1"),
+				AttributeFilters: []*schema.AttributeFilter{
+					{Key: "instanceType", Value: strPtr("m5.large")},
+				},
+			},
+			expected: []string{},
+		},
+		{
+			name: "vendor, service and region with no products",
+			productFilter: &schema.ProductFilter{
+				VendorName: strPtr("aws"),
+				Service:    strPtr("AmazonEC2"),
+				Region:     strPtr("eu-west-1"),
+			},
+			expected: []string{},
+		},
+		{
+			name: "attribute regex with flags",
+			productFilter: &schema.ProductFilter{
+				VendorName: strPtr("aws"),
+				AttributeFilters: []*schema.AttributeFilter{
+					{Key: "usagetype", ValueRegex: strPtr("/boxusage:t3\\.medium$/i")},
+				},
+			},
+			expected: []string{"h1", "h2"},
+		},
+		{
+			name: "attribute regex with negative lookahead",
+			productFilter: &schema.ProductFilter{
+				VendorName: strPtr("aws"),
+				AttributeFilters: []*schema.AttributeFilter{
+					{Key: "usagetype", ValueRegex: strPtr("/^BoxUsage:(?!t3)/")},
+				},
+			},
+			expected: []string{},
+		},
+		{
+			name: "empty attribute value matches missing attribute",
+			productFilter: &schema.ProductFilter{
+				Service: strPtr("AmazonS3"),
+				AttributeFilters: []*schema.AttributeFilter{
+					{Key: "volumeType", Value: strPtr("")},
+				},
+			},
+			expected: []string{"h3", "h4"},
+		},
+		{
+			name: "term filters",
+			productFilter: &schema.ProductFilter{
+				Service: strPtr("AmazonEC2"),
+			},
+			priceFilter: &schema.PriceFilter{
+				TermLength:         strPtr("1yr"),
+				TermPurchaseOption: strPtr("No Upfront"),
+				TermOfferingClass:  strPtr("standard"),
+			},
+			expected: []string{"h2"},
+		},
+		{
+			name:          "description regex",
+			productFilter: &schema.ProductFilter{Service: strPtr("AmazonEC2")},
+			priceFilter:   &schema.PriceFilter{DescriptionRegex: strPtr("/on demand linux/i")},
+			expected:      []string{"h1"},
+		},
+		{
+			name:          "tier start usage amount is compared numerically",
+			productFilter: &schema.ProductFilter{Service: strPtr("AmazonS3")},
+			priceFilter:   &schema.PriceFilter{StartUsageAmount: strPtr("51200")},
+			expected:      []string{"h4"},
+		},
+		{
+			name:          "no matching product",
+			productFilter: &schema.ProductFilter{Service: strPtr("AmazonRDS")},
+			expected:      []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := b.Query(tt.productFilter, tt.priceFilter, "USD")
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, priceHashes(res))
+		})
+	}
+}
+
+func TestPriceBundleQueryCurrency(t *testing.T) {
+	b := testBundle(t)
+
+	res, err := b.Query(&schema.ProductFilter{Sku: strPtr("SKU1")}, &schema.PriceFilter{PurchaseOption: strPtr("on_demand")}, "EUR")
+	require.NoError(t, err)
+
+	prices := res.Get("data.products.0.prices").Array()
+	require.Len(t, prices, 1)
+	assert.Equal(t, "0.039", prices[0].Get("EUR").String())
+	assert.Equal(t, "Inf", prices[0].Get("endUsageAmount").String())
+}
+
+func TestPriceBundleRoundTrip(t *testing.T) {
+	b := testBundle(t)
+
+	path := filepath.Join(t.TempDir(), "bundle.json")
+	require.NoError(t, b.WriteToPath(path))
+
+	loaded, err := LoadPriceBundle(path)
+	require.NoError(t, err)
+
+	assert.True(t, loaded.HasCurrency("eur"))
+	assert.False(t, loaded.HasCurrency("GBP"))
+
+	res, err := loaded.Query(&schema.ProductFilter{Sku: strPtr("SKU2")}, nil, "USD")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"h3", "h4"}, priceHashes(res))
+}
@@ -3,8 +3,12 @@ package apiclient
 import (
 	"crypto/tls"
 	"crypto/x509"
+	"encoding/json"
 	"fmt"
 	"os"
+	"sort"
+	"strings"
+	"sync"
 
 	"github.com/infracost/infracost/internal/config"
 	"github.com/infracost/infracost/internal/schema"
@@ -13,16 +17,35 @@ import (
 	"github.com/tidwall/gjson"
 )
 
+// MaxQueriesPerBatch is the maximum number of queries sent to the Cloud
+// Pricing API in a single GraphQL request.
+const MaxQueriesPerBatch = 100
+
+// exportPartitionsPerRequest is the number of product partitions, i.e. the
+// products of a service, product family and region, requested per GraphQL
+// request when a whole vendor/region slice is recorded to a price bundle.
+const exportPartitionsPerRequest = 1
+
 var (
 	excludedEnv = map[string]struct{}{
 		"repoMetadata": {},
 	}
+
+	// cacheEvictOnce makes sure the pricing cache is only trimmed once per run.
+	cacheEvictOnce sync.Once
 )
 
 type PricingAPIClient struct {
 	APIClient
 	Currency       string
 	EventsDisabled bool
+
+	// bundle is set when prices are resolved from a local price bundle
+	// instead of the Cloud Pricing API.
+	bundle    *PriceBundle
+	bundleErr error
+
+	cache *PricingCache
 }
 
 type PriceQueryKey struct {
@@ -37,7 +60,8 @@ type PriceQueryResult struct {
 
 func NewPricingAPIClient(ctx *config.RunContext) *PricingAPIClient {
 	currency := ctx.Config.Currency
-	if currency == "" {
+	if currency == "" || ctx.Config.FXRatesFile != "" {
+		// Prices are converted from USD with the local exchange rates if they're set
 		currency = "USD"
 	}
 
@@ -69,7 +93,7 @@ func NewPricingAPIClient(ctx *config.RunContext) *PricingAPIClient {
 		tlsConfig.InsecureSkipVerify = *ctx.Config.TLSInsecureSkipVerify
 	}
 
-	return &PricingAPIClient{
+	c := &PricingAPIClient{
 		APIClient: APIClient{
 			endpoint:  ctx.Config.PricingAPIEndpoint,
 			apiKey:    ctx.Config.APIKey,
@@ -79,6 +103,19 @@ func NewPricingAPIClient(ctx *config.RunContext) *PricingAPIClient {
 		Currency:       currency,
 		EventsDisabled: ctx.Config.EventsDisabled,
 	}
+
+	if ctx.Config.PricingBundleFile != "" {
+		// Offline runs must not make any requests, including events.
+		c.EventsDisabled = true
+		c.bundle, c.bundleErr = LoadPriceBundle(ctx.Config.PricingBundleFile)
+		if c.bundleErr == nil && !c.bundle.HasCurrency(currency) {
+			c.bundleErr = fmt.Errorf("Price bundle %s does not contain prices in %s, it was exported with: %s", ctx.Config.PricingBundleFile, currency, strings.Join(c.bundle.Currencies, ", "))
+		}
+	} else if !ctx.Config.NoCache && !ctx.Config.PricingCacheDisabled {
+		c.cache = NewPricingCache(ctx.Config.CachePath(), ctx.Config.PricingCacheTTL, ctx.Config.PricingCacheMaxSizeMB*1024*1024)
+	}
+
+	return c
 }
 
 func (c *PricingAPIClient) AddEvent(name string, env map[string]interface{}) error {
@@ -112,9 +149,9 @@ func (c *PricingAPIClient) RunQueries(r *schema.Resource) ([]PriceQueryResult, e
 		return []PriceQueryResult{}, nil
 	}
 
-	log.Debugf("Getting pricing details from %s for %s", c.endpoint, r.Name)
+	log.Debugf("Getting pricing details for %s", r.Name)
 
-	results, err := c.doQueries(queries)
+	results, err := c.PerformQueries(queries)
 	if err != nil {
 		return []PriceQueryResult{}, err
 	}
@@ -122,6 +159,339 @@ func (c *PricingAPIClient) RunQueries(r *schema.Resource) ([]PriceQueryResult, e
 	return c.zipQueryResults(keys, results), nil
 }
 
+// BatchQueries returns the price queries for all the cost components of the
+// resources, including their sub-resources. Skipped resources are ignored.
+// The keys and queries are returned in the same order.
+func (c *PricingAPIClient) BatchQueries(resources []*schema.Resource) ([]PriceQueryKey, []GraphQLQuery) {
+	keys := make([]PriceQueryKey, 0)
+	queries := make([]GraphQLQuery, 0)
+
+	for _, r := range resources {
+		if r.IsSkipped {
+			continue
+		}
+
+		rKeys, rQueries := c.batchQueries(r)
+		keys = append(keys, rKeys...)
+		queries = append(queries, rQueries...)
+	}
+
+	return keys, queries
+}
+
+// DedupeQueries removes queries that have the same product and price filters.
+// It returns the unique queries and, for each of the input queries, the index
+// of the unique query that answers it.
+func DedupeQueries(queries []GraphQLQuery) ([]GraphQLQuery, []int) {
+	unique := make([]GraphQLQuery, 0)
+	indexes := make([]int, len(queries))
+	seen := make(map[string]int)
+
+	for i, q := range queries {
+		fingerprint := queryFingerprint(q)
+
+		if j, ok := seen[fingerprint]; ok {
+			indexes[i] = j
+			continue
+		}
+
+		seen[fingerprint] = len(unique)
+		indexes[i] = len(unique)
+		unique = append(unique, q)
+	}
+
+	return unique, indexes
+}
+
+// SplitQueries splits the queries into batches of at most size queries.
+func SplitQueries(queries []GraphQLQuery, size int) [][]GraphQLQuery {
+	batches := make([][]GraphQLQuery, 0, len(queries)/size+1)
+
+	for i := 0; i < len(queries); i += size {
+		end := i + size
+		if end > len(queries) {
+			end = len(queries)
+		}
+
+		batches = append(batches, queries[i:end])
+	}
+
+	return batches
+}
+
+// ZipQueryResults pairs the keys returned by BatchQueries with the results of their queries.
+func (c *PricingAPIClient) ZipQueryResults(keys []PriceQueryKey, results []gjson.Result) []PriceQueryResult {
+	return c.zipQueryResults(keys, results)
+}
+
+// PerformQueries returns the results of the queries in the same order. The
+// results come from the price bundle if one is configured, otherwise from the
+// Cloud Pricing API, using the pricing cache for any queries that have been
+// run recently.
+func (c *PricingAPIClient) PerformQueries(queries []GraphQLQuery) ([]gjson.Result, error) {
+	if c.bundle != nil || c.bundleErr != nil {
+		return c.runBundleQueries(queries)
+	}
+
+	if c.cache == nil {
+		return c.doQueries(queries)
+	}
+
+	results := make([]gjson.Result, len(queries))
+	keys := make([]string, len(queries))
+	missIndexes := make([]int, 0)
+	missQueries := make([]GraphQLQuery, 0)
+
+	for i, q := range queries {
+		keys[i] = c.cache.Key(c.endpoint, c.Currency, q)
+
+		if res, ok := c.cache.Get(keys[i]); ok {
+			results[i] = res
+			continue
+		}
+
+		missIndexes = append(missIndexes, i)
+		missQueries = append(missQueries, q)
+	}
+
+	log.Debugf("Pricing cache: %d hits, %d misses", len(queries)-len(missQueries), len(missQueries))
+
+	if len(missQueries) == 0 {
+		return results, nil
+	}
+
+	fetched, err := c.doQueries(missQueries)
+	if err != nil {
+		return []gjson.Result{}, err
+	}
+
+	for j, i := range missIndexes {
+		if j >= len(fetched) {
+			break
+		}
+
+		results[i] = fetched[j]
+
+		// Don't cache responses that contain GraphQL errors.
+		if fetched[j].Get("data").Exists() && !fetched[j].Get("errors").Exists() {
+			c.cache.Set(keys[i], fetched[j])
+		}
+	}
+
+	cacheEvictOnce.Do(c.cache.Evict)
+
+	return results, nil
+}
+
+func (c *PricingAPIClient) runBundleQueries(queries []GraphQLQuery) ([]gjson.Result, error) {
+	if c.bundleErr != nil {
+		return []gjson.Result{}, c.bundleErr
+	}
+
+	results := make([]gjson.Result, 0, len(queries))
+	for _, q := range queries {
+		productFilter, priceFilter := queryFilters(q)
+
+		res, err := c.bundle.Query(productFilter, priceFilter, c.Currency)
+		if err != nil {
+			return []gjson.Result{}, err
+		}
+
+		results = append(results, res)
+	}
+
+	return results, nil
+}
+
+// queryFilters returns the product and price filters of a query built by buildQuery.
+func queryFilters(q GraphQLQuery) (*schema.ProductFilter, *schema.PriceFilter) {
+	productFilter, _ := q.Variables["productFilter"].(*schema.ProductFilter)
+	priceFilter, _ := q.Variables["priceFilter"].(*schema.PriceFilter)
+
+	return productFilter, priceFilter
+}
+
+// queryFingerprint returns a string that identifies the product and price
+// filters of a query. Attribute filters are sorted so that queries which only
+// differ in filter order have the same fingerprint.
+func queryFingerprint(q GraphQLQuery) string {
+	productFilter, priceFilter := queryFilters(q)
+
+	if productFilter != nil {
+		normalized := *productFilter
+		normalized.AttributeFilters = append([]*schema.AttributeFilter{}, productFilter.AttributeFilters...)
+		sort.SliceStable(normalized.AttributeFilters, func(i, j int) bool {
+			return normalized.AttributeFilters[i].Key < normalized.AttributeFilters[j].Key
+		})
+		productFilter = &normalized
+	}
+
+	b, _ := json.Marshal(map[string]interface{}{
+		"productFilter": productFilter,
+		"priceFilter":   priceFilter,
+	})
+
+	return string(b)
+}
+
+// RecordQueries runs the price queries for all the resources using the full
+// export query and adds the returned products and prices to the bundle.
+func (c *PricingAPIClient) RecordQueries(resources []*schema.Resource, bundle *PriceBundle) error {
+	_, queries := c.BatchQueries(resources)
+	unique, _ := DedupeQueries(queries)
+
+	exportQueries := make([]GraphQLQuery, 0, len(unique))
+	for _, q := range unique {
+		productFilter, priceFilter := queryFilters(q)
+		exportQueries = append(exportQueries, c.buildExportQuery(productFilter, priceFilter, bundle.Currencies))
+	}
+
+	return c.recordBatched(exportQueries, bundle, MaxQueriesPerBatch)
+}
+
+// RecordProducts adds all the products matching the filter, with all of their
+// prices, to the bundle. This is used to export a whole vendor/region slice.
+// The Cloud Pricing API doesn't paginate products, so the service, product
+// family and region of the matching products are listed first and the products
+// are then requested one partition at a time.
+func (c *PricingAPIClient) RecordProducts(filter *schema.ProductFilter, bundle *PriceBundle) error {
+	results, err := c.doQueries([]GraphQLQuery{c.buildPartitionQuery(filter)})
+	if err != nil {
+		return err
+	}
+
+	if len(results) == 0 {
+		return nil
+	}
+
+	if results[0].Get("errors").Exists() {
+		return fmt.Errorf("graphql error: %s", results[0].Get("errors").String())
+	}
+
+	partitions := productPartitions(filter, results[0])
+	log.Debugf("Recording %d product partitions from %s", len(partitions), c.endpoint)
+
+	queries := make([]GraphQLQuery, 0, len(partitions))
+	for _, partition := range partitions {
+		queries = append(queries, c.buildExportQuery(partition, nil, bundle.Currencies))
+	}
+
+	return c.recordBatched(queries, bundle, exportPartitionsPerRequest)
+}
+
+// productPartitions returns a product filter for each unique service, product
+// family and region in the response of a partition query. An empty product
+// family or region is left as it is in filter, since the field may not be set
+// on the products at all.
+func productPartitions(filter *schema.ProductFilter, res gjson.Result) []*schema.ProductFilter {
+	seen := map[string]bool{}
+	partitions := []*schema.ProductFilter{}
+
+	for _, p := range res.Get("data.products").Array() {
+		service := p.Get("service").String()
+		productFamily := p.Get("productFamily").String()
+		region := p.Get("region").String()
+
+		key := productGroupKey(service, productFamily, region)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		partition := *filter
+		partition.Service = &service
+		if productFamily != "" {
+			partition.ProductFamily = &productFamily
+		}
+		if region != "" {
+			partition.Region = &region
+		}
+
+		partitions = append(partitions, &partition)
+	}
+
+	return partitions
+}
+
+func (c *PricingAPIClient) recordBatched(queries []GraphQLQuery, bundle *PriceBundle, batchSize int) error {
+	for _, batch := range SplitQueries(queries, batchSize) {
+		log.Debugf("Recording %d price queries from %s", len(batch), c.endpoint)
+
+		results, err := c.doQueries(batch)
+		if err != nil {
+			return err
+		}
+
+		for _, res := range results {
+			if res.Get("errors").Exists() {
+				return fmt.Errorf("graphql error: %s", res.Get("errors").String())
+			}
+
+			bundle.AddProducts(res)
+		}
+	}
+
+	return nil
+}
+
+// buildPartitionQuery builds a query that only returns the fields used to
+// partition the products matching the product filter.
+func (c *PricingAPIClient) buildPartitionQuery(product *schema.ProductFilter) GraphQLQuery {
+	v := map[string]interface{}{}
+	v["productFilter"] = product
+
+	query := `
+		query($productFilter: ProductFilter!) {
+			products(filter: $productFilter) {
+				service
+				productFamily
+				region
+			}
+		}
+	`
+
+	return GraphQLQuery{query, v}
+}
+
+// buildExportQuery builds a query that returns all the fields needed to answer
+// the same product and price filters from a PriceBundle.
+func (c *PricingAPIClient) buildExportQuery(product *schema.ProductFilter, price *schema.PriceFilter, currencies []string) GraphQLQuery {
+	v := map[string]interface{}{}
+	v["productFilter"] = product
+	v["priceFilter"] = price
+
+	query := fmt.Sprintf(`
+		query($productFilter: ProductFilter!, $priceFilter: PriceFilter) {
+			products(filter: $productFilter) {
+				productHash
+				vendorName
+				service
+				productFamily
+				region
+				sku
+				attributes {
+					key
+					value
+				}
+				prices(filter: $priceFilter) {
+					priceHash
+					purchaseOption
+					unit
+					description
+					startUsageAmount
+					endUsageAmount
+					termLength
+					termPurchaseOption
+					termOfferingClass
+					%s
+				}
+			}
+		}
+	`, strings.Join(currencies, "\n"))
+
+	return GraphQLQuery{query, v}
+}
+
 func (c *PricingAPIClient) buildQuery(product *schema.ProductFilter, price *schema.PriceFilter) GraphQLQuery {
 	v := map[string]interface{}{}
 	v["productFilter"] = product
@@ -0,0 +1,176 @@
+package apiclient
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/tidwall/gjson"
+)
+
+var (
+	pricingCacheVersion = "0.1"
+	pricingCacheDirName = "pricing-cache"
+	// evictionLockMaxAge is how long an eviction lock is respected before it is
+	// assumed to have been left behind by a process that was killed.
+	evictionLockMaxAge = time.Minute
+)
+
+// PricingCache is a content-addressed on-disk cache of Cloud Pricing API query
+// results. Each entry is stored in its own file named by the hash of the
+// normalized query, so multiple infracost processes can share the cache: entries
+// are written to a temporary file and renamed into place, and a missing or
+// partially removed entry is treated as a cache miss.
+type PricingCache struct {
+	dir     string
+	ttl     time.Duration
+	maxSize int64
+}
+
+// NewPricingCache returns a cache that stores entries in the pricing-cache
+// directory under infracostDir. Entries older than ttl are ignored and the oldest
+// entries are evicted when the cache grows beyond maxSize bytes.
+func NewPricingCache(infracostDir string, ttl time.Duration, maxSize int64) *PricingCache {
+	return &PricingCache{
+		dir:     filepath.Join(infracostDir, pricingCacheDirName),
+		ttl:     ttl,
+		maxSize: maxSize,
+	}
+}
+
+// Key returns the content address for a query. Queries whose filters only
+// differ in the order of their attribute filters share the same entry.
+func (c *PricingCache) Key(endpoint, currency string, q GraphQLQuery) string {
+	sum := sha256.Sum256([]byte(strings.Join([]string{pricingCacheVersion, endpoint, currency, queryFingerprint(q)}, "\n")))
+
+	return hex.EncodeToString(sum[:])
+}
+
+// Get returns the cached result for key. The second return value is false if
+// the entry does not exist, has expired or can't be read.
+func (c *PricingCache) Get(key string) (gjson.Result, bool) {
+	p := c.entryPath(key)
+
+	info, err := os.Stat(p)
+	if err != nil {
+		return gjson.Result{}, false
+	}
+
+	if time.Since(info.ModTime()) > c.ttl {
+		return gjson.Result{}, false
+	}
+
+	data, err := os.ReadFile(p)
+	if err != nil || !gjson.ValidBytes(data) {
+		return gjson.Result{}, false
+	}
+
+	return gjson.ParseBytes(data), true
+}
+
+// Set stores the result for key. Errors are logged and otherwise ignored since
+// the cache is only an optimization.
+func (c *PricingCache) Set(key string, result gjson.Result) {
+	p := c.entryPath(key)
+
+	err := os.MkdirAll(filepath.Dir(p), 0700)
+	if err != nil {
+		log.Debugf("Couldn't create pricing cache directory: %v", err)
+		return
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(p), key+".*.tmp")
+	if err != nil {
+		log.Debugf("Failed to write pricing cache entry: %v", err)
+		return
+	}
+
+	_, err = tmp.WriteString(result.Raw)
+	closeErr := tmp.Close()
+	if err != nil || closeErr != nil {
+		log.Debugf("Failed to write pricing cache entry: %v %v", err, closeErr)
+		_ = os.Remove(tmp.Name())
+		return
+	}
+
+	err = os.Rename(tmp.Name(), p)
+	if err != nil {
+		log.Debugf("Failed to write pricing cache entry: %v", err)
+		_ = os.Remove(tmp.Name())
+	}
+}
+
+// Evict removes expired entries and, if the cache is still larger than the
+// size cap, the least recently written entries until it is under the cap. Only
+// one process evicts at a time, other processes skip eviction.
+func (c *PricingCache) Evict() {
+	lockPath := filepath.Join(c.dir, "evict.lock")
+
+	if info, err := os.Stat(lockPath); err == nil {
+		if time.Since(info.ModTime()) < evictionLockMaxAge {
+			return
+		}
+		_ = os.Remove(lockPath)
+	}
+
+	lock, err := os.OpenFile(lockPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+	if err != nil {
+		return
+	}
+	_ = lock.Close()
+	defer os.Remove(lockPath)
+
+	type entry struct {
+		path    string
+		size    int64
+		modTime time.Time
+	}
+
+	var entries []entry
+	var total int64
+
+	_ = filepath.Walk(c.dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() || filepath.Ext(path) != ".json" {
+			return nil
+		}
+
+		if time.Since(info.ModTime()) > c.ttl {
+			_ = os.Remove(path)
+			return nil
+		}
+
+		entries = append(entries, entry{path, info.Size(), info.ModTime()})
+		total += info.Size()
+
+		return nil
+	})
+
+	if total <= c.maxSize {
+		return
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].modTime.Before(entries[j].modTime)
+	})
+
+	for _, e := range entries {
+		if total <= c.maxSize {
+			break
+		}
+
+		if os.Remove(e.path) == nil {
+			total -= e.size
+		}
+	}
+
+	log.Debugf("Evicted pricing cache entries, cache size is now %d bytes", total)
+}
+
+func (c *PricingCache) entryPath(key string) string {
+	return filepath.Join(c.dir, key[:2], key+".json")
+}
@@ -0,0 +1,90 @@
+package apiclient
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func testCacheQuery(attrs ...*schema.AttributeFilter) GraphQLQuery {
+	return GraphQLQuery{
+		Query: "query",
+		Variables: map[string]interface{}{
+			"productFilter": &schema.ProductFilter{
+				VendorName:       strPtr("aws"),
+				AttributeFilters: attrs,
+			},
+			"priceFilter": &schema.PriceFilter{PurchaseOption: strPtr("on_demand")},
+		},
+	}
+}
+
+func TestPricingCacheKey(t *testing.T) {
+	c := NewPricingCache(t.TempDir(), time.Hour, 1024)
+
+	a := &schema.AttributeFilter{Key: "instanceType", Value: strPtr("t3.medium")}
+	b := &schema.AttributeFilter{Key: "operatingSystem", Value: strPtr("Linux")}
+
+	k1 := c.Key("https://pricing.api.infracost.io", "USD", testCacheQuery(a, b))
+	k2 := c.Key("https://pricing.api.infracost.io", "USD", testCacheQuery(b, a))
+	assert.Equal(t, k1, k2, "attribute filter order should not change the key")
+
+	k3 := c.Key("https://pricing.api.infracost.io", "EUR", testCacheQuery(a, b))
+	assert.NotEqual(t, k1, k3, "currency should change the key")
+
+	k4 := c.Key("https://pricing.example.com", "USD", testCacheQuery(a, b))
+	assert.NotEqual(t, k1, k4, "endpoint should change the key")
+}
+
+func TestPricingCacheGetSet(t *testing.T) {
+	c := NewPricingCache(t.TempDir(), time.Hour, 1024*1024)
+	key := c.Key("", "USD", testCacheQuery())
+
+	_, ok := c.Get(key)
+	assert.False(t, ok)
+
+	c.Set(key, gjson.Parse(`{"data":{"products":[]}}`))
+
+	res, ok := c.Get(key)
+	require.True(t, ok)
+	assert.True(t, res.Get("data.products").Exists())
+
+	old := time.Now().Add(-2 * time.Hour)
+	require.NoError(t, os.Chtimes(c.entryPath(key), old, old))
+
+	_, ok = c.Get(key)
+	assert.False(t, ok, "expired entries should be a cache miss")
+}
+
+func TestPricingCacheEvict(t *testing.T) {
+	dir := t.TempDir()
+	c := NewPricingCache(dir, time.Hour, 60)
+
+	keys := []string{}
+	for i, attr := range []string{"a", "b", "c"} {
+		key := c.Key("", "USD", testCacheQuery(&schema.AttributeFilter{Key: attr, Value: strPtr(attr)}))
+		c.Set(key, gjson.Parse(`{"data":{"products":[{"prices":[]}]}}`))
+
+		mod := time.Now().Add(time.Duration(i-3) * time.Minute)
+		require.NoError(t, os.Chtimes(c.entryPath(key), mod, mod))
+		keys = append(keys, key)
+	}
+
+	c.Evict()
+
+	_, ok := c.Get(keys[0])
+	assert.False(t, ok, "oldest entry should be evicted")
+
+	_, ok = c.Get(keys[2])
+	assert.True(t, ok, "newest entry should be kept")
+
+	_, err := os.Stat(filepath.Join(dir, pricingCacheDirName, "evict.lock"))
+	assert.True(t, os.IsNotExist(err), "eviction lock should be removed")
+}
@@ -0,0 +1,136 @@
+package apiclient
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func TestDedupeQueries(t *testing.T) {
+	c := &PricingAPIClient{Currency: "USD"}
+
+	a := &schema.AttributeFilter{Key: "instanceType", Value: strPtr("t3.medium")}
+	b := &schema.AttributeFilter{Key: "operatingSystem", Value: strPtr("Linux")}
+	onDemand := &schema.PriceFilter{PurchaseOption: strPtr("on_demand")}
+
+	queries := []GraphQLQuery{
+		c.buildQuery(&schema.ProductFilter{AttributeFilters: []*schema.AttributeFilter{a, b}}, onDemand),
+		c.buildQuery(&schema.ProductFilter{AttributeFilters: []*schema.AttributeFilter{b, a}}, onDemand),
+		c.buildQuery(&schema.ProductFilter{AttributeFilters: []*schema.AttributeFilter{a}}, onDemand),
+		c.buildQuery(&schema.ProductFilter{AttributeFilters: []*schema.AttributeFilter{a, b}}, &schema.PriceFilter{PurchaseOption: strPtr("reserved")}),
+		c.buildQuery(&schema.ProductFilter{AttributeFilters: []*schema.AttributeFilter{a}}, &schema.PriceFilter{PurchaseOption: strPtr("on_demand")}),
+	}
+
+	unique, indexes := DedupeQueries(queries)
+
+	assert.Len(t, unique, 3)
+	assert.Equal(t, []int{0, 0, 1, 2, 1}, indexes)
+}
+
+func TestSplitQueries(t *testing.T) {
+	queries := make([]GraphQLQuery, 250)
+
+	batches := SplitQueries(queries, 100)
+
+	assert.Len(t, batches, 3)
+	assert.Len(t, batches[0], 100)
+	assert.Len(t, batches[2], 50)
+	assert.Len(t, SplitQueries([]GraphQLQuery{}, 100), 0)
+}
+
+// assertQueryVariablesDeclared checks that every variable used in a GraphQL
+// query is declared by the query, which the Cloud Pricing API requires.
+func assertQueryVariablesDeclared(t *testing.T, query string) {
+	t.Helper()
+
+	header, body, ok := strings.Cut(query, "{")
+	require.True(t, ok, "query has no selection set: %s", query)
+
+	declared := map[string]bool{}
+	for _, m := range regexp.MustCompile(`\$(\w+)\s*:`).FindAllStringSubmatch(header, -1) {
+		declared[m[1]] = true
+	}
+
+	for _, m := range regexp.MustCompile(`\$(\w+)`).FindAllStringSubmatch(body, -1) {
+		assert.True(t, declared[m[1]], "variable $%s is used but not declared in query: %s", m[1], query)
+	}
+}
+
+func TestRecordProductsPartitions(t *testing.T) {
+	var requests int
+	var partitions []string
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+
+		var queries []GraphQLQuery
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&queries))
+
+		results := make([]string, 0, len(queries))
+		for _, q := range queries {
+			assertQueryVariablesDeclared(t, q.Query)
+
+			filter := q.Variables["productFilter"].(map[string]interface{})
+			if !strings.Contains(q.Query, "prices") {
+				results = append(results, `{"data": {"products": [
+					{"service": "AmazonEC2", "productFamily": "Compute Instance", "region": "us-east-1"},
+					{"service": "AmazonEC2", "productFamily": "Compute Instance", "region": "us-east-1"},
+					{"service": "AmazonEC2", "productFamily": "Storage", "region": "us-east-1"},
+					{"service": "AWSDataTransfer", "productFamily": "Data Transfer", "region": ""}
+				]}}`)
+				continue
+			}
+
+			partition := fmt.Sprintf("%v/%v/%v", filter["service"], filter["productFamily"], filter["region"])
+			partitions = append(partitions, partition)
+			results = append(results, fmt.Sprintf(`{"data": {"products": [{"productHash": "%s", "vendorName": "aws", "prices": [{"priceHash": "h-%s", "USD": "0.1"}]}]}}`, partition, partition))
+		}
+
+		fmt.Fprintf(w, "[%s]", strings.Join(results, ","))
+	}))
+	defer srv.Close()
+
+	c := &PricingAPIClient{APIClient: APIClient{endpoint: srv.URL}, Currency: "USD"}
+	b := NewPriceBundle([]string{"USD"})
+
+	err := c.RecordProducts(&schema.ProductFilter{VendorName: strPtr("aws")}, b)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		"AmazonEC2/Compute Instance/us-east-1",
+		"AmazonEC2/Storage/us-east-1",
+		"AWSDataTransfer/Data Transfer/<nil>",
+	}, partitions)
+	assert.Equal(t, 4, requests)
+	assert.Len(t, b.Products, 3)
+}
+
+func TestExportQueriesDeclareVariables(t *testing.T) {
+	c := &PricingAPIClient{Currency: "USD"}
+	filter := &schema.ProductFilter{VendorName: strPtr("aws")}
+
+	assertQueryVariablesDeclared(t, c.buildPartitionQuery(filter).Query)
+	assertQueryVariablesDeclared(t, c.buildExportQuery(filter, nil, []string{"USD", "EUR"}).Query)
+	assertQueryVariablesDeclared(t, c.buildQuery(filter, nil).Query)
+}
+
+func TestRecordProductsGraphQLError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"errors": [{"message": "Unknown argument"}]}]`)
+	}))
+	defer srv.Close()
+
+	c := &PricingAPIClient{APIClient: APIClient{endpoint: srv.URL}, Currency: "USD"}
+
+	err := c.RecordProducts(&schema.ProductFilter{VendorName: strPtr("aws")}, NewPriceBundle([]string{"USD"}))
+	assert.ErrorContains(t, err, "graphql error")
+}
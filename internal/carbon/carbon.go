@@ -0,0 +1,298 @@
+// Package carbon estimates the carbon emissions of cloud resources from an
+// embedded dataset of emissions coefficients.
+package carbon
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+//go:embed coefficients.json
+var coefficientsJSON []byte
+
+var (
+	loadedCoefficients    *Coefficients
+	loadedCoefficientsErr error
+	loadCoefficientsOnce  sync.Once
+)
+
+// Coefficients are the values used to convert the usage of cost components
+// to estimated emissions. Energy is in kWh and grid intensities are in kgCO2e
+// per kWh.
+type Coefficients struct {
+	Version              string                          `json:"version"`
+	Source               string                          `json:"source"`
+	StorageKWhPerGBMonth map[string]float64              `json:"storageKWhPerGBMonth"`
+	NetworkKWhPerGB      float64                         `json:"networkKWhPerGB"`
+	Providers            map[string]ProviderCoefficients `json:"providers"`
+}
+
+// ProviderCoefficients are the coefficients of a cloud provider. The grid
+// intensities are keyed by region and the instance family power is the
+// average power draw of a vCPU in watts.
+type ProviderCoefficients struct {
+	PUE                        float64            `json:"pue"`
+	DefaultGridIntensity       float64            `json:"defaultGridIntensity"`
+	GridIntensity              map[string]float64 `json:"gridIntensity"`
+	DefaultWattsPerVCPU        float64            `json:"defaultWattsPerVCPU"`
+	InstanceFamilyWattsPerVCPU map[string]float64 `json:"instanceFamilyWattsPerVCPU"`
+}
+
+// LoadCoefficients returns the embedded coefficients. They are only parsed
+// once per run.
+func LoadCoefficients() (*Coefficients, error) {
+	loadCoefficientsOnce.Do(func() {
+		var c Coefficients
+		if err := json.Unmarshal(coefficientsJSON, &c); err != nil {
+			loadedCoefficientsErr = fmt.Errorf("error parsing carbon coefficients: %w", err)
+			return
+		}
+
+		loadedCoefficients = &c
+	})
+
+	return loadedCoefficients, loadedCoefficientsErr
+}
+
+// CoefficientsVersion returns the version of the embedded coefficients, or an
+// empty string if they can't be loaded.
+func CoefficientsVersion() string {
+	c, err := LoadCoefficients()
+	if err != nil {
+		return ""
+	}
+
+	return c.Version
+}
+
+// Estimate sets the carbon intensity of the compute, storage and network cost
+// components of the resources and their subresources. The monthly emissions
+// are calculated from it along with the costs.
+func Estimate(resources []*schema.Resource) error {
+	c, err := LoadCoefficients()
+	if err != nil {
+		return err
+	}
+
+	for _, r := range resources {
+		c.estimateResource(r)
+	}
+
+	return nil
+}
+
+func (c *Coefficients) estimateResource(r *schema.Resource) {
+	for _, cc := range r.CostComponents {
+		region := r.Region
+		if region == "" && cc.ProductFilter != nil && cc.ProductFilter.Region != nil {
+			region = *cc.ProductFilter.Region
+		}
+
+		if intensity := c.Intensity(region, cc); intensity != nil {
+			cc.SetCarbonIntensity(*intensity)
+		}
+	}
+
+	for _, s := range r.SubResources {
+		c.estimateResource(s)
+	}
+}
+
+// Intensity returns the estimated emissions in kgCO2e per unit of the monthly
+// quantity of the cost component, or nil if the cost component isn't for
+// compute, storage or network usage of a supported provider.
+func (c *Coefficients) Intensity(region string, cc *schema.CostComponent) *decimal.Decimal {
+	if cc.ProductFilter == nil || cc.ProductFilter.VendorName == nil {
+		return nil
+	}
+
+	p, ok := c.Providers[*cc.ProductFilter.VendorName]
+	if !ok {
+		return nil
+	}
+
+	var kWh float64
+	switch {
+	case cc.Unit == "hours":
+		instanceType := instanceTypeAttribute(cc.ProductFilter)
+		if instanceType == "" {
+			return nil
+		}
+
+		family, vCPUs := parseInstanceType(*cc.ProductFilter.VendorName, instanceType)
+		if vCPUs == 0 {
+			return nil
+		}
+
+		watts, ok := p.InstanceFamilyWattsPerVCPU[family]
+		if !ok {
+			watts = p.DefaultWattsPerVCPU
+		}
+
+		kWh = vCPUs * watts / 1000
+	case cc.Unit == "GB" && isNetworkComponent(cc.Name):
+		kWh = c.NetworkKWhPerGB
+	case cc.Unit == "GB" && isStorageComponent(cc.Name):
+		kWh = c.StorageKWhPerGBMonth[storageType(cc.Name)]
+	default:
+		return nil
+	}
+
+	gridIntensity, ok := p.GridIntensity[region]
+	if !ok {
+		gridIntensity = p.DefaultGridIntensity
+	}
+
+	intensity := decimal.NewFromFloat(kWh * p.PUE * gridIntensity)
+	return &intensity
+}
+
+var instanceTypeAttributes = []string{"instanceType", "armSkuName", "machineType"}
+
+// instanceTypeAttribute returns the instance type the product filter matches,
+// stripping any regex anchors and flags from it.
+func instanceTypeAttribute(f *schema.ProductFilter) string {
+	for _, a := range f.AttributeFilters {
+		if !contains(instanceTypeAttributes, a.Key) {
+			continue
+		}
+
+		if a.Value != nil {
+			return *a.Value
+		}
+
+		if a.ValueRegex != nil {
+			v := strings.TrimSuffix(*a.ValueRegex, "/i")
+			v = strings.ReplaceAll(strings.Trim(v, "/^$"), `\.`, ".")
+			if !strings.ContainsAny(v, `\*+?()[]{}|`) {
+				return v
+			}
+		}
+	}
+
+	return ""
+}
+
+var (
+	awsSizeRegex   = regexp.MustCompile(`^(\d*)xlarge$`)
+	azureSizeRegex = regexp.MustCompile(`^([a-z]+)(\d+)`)
+	gcpSizes       = map[string]float64{"micro": 0.25, "small": 0.5, "medium": 1}
+	awsSizes       = map[string]float64{"nano": 1, "micro": 1, "small": 1, "medium": 1, "large": 2, "metal": 96}
+)
+
+// parseInstanceType returns the family and the number of vCPUs of the
+// instance type, using the naming conventions of each provider. The number
+// of vCPUs is zero if it can't be worked out from the name.
+func parseInstanceType(provider, instanceType string) (string, float64) {
+	instanceType = strings.ToLower(instanceType)
+
+	switch provider {
+	case "aws":
+		// e.g. m5.xlarge, db.t3.medium or cache.r6g.2xlarge
+		parts := strings.Split(instanceType, ".")
+		if len(parts) < 2 {
+			return "", 0
+		}
+
+		family, size := parts[len(parts)-2], parts[len(parts)-1]
+		if vCPUs, ok := awsSizes[size]; ok {
+			return family, vCPUs
+		}
+
+		m := awsSizeRegex.FindStringSubmatch(size)
+		if m == nil {
+			return family, 0
+		}
+
+		multiplier := 1
+		if m[1] != "" {
+			multiplier, _ = strconv.Atoi(m[1])
+		}
+
+		return family, float64(4 * multiplier)
+	case "azure":
+		// e.g. Standard_D4s_v3 or Basic_A1
+		name := instanceType
+		if i := strings.Index(name, "_"); i != -1 {
+			name = name[i+1:]
+		}
+
+		m := azureSizeRegex.FindStringSubmatch(name)
+		if m == nil {
+			return "", 0
+		}
+
+		vCPUs, _ := strconv.Atoi(m[2])
+		family := m[1]
+		if suffix := strings.TrimPrefix(name, m[0]); strings.HasPrefix(suffix, "a") || strings.HasPrefix(suffix, "p") {
+			family += suffix[:1]
+		}
+
+		return family, float64(vCPUs)
+	case "gcp":
+		// e.g. n1-standard-4 or e2-micro
+		parts := strings.Split(instanceType, "-")
+		if len(parts) < 2 {
+			return "", 0
+		}
+
+		family, size := parts[0], parts[len(parts)-1]
+		if vCPUs, ok := gcpSizes[size]; ok {
+			return family, vCPUs
+		}
+
+		vCPUs, err := strconv.Atoi(size)
+		if err != nil {
+			return family, 0
+		}
+
+		return family, float64(vCPUs)
+	}
+
+	return "", 0
+}
+
+func isNetworkComponent(name string) bool {
+	return containsAny(strings.ToLower(name), "data transfer", "egress", "outbound", "internet")
+}
+
+func isStorageComponent(name string) bool {
+	return containsAny(strings.ToLower(name), "storage", "snapshot", "backup", "disk")
+}
+
+func storageType(name string) string {
+	if containsAny(strings.ToLower(name), "hdd", "magnetic", "cold", "standard") {
+		return "hdd"
+	}
+
+	return "ssd"
+}
+
+func containsAny(s string, substrs ...string) bool {
+	for _, substr := range substrs {
+		if strings.Contains(s, substr) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func contains(arr []string, e string) bool {
+	for _, a := range arr {
+		if a == e {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,133 @@
+package carbon
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func strPtr(s string) *string {
+	return &s
+}
+
+func TestParseInstanceType(t *testing.T) {
+	tests := []struct {
+		provider     string
+		instanceType string
+		family       string
+		vCPUs        float64
+	}{
+		{"aws", "m5.xlarge", "m5", 4},
+		{"aws", "m5.4xlarge", "m5", 16},
+		{"aws", "t3.large", "t3", 2},
+		{"aws", "db.r6g.2xlarge", "r6g", 8},
+		{"aws", "m5", "", 0},
+		{"azure", "Standard_D4s_v3", "d", 4},
+		{"azure", "Standard_D8as_v4", "da", 8},
+		{"azure", "Basic_A1", "a", 1},
+		{"gcp", "n1-standard-4", "n1", 4},
+		{"gcp", "e2-micro", "e2", 0.25},
+		{"gcp", "custom", "", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.provider+"/"+tt.instanceType, func(t *testing.T) {
+			family, vCPUs := parseInstanceType(tt.provider, tt.instanceType)
+			assert.Equal(t, tt.family, family)
+			assert.Equal(t, tt.vCPUs, vCPUs)
+		})
+	}
+}
+
+func TestIntensity(t *testing.T) {
+	c, err := LoadCoefficients()
+	require.NoError(t, err)
+	assert.NotEmpty(t, CoefficientsVersion())
+
+	instance := &schema.CostComponent{
+		Name: "Instance usage (Linux/UNIX, on-demand, m5.xlarge)",
+		Unit: "hours",
+		ProductFilter: &schema.ProductFilter{
+			VendorName: strPtr("aws"),
+			AttributeFilters: []*schema.AttributeFilter{
+				{Key: "instanceType", Value: strPtr("m5.xlarge")},
+			},
+		},
+	}
+	vm := &schema.CostComponent{
+		Name: "Instance usage (pay as you go, Standard_D4s_v3)",
+		Unit: "hours",
+		ProductFilter: &schema.ProductFilter{
+			VendorName: strPtr("azure"),
+			AttributeFilters: []*schema.AttributeFilter{
+				{Key: "armSkuName", ValueRegex: strPtr("/^Standard_D4s_v3$/i")},
+			},
+		},
+	}
+	ssd := &schema.CostComponent{Name: "Storage (general purpose SSD, gp2)", Unit: "GB", ProductFilter: &schema.ProductFilter{VendorName: strPtr("aws")}}
+	hdd := &schema.CostComponent{Name: "Storage (cold HDD, sc1)", Unit: "GB", ProductFilter: &schema.ProductFilter{VendorName: strPtr("aws")}}
+	transfer := &schema.CostComponent{Name: "Data transfer out to internet", Unit: "GB", ProductFilter: &schema.ProductFilter{VendorName: strPtr("aws")}}
+	requests := &schema.CostComponent{Name: "Requests", Unit: "1M requests", ProductFilter: &schema.ProductFilter{VendorName: strPtr("aws")}}
+
+	aws := c.Providers["aws"]
+
+	// 4 vCPUs at the m5 power draw, adjusted for the PUE and the grid intensity of eu-west-1
+	expected := decimal.NewFromFloat(4 * aws.InstanceFamilyWattsPerVCPU["m5"] / 1000 * aws.PUE * aws.GridIntensity["eu-west-1"])
+	assert.Equal(t, expected.String(), c.Intensity("eu-west-1", instance).String())
+
+	// Unknown regions use the default grid intensity of the provider
+	expected = decimal.NewFromFloat(4 * aws.InstanceFamilyWattsPerVCPU["m5"] / 1000 * aws.PUE * aws.DefaultGridIntensity)
+	assert.Equal(t, expected.String(), c.Intensity("mars-1", instance).String())
+
+	assert.NotNil(t, c.Intensity("westeurope", vm))
+	assert.True(t, c.Intensity("eu-west-1", ssd).GreaterThan(*c.Intensity("eu-west-1", hdd)))
+	assert.NotNil(t, c.Intensity("eu-west-1", transfer))
+	assert.Nil(t, c.Intensity("eu-west-1", requests))
+}
+
+func TestEstimate(t *testing.T) {
+	qty := decimal.NewFromInt(730)
+	c := &schema.CostComponent{
+		Name:            "Instance usage (Linux/UNIX, on-demand, m5.xlarge)",
+		Unit:            "hours",
+		UnitMultiplier:  decimal.NewFromInt(1),
+		MonthlyQuantity: &qty,
+		ProductFilter: &schema.ProductFilter{
+			VendorName: strPtr("aws"),
+			Region:     strPtr("eu-north-1"),
+			AttributeFilters: []*schema.AttributeFilter{
+				{Key: "instanceType", Value: strPtr("m5.xlarge")},
+			},
+		},
+	}
+	size := decimal.NewFromInt(50)
+	storage := &schema.CostComponent{
+		Name:            "Storage (general purpose SSD, gp2)",
+		Unit:            "GB",
+		UnitMultiplier:  decimal.NewFromInt(1),
+		MonthlyQuantity: &size,
+		ProductFilter: &schema.ProductFilter{
+			VendorName: strPtr("aws"),
+			Region:     strPtr("eu-north-1"),
+		},
+	}
+	r := &schema.Resource{
+		Name:           "aws_instance.web",
+		CostComponents: []*schema.CostComponent{c},
+		SubResources:   []*schema.Resource{{Name: "root_block_device", CostComponents: []*schema.CostComponent{storage}}},
+	}
+
+	require.NoError(t, Estimate([]*schema.Resource{r}))
+	require.NotNil(t, c.CarbonIntensity())
+	require.NotNil(t, storage.CarbonIntensity())
+
+	r.CalculateCosts()
+	require.NotNil(t, r.MonthlyCarbon)
+	expected := c.CarbonIntensity().Mul(qty).Add(storage.CarbonIntensity().Mul(size))
+	assert.Equal(t, expected.String(), r.MonthlyCarbon.String())
+	assert.Equal(t, storage.MonthlyCarbon.String(), r.SubResources[0].MonthlyCarbon.String())
+}
@@ -41,6 +41,50 @@ func (e *CLIError) SanitizedStack() string {
 	return ""
 }
 
+// PartialResultsExitCode is the exit code used when the output was generated
+// but the prices of some cost components could not be retrieved.
+const PartialResultsExitCode = 3
+
+// PartialResultsError is returned when the output was generated but is
+// incomplete since the prices of some cost components could not be retrieved.
+type PartialResultsError struct {
+	count int
+}
+
+func NewPartialResultsError(count int) *PartialResultsError {
+	return &PartialResultsError{count: count}
+}
+
+func (e *PartialResultsError) Error() string {
+	if e.count == 1 {
+		return "The price of 1 cost component could not be retrieved, costs are a lower bound"
+	}
+
+	return fmt.Sprintf("The prices of %d cost components could not be retrieved, costs are a lower bound", e.count)
+}
+
+// TagPolicyExitCode is the exit code used when the output was generated but
+// some resources violate the tag policy of the config file.
+const TagPolicyExitCode = 4
+
+// TagPolicyError is returned when the output was generated but some resources
+// are missing required tags or have tag values that aren't allowed.
+type TagPolicyError struct {
+	count int
+}
+
+func NewTagPolicyError(count int) *TagPolicyError {
+	return &TagPolicyError{count: count}
+}
+
+func (e *TagPolicyError) Error() string {
+	if e.count == 1 {
+		return "Tag policy failed: 1 resource is missing required tags or has tag values that aren't allowed"
+	}
+
+	return fmt.Sprintf("Tag policy failed: %d resources are missing required tags or have tag values that aren't allowed", e.count)
+}
+
 // PanicError is used to collect goroutine panics into an error interface so
 // that we can do type assertion on err checking.
 type PanicError struct {
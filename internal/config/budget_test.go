@@ -0,0 +1,83 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigLoadBudget(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "infracost.yml")
+	err := os.WriteFile(path, []byte(`version: 0.1
+
+projects:
+  - path: path/to/my_terraform
+    budget:
+      monthly_budget: 1000
+      max_increase: 200.5
+      max_increase_percent: 10
+      level: block
+  - path: path/to/other_terraform
+`), os.ModePerm)
+	require.NoError(t, err)
+
+	c := Config{}
+	err = c.LoadFromConfigFile(path)
+	require.NoError(t, err)
+	require.Len(t, c.Projects, 2)
+
+	b := c.Projects[0].Budget
+	require.NotNil(t, b)
+	assert.Equal(t, 1000.0, *b.MonthlyBudget)
+	assert.Equal(t, 200.5, *b.MaxIncrease)
+	assert.Equal(t, 10.0, *b.MaxIncreasePercent)
+	assert.True(t, b.IsBlocking())
+
+	assert.Nil(t, c.Projects[1].Budget)
+}
+
+func TestConfigLoadBudgetInvalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		budget string
+		error  string
+	}{
+		{
+			name:   "no thresholds",
+			budget: "level: warning",
+			error:  "budget must have at least one of monthly_budget, max_increase or max_increase_percent",
+		},
+		{
+			name:   "negative threshold",
+			budget: "max_increase: -10",
+			error:  "budget max_increase must not be negative",
+		},
+		{
+			name:   "invalid level",
+			budget: "monthly_budget: 100\n      level: error",
+			error:  "budget level 'error' is not valid, valid levels are warning and block",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "infracost.yml")
+			err := os.WriteFile(path, []byte(`version: 0.1
+
+projects:
+  - path: path/to/my_terraform
+    budget:
+      `+tt.budget+`
+`), os.ModePerm)
+			require.NoError(t, err)
+
+			c := Config{}
+			err = c.LoadFromConfigFile(path)
+			require.Error(t, err)
+			assert.Equal(t, "config file is invalid, see https://infracost.io/config-file for valid options:\n\tproject config defined for path: [path/to/my_terraform] is invalid:\n\t\t"+tt.error, err.Error())
+		})
+	}
+}
@@ -6,12 +6,15 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 	"github.com/kelseyhightower/envconfig"
 	"github.com/sirupsen/logrus"
 
+	"github.com/infracost/infracost/internal/fxrates"
 	"github.com/infracost/infracost/internal/logging"
+	"github.com/infracost/infracost/internal/schema"
 )
 
 const InfracostDir = ".infracost"
@@ -54,9 +57,17 @@ type Project struct {
 	TerragruntFlags string `envconfig:"TERRAGRUNT_FLAGS"`
 	// UsageFile is the full path to usage file that specifies values for usage-based resources
 	UsageFile string `yaml:"usage_file,omitempty" ignored:"true"`
+	// DataStubsFile is the path to a file that specifies the attribute values of data sources that
+	// can't be evaluated in Terraform directories, e.g. AMI and instance type lookups.
+	DataStubsFile string `yaml:"data_stubs_file,omitempty" ignored:"true"`
+	// RemoteStates maps terraform_remote_state data source addresses to the state file or
+	// project in the config file that they are resolved from.
+	RemoteStates map[string]RemoteState `yaml:"remote_states,omitempty" ignored:"true"`
 	// TerraformUseState sets if the users wants to use the terraform state for infracost ops.
 	TerraformUseState bool              `yaml:"terraform_use_state,omitempty" ignored:"true"`
 	Env               map[string]string `yaml:"env,omitempty" ignored:"true"`
+	// Budget sets the monthly budget and the maximum allowed cost increases of the project.
+	Budget *schema.Budget `yaml:"budget,omitempty" ignored:"true"`
 }
 
 type Config struct {
@@ -82,6 +93,37 @@ type Config struct {
 	EnableCloud               *bool  `yaml:"enable_cloud,omitempty" envconfig:"ENABLE_CLOUD"`
 	EnableCloudUpload         *bool  `yaml:"enable_cloud,omitempty" envconfig:"ENABLE_CLOUD_UPLOAD"`
 	DisableHCLParsing         bool   `yaml:"disable_hcl_parsing,omitempty" envconfig:"DISABLE_HCL_PARSING"`
+	// PricingBundleFile is the path to a price bundle created with `infracost prices export`.
+	// When set, prices are resolved from the bundle instead of the Cloud Pricing API.
+	PricingBundleFile string `yaml:"pricing_bundle_file,omitempty" envconfig:"PRICING_BUNDLE_FILE"`
+	// PricingCacheDisabled turns off the on-disk cache of Cloud Pricing API queries.
+	PricingCacheDisabled bool `yaml:"pricing_cache_disabled,omitempty" envconfig:"PRICING_CACHE_DISABLED"`
+	// PricingCacheTTL is how long cached Cloud Pricing API query results are used for.
+	PricingCacheTTL time.Duration `yaml:"pricing_cache_ttl,omitempty" envconfig:"PRICING_CACHE_TTL"`
+	// PricingCacheMaxSizeMB is the size in MB the pricing cache is trimmed to when it grows beyond it.
+	PricingCacheMaxSizeMB int64 `yaml:"pricing_cache_max_size_mb,omitempty" envconfig:"PRICING_CACHE_MAX_SIZE_MB"`
+	// AllowPartialPrices reports the costs that could be priced when some Cloud Pricing API
+	// requests still fail after being retried, instead of failing the whole run.
+	AllowPartialPrices bool `yaml:"allow_partial_prices,omitempty" envconfig:"ALLOW_PARTIAL_PRICES"`
+	// DiscountsFile is the path to a discount rules file whose discounts are applied
+	// to the cost components after they are priced.
+	DiscountsFile string `yaml:"discounts_file,omitempty" envconfig:"DISCOUNTS_FILE"`
+	// PriceOverridesFile is the path to a price overrides file that sets fixed prices or
+	// price multipliers for cost components.
+	PriceOverridesFile string `yaml:"price_overrides_file,omitempty" envconfig:"PRICE_OVERRIDES_FILE"`
+	// FXRatesFile is the path to a local exchange rates file. When set, prices are retrieved in
+	// USD and converted to the currency with its rates, and reports in different currencies can
+	// be combined.
+	FXRatesFile string `yaml:"fx_rates_file,omitempty" envconfig:"FX_RATES_FILE"`
+	// HoursPerMonth is the number of hours in a month that hourly prices are converted
+	// to monthly costs with. Defaults to 730, the average number of hours in a month.
+	HoursPerMonth float64 `yaml:"hours_per_month,omitempty" envconfig:"HOURS_PER_MONTH"`
+	// ForecastMonths is the number of months to forecast the costs for with the
+	// growth functions of the usage file.
+	ForecastMonths int `yaml:"forecast_months,omitempty" ignored:"true"`
+	// ReplacementOverlap is how long both copies of a resource replaced with
+	// create_before_destroy run for, it is used to estimate a one-off overlap cost.
+	ReplacementOverlap time.Duration `yaml:"replacement_overlap,omitempty" envconfig:"REPLACEMENT_OVERLAP"`
 
 	TLSInsecureSkipVerify *bool  `envconfig:"TLS_INSECURE_SKIP_VERIFY"`
 	TLSCACertFile         string `envconfig:"TLS_CA_CERT_FILE"`
@@ -102,6 +144,8 @@ type Config struct {
 	ShowSkipped     bool       `yaml:"show_skipped,omitempty" ignored:"true"`
 	SyncUsageFile   bool       `yaml:"sync_usage_file,omitempty" ignored:"true"`
 	Fields          []string   `yaml:"fields,omitempty" ignored:"true"`
+	GroupBy         []string   `yaml:"group_by,omitempty" ignored:"true"`
+	TagPolicy       *TagPolicy `yaml:"tag_policy,omitempty" ignored:"true"`
 	CompareTo       string
 	GitDiffTarget   *string
 
@@ -145,6 +189,10 @@ func DefaultConfig() *Config {
 		Format: "table",
 		Fields: []string{"monthlyQuantity", "unit", "monthlyCost"},
 
+		PricingCacheDisabled:  IsTest(),
+		PricingCacheTTL:       24 * time.Hour,
+		PricingCacheMaxSizeMB: 100,
+
 		EventsDisabled: IsTest(),
 	}
 }
@@ -158,6 +206,22 @@ func (c *Config) RepoPath() string {
 	return c.RootPath
 }
 
+// CachePath returns the .infracost directory used for caches that are shared
+// between all the projects in a run. This is next to the config file, or in the
+// directory given by the --path flag.
+func (c *Config) CachePath() string {
+	dir := c.RepoPath()
+	if dir == "" {
+		dir = "."
+	}
+
+	if info, err := os.Stat(dir); err == nil && !info.IsDir() {
+		dir = filepath.Dir(dir)
+	}
+
+	return filepath.Join(dir, InfracostDir)
+}
+
 func (c *Config) LoadFromConfigFile(path string) error {
 	cfgFile, err := loadConfigFile(path)
 	if err != nil {
@@ -165,6 +229,7 @@ func (c *Config) LoadFromConfigFile(path string) error {
 	}
 
 	c.Projects = cfgFile.Projects
+	c.TagPolicy = cfgFile.TagPolicy
 
 	// Reload the environment to overwrite any of the config file configs
 	err = c.LoadFromEnv()
@@ -304,6 +369,27 @@ func (c *Config) IsSelfHosted() bool {
 	return c.PricingAPIEndpoint != "" && c.PricingAPIEndpoint != c.DefaultPricingAPIEndpoint
 }
 
+// UsePricingBundle returns true if prices should be resolved from a local
+// price bundle instead of the Cloud Pricing API.
+func (c *Config) UsePricingBundle() bool {
+	return c.PricingBundleFile != ""
+}
+
+// LoadFXRates returns the exchange rates from FXRatesFile, or nil if it isn't set.
+func (c *Config) LoadFXRates() (*fxrates.Rates, error) {
+	if c.FXRatesFile == "" {
+		return nil, nil
+	}
+
+	return fxrates.Load(c.FXRatesFile)
+}
+
+// EstimateCarbon returns true if the monthlyCarbon output field is requested,
+// in which case the carbon emissions of the resources are estimated.
+func (c *Config) EstimateCarbon() bool {
+	return containsString(c.Fields, "monthlyCarbon")
+}
+
 func IsTest() bool {
 	return os.Getenv("INFRACOST_ENV") == "test" || strings.HasSuffix(os.Args[0], ".test")
 }
@@ -109,8 +109,9 @@ func (y *YamlError) Error() string {
 }
 
 type fileSpec struct {
-	Version  string     `yaml:"version"`
-	Projects []*Project `yaml:"projects" ignored:"true"`
+	Version   string     `yaml:"version"`
+	Projects  []*Project `yaml:"projects" ignored:"true"`
+	TagPolicy *TagPolicy `yaml:"tag_policy,omitempty" ignored:"true"`
 }
 
 // UnmarshalYAML implements the yaml.v2.Unmarshaller interface. Marshalls the
@@ -201,8 +202,57 @@ func (f *fileSpec) UnmarshalYAML(unmarshal func(interface{}) error) error {
 		return &YamlError{raw: ErrorInvalidConfigFile}
 	}
 
+	if c.TagPolicy != nil {
+		err = c.TagPolicy.Validate()
+		if err != nil {
+			return &YamlError{
+				base:   "config file is invalid, see https://infracost.io/config-file for valid options",
+				errors: []error{err},
+			}
+		}
+	}
+
+	for _, p := range c.Projects {
+		if p.Budget == nil {
+			continue
+		}
+
+		err = p.Budget.Validate()
+		if err != nil {
+			return &YamlError{
+				base: "config file is invalid, see https://infracost.io/config-file for valid options",
+				errors: []error{&YamlError{
+					base:   fmt.Sprintf("project config defined for path: [%s] is invalid", p.Path),
+					errors: []error{err},
+				}},
+			}
+		}
+	}
+
+	for _, p := range c.Projects {
+		addresses := make([]string, 0, len(p.RemoteStates))
+		for addr := range p.RemoteStates {
+			addresses = append(addresses, addr)
+		}
+		sort.Strings(addresses)
+
+		for _, addr := range addresses {
+			err = p.RemoteStates[addr].Validate(c.Projects)
+			if err != nil {
+				return &YamlError{
+					base: "config file is invalid, see https://infracost.io/config-file for valid options",
+					errors: []error{&YamlError{
+						base:   fmt.Sprintf("project config defined for path: [%s] is invalid", p.Path),
+						errors: []error{fmt.Errorf("%s: %w", addr, err)},
+					}},
+				}
+			}
+		}
+	}
+
 	f.Version = c.Version
 	f.Projects = c.Projects
+	f.TagPolicy = c.TagPolicy
 	return nil
 }
 
@@ -0,0 +1,49 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+)
+
+// RemoteState is the state that a terraform_remote_state data source of a project is
+// resolved from. It is either a state file or another project in the config file, whose
+// output blocks are evaluated to give the state outputs.
+type RemoteState struct {
+	// StateFile is the path to a Terraform state JSON file.
+	StateFile string `yaml:"state_file,omitempty"`
+	// Project is the name or path of another project in the config file.
+	Project string `yaml:"project,omitempty"`
+}
+
+// Validate returns an error if the remote state doesn't set exactly one of the state file
+// or project, or the project isn't one of the projects.
+func (r RemoteState) Validate(projects []*Project) error {
+	if (r.StateFile == "") == (r.Project == "") {
+		return errors.New("remote state must have exactly one of state_file or project")
+	}
+
+	if r.Project != "" && FindProject(projects, r.Project) == nil {
+		return fmt.Errorf("remote state project '%s' is not a project in the config file", r.Project)
+	}
+
+	return nil
+}
+
+// FindProject returns the project with the name, or if no project has the name the project
+// with the path. It returns nil if there is no such project.
+func FindProject(projects []*Project, nameOrPath string) *Project {
+	for _, p := range projects {
+		if p.Name != "" && p.Name == nameOrPath {
+			return p
+		}
+	}
+
+	for _, p := range projects {
+		if filepath.Clean(p.Path) == filepath.Clean(nameOrPath) {
+			return p
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,82 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigLoadRemoteStates(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "infracost.yml")
+	err := os.WriteFile(path, []byte(`version: 0.1
+
+projects:
+  - path: path/to/network
+    name: network
+  - path: path/to/app
+    remote_states:
+      data.terraform_remote_state.network:
+        project: network
+      data.terraform_remote_state.dns:
+        state_file: states/dns.tfstate
+`), os.ModePerm)
+	require.NoError(t, err)
+
+	c := Config{}
+	err = c.LoadFromConfigFile(path)
+	require.NoError(t, err)
+	require.Len(t, c.Projects, 2)
+
+	assert.Equal(t, map[string]RemoteState{
+		"data.terraform_remote_state.network": {Project: "network"},
+		"data.terraform_remote_state.dns":     {StateFile: "states/dns.tfstate"},
+	}, c.Projects[1].RemoteStates)
+	assert.Equal(t, c.Projects[0], FindProject(c.Projects, "network"))
+	assert.Equal(t, c.Projects[1], FindProject(c.Projects, "path/to/app/"))
+}
+
+func TestConfigLoadRemoteStatesInvalid(t *testing.T) {
+	tests := []struct {
+		name        string
+		remoteState string
+		error       string
+	}{
+		{
+			name:        "no state",
+			remoteState: "{}",
+			error:       "data.terraform_remote_state.network: remote state must have exactly one of state_file or project",
+		},
+		{
+			name:        "state file and project",
+			remoteState: "{state_file: network.tfstate, project: network}",
+			error:       "data.terraform_remote_state.network: remote state must have exactly one of state_file or project",
+		},
+		{
+			name:        "unknown project",
+			remoteState: "{project: network}",
+			error:       "data.terraform_remote_state.network: remote state project 'network' is not a project in the config file",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "infracost.yml")
+			err := os.WriteFile(path, []byte(`version: 0.1
+
+projects:
+  - path: path/to/my_terraform
+    remote_states:
+      data.terraform_remote_state.network: `+tt.remoteState+`
+`), os.ModePerm)
+			require.NoError(t, err)
+
+			c := Config{}
+			err = c.LoadFromConfigFile(path)
+			require.Error(t, err)
+			assert.Equal(t, "config file is invalid, see https://infracost.io/config-file for valid options:\n\tproject config defined for path: [path/to/my_terraform] is invalid:\n\t\t"+tt.error, err.Error())
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+)
+
+// TagPolicy is the set of tags that every taggable, priced resource must have.
+// It is defined in the tag_policy block of the config file.
+type TagPolicy struct {
+	RequiredTags []RequiredTag `yaml:"required_tags"`
+}
+
+// RequiredTag is a tag that resources must have. If AllowedValues or Pattern
+// are set the value of the tag must also be one of the allowed values or match
+// the pattern.
+type RequiredTag struct {
+	Key           string   `yaml:"key"`
+	AllowedValues []string `yaml:"allowed_values,omitempty"`
+	Pattern       string   `yaml:"pattern,omitempty"`
+
+	patternRegex *regexp.Regexp
+}
+
+// Validate returns an error if the policy has no required tags or any of them
+// are invalid, and compiles the patterns of the required tags.
+func (p *TagPolicy) Validate() error {
+	if len(p.RequiredTags) == 0 {
+		return errors.New("tag_policy must have at least one required tag")
+	}
+
+	for i := range p.RequiredTags {
+		t := &p.RequiredTags[i]
+
+		if t.Key == "" {
+			return fmt.Errorf("tag_policy required tag at index %d must have a key", i)
+		}
+
+		if t.Pattern == "" {
+			continue
+		}
+
+		re, err := regexp.Compile(t.Pattern)
+		if err != nil {
+			return fmt.Errorf("tag_policy required tag %s has an invalid pattern: %w", t.Key, err)
+		}
+		t.patternRegex = re
+	}
+
+	return nil
+}
+
+// Check returns a message for each of the required tags that are missing from
+// tags or have a value that isn't allowed.
+func (p *TagPolicy) Check(tags map[string]string) []string {
+	messages := make([]string, 0)
+
+	for _, t := range p.RequiredTags {
+		v, ok := tags[t.Key]
+		if !ok {
+			messages = append(messages, fmt.Sprintf("missing required tag %s", t.Key))
+			continue
+		}
+
+		if len(t.AllowedValues) > 0 && !containsString(t.AllowedValues, v) {
+			messages = append(messages, fmt.Sprintf("tag %s has value '%s', allowed values are: %s", t.Key, v, joinQuoted(t.AllowedValues)))
+			continue
+		}
+
+		if t.Pattern == "" {
+			continue
+		}
+
+		re := t.patternRegex
+		if re == nil {
+			// The policy hasn't been validated so skip patterns that don't compile.
+			var err error
+			re, err = regexp.Compile(t.Pattern)
+			if err != nil {
+				continue
+			}
+		}
+
+		if !re.MatchString(v) {
+			messages = append(messages, fmt.Sprintf("tag %s has value '%s' that doesn't match %s", t.Key, v, t.Pattern))
+		}
+	}
+
+	return messages
+}
+
+func containsString(values []string, v string) bool {
+	for _, s := range values {
+		if s == v {
+			return true
+		}
+	}
+
+	return false
+}
+
+func joinQuoted(values []string) string {
+	s := ""
+	for i, v := range values {
+		if i > 0 {
+			s += ", "
+		}
+		s += fmt.Sprintf("'%s'", v)
+	}
+
+	return s
+}
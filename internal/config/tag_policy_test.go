@@ -0,0 +1,61 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigLoadTagPolicy(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "infracost.yml")
+	err := os.WriteFile(path, []byte(`version: 0.1
+
+tag_policy:
+  required_tags:
+    - key: team
+      allowed_values: [web, data]
+    - key: cost-center
+      pattern: ^CC-[0-9]+$
+    - key: owner
+
+projects:
+  - path: path/to/my_terraform
+`), os.ModePerm)
+	require.NoError(t, err)
+
+	c := Config{}
+	err = c.LoadFromConfigFile(path)
+	require.NoError(t, err)
+	require.NotNil(t, c.TagPolicy)
+	require.Len(t, c.TagPolicy.RequiredTags, 3)
+
+	assert.Empty(t, c.TagPolicy.Check(map[string]string{"team": "web", "cost-center": "CC-123", "owner": ""}))
+	assert.Equal(t, []string{
+		"tag team has value 'ops', allowed values are: 'web', 'data'",
+		"tag cost-center has value 'marketing' that doesn't match ^CC-[0-9]+$",
+		"missing required tag owner",
+	}, c.TagPolicy.Check(map[string]string{"team": "ops", "cost-center": "marketing"}))
+}
+
+func TestConfigLoadTagPolicyInvalidPattern(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "infracost.yml")
+	err := os.WriteFile(path, []byte(`version: 0.1
+
+tag_policy:
+  required_tags:
+    - key: team
+      pattern: "["
+
+projects:
+  - path: path/to/my_terraform
+`), os.ModePerm)
+	require.NoError(t, err)
+
+	c := Config{}
+	err = c.LoadFromConfigFile(path)
+	require.Error(t, err)
+	assert.Equal(t, "config file is invalid, see https://infracost.io/config-file for valid options:\n\ttag_policy required tag team has an invalid pattern: error parsing regexp: missing closing ]: `[`", err.Error())
+}
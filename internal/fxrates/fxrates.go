@@ -0,0 +1,170 @@
+package fxrates
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/shopspring/decimal"
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+// BaseCurrency is the currency that all rates are relative to.
+const BaseCurrency = "USD"
+
+const dateLayout = "2006-01-02"
+
+var (
+	loadedRates   = map[string]*Rates{}
+	loadedRatesMu = &sync.Mutex{}
+)
+
+// Rates is a table of exchange rates from USD that were effective on a given
+// date. A rate is the amount of the currency that one USD buys.
+type Rates struct {
+	// Source is the path of the file the rates were loaded from.
+	Source        string
+	EffectiveDate time.Time
+
+	rates map[string]decimal.Decimal
+}
+
+type ratesFile struct {
+	EffectiveDate string            `yaml:"effective_date"`
+	Rates         map[string]string `yaml:"rates"`
+}
+
+// Load reads the exchange rates from a YAML or CSV file. Files are only read
+// once per run.
+//
+// YAML files have an effective_date and a map of rates:
+//
+//	effective_date: 2023-06-30
+//	rates:
+//	  EUR: 0.9168
+//	  GBP: 0.7866
+//
+// CSV files have a currency,rate,effective_date header and a row per currency,
+// all with the same effective date.
+func Load(path string) (*Rates, error) {
+	loadedRatesMu.Lock()
+	defer loadedRatesMu.Unlock()
+
+	if r, ok := loadedRates[path]; ok {
+		return r, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("Error reading exchange rates file %s: %w", path, err)
+	}
+
+	var f ratesFile
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		f, err = parseCSV(data)
+	default:
+		err = yamlv3.Unmarshal(data, &f)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Error parsing exchange rates file %s: %w", path, err)
+	}
+
+	r, err := newRates(path, f)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid exchange rates file %s: %w", path, err)
+	}
+
+	loadedRates[path] = r
+
+	return r, nil
+}
+
+func parseCSV(data []byte) (ratesFile, error) {
+	f := ratesFile{Rates: map[string]string{}}
+
+	records, err := csv.NewReader(strings.NewReader(string(data))).ReadAll()
+	if err != nil {
+		return f, err
+	}
+
+	if len(records) == 0 || strings.Join(records[0], ",") != "currency,rate,effective_date" {
+		return f, fmt.Errorf("expected a currency,rate,effective_date header")
+	}
+
+	for _, record := range records[1:] {
+		if f.EffectiveDate != "" && record[2] != f.EffectiveDate {
+			return f, fmt.Errorf("all rates must have the same effective_date, found %s and %s", f.EffectiveDate, record[2])
+		}
+
+		f.EffectiveDate = record[2]
+		f.Rates[record[0]] = record[1]
+	}
+
+	return f, nil
+}
+
+func newRates(source string, f ratesFile) (*Rates, error) {
+	date, err := time.Parse(dateLayout, f.EffectiveDate)
+	if err != nil {
+		return nil, fmt.Errorf("effective_date must be a date in the format YYYY-MM-DD")
+	}
+
+	r := &Rates{
+		Source:        source,
+		EffectiveDate: date,
+		rates:         map[string]decimal.Decimal{BaseCurrency: decimal.NewFromInt(1)},
+	}
+
+	for currency, rate := range f.Rates {
+		d, err := decimal.NewFromString(rate)
+		if err != nil || !d.IsPositive() {
+			return nil, fmt.Errorf("invalid rate %q for %s", rate, currency)
+		}
+
+		r.rates[strings.ToUpper(currency)] = d
+	}
+
+	return r, nil
+}
+
+// Has returns true if the rates can convert to and from the currency.
+func (r *Rates) Has(currency string) bool {
+	_, ok := r.rates[normalize(currency)]
+	return ok
+}
+
+// Factor returns the number that amounts in the from currency are multiplied
+// by to convert them to the to currency.
+func (r *Rates) Factor(from, to string) (decimal.Decimal, error) {
+	fromRate, ok := r.rates[normalize(from)]
+	if !ok {
+		return decimal.Zero, fmt.Errorf("Exchange rates file %s has no rate for %s", r.Source, normalize(from))
+	}
+
+	toRate, ok := r.rates[normalize(to)]
+	if !ok {
+		return decimal.Zero, fmt.Errorf("Exchange rates file %s has no rate for %s", r.Source, normalize(to))
+	}
+
+	return toRate.Div(fromRate), nil
+}
+
+// FormattedEffectiveDate returns the effective date as YYYY-MM-DD.
+func (r *Rates) FormattedEffectiveDate() string {
+	return r.EffectiveDate.Format(dateLayout)
+}
+
+// normalize returns the currency code in upper case, an empty currency is USD
+// since that is the default currency of Infracost.
+func normalize(currency string) string {
+	if currency == "" {
+		return BaseCurrency
+	}
+
+	return strings.ToUpper(currency)
+}
@@ -0,0 +1,81 @@
+package fxrates
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFile(t *testing.T, name, content string) string {
+	p := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(p, []byte(content), 0600))
+	return p
+}
+
+func TestLoad(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		content string
+	}{
+		{
+			name:    "yaml",
+			file:    "rates.yml",
+			content: "effective_date: 2023-06-30\nrates:\n  EUR: 0.9\n  gbp: 0.8\n",
+		},
+		{
+			name:    "csv",
+			file:    "rates.csv",
+			content: "currency,rate,effective_date\nEUR,0.9,2023-06-30\nGBP,0.8,2023-06-30\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := writeFile(t, tt.file, tt.content)
+
+			rates, err := Load(p)
+			require.NoError(t, err)
+
+			assert.Equal(t, p, rates.Source)
+			assert.Equal(t, "2023-06-30", rates.FormattedEffectiveDate())
+			assert.True(t, rates.Has("usd"))
+			assert.False(t, rates.Has("JPY"))
+
+			factor, err := rates.Factor("", "EUR")
+			require.NoError(t, err)
+			assert.Equal(t, "0.9", factor.String())
+
+			factor, err = rates.Factor("EUR", "GBP")
+			require.NoError(t, err)
+			assert.Equal(t, "0.8889", factor.StringFixed(4))
+
+			_, err = rates.Factor("EUR", "JPY")
+			assert.ErrorContains(t, err, "has no rate for JPY")
+		})
+	}
+}
+
+func TestLoadInvalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		err     string
+	}{
+		{name: "date", file: "rates.yml", content: "rates:\n  EUR: 0.9\n", err: "effective_date must be a date"},
+		{name: "rate", file: "rates.yml", content: "effective_date: 2023-06-30\nrates:\n  EUR: -1\n", err: "invalid rate"},
+		{name: "header", file: "rates.csv", content: "EUR,0.9,2023-06-30\n", err: "expected a currency,rate,effective_date header"},
+		{name: "mixed dates", file: "rates.csv", content: "currency,rate,effective_date\nEUR,0.9,2023-06-30\nGBP,0.8,2023-07-31\n", err: "same effective_date"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Load(writeFile(t, tt.file, tt.content))
+			assert.ErrorContains(t, err, tt.err)
+		})
+	}
+}
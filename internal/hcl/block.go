@@ -49,6 +49,19 @@ var (
 				Type:       "data",
 				LabelNames: []string{"type", "name"},
 			},
+			{
+				Type:       "ephemeral",
+				LabelNames: []string{"type", "name"},
+			},
+			{
+				Type: "moved",
+			},
+			{
+				Type: "import",
+			},
+			{
+				Type: "removed",
+			},
 		},
 	}
 	justProviderBlocks = &hcl.BodySchema{
@@ -377,6 +390,7 @@ func (b BlockBuilder) BuildModuleBlocks(block *Block, modulePath string) (Blocks
 	}
 
 	moduleCtx := NewContext(&hcl.EvalContext{}, nil, b.Logger)
+	var hclBlocks, overrideBlocks hcl.Blocks
 	for _, file := range moduleFiles {
 		fileBlocks, err := loadBlocksFromFile(file, nil)
 		if err != nil {
@@ -387,9 +401,16 @@ func (b BlockBuilder) BuildModuleBlocks(block *Block, modulePath string) (Blocks
 			b.Logger.Debugf("Added %d blocks from %s...", len(fileBlocks), fileBlocks[0].DefRange.Filename)
 		}
 
-		for _, fileBlock := range fileBlocks {
-			blocks = append(blocks, b.NewBlock(file.path, fileBlock, moduleCtx, block))
+		if isOverrideFile(file.path) {
+			overrideBlocks = append(overrideBlocks, fileBlocks...)
+			continue
 		}
+
+		hclBlocks = append(hclBlocks, fileBlocks...)
+	}
+
+	for _, hclBlock := range mergeOverrides(hclBlocks, overrideBlocks, b.Logger) {
+		blocks = append(blocks, b.NewBlock(hclBlock.DefRange.Filename, hclBlock, moduleCtx, block))
 	}
 
 	return blocks, err
@@ -518,7 +539,7 @@ func (b Block) ShouldExpand() bool {
 		return false
 	}
 
-	return b.Type() == "resource" || b.Type() == "module" || b.Type() == "data"
+	return b.Type() == "resource" || b.Type() == "module" || b.Type() == "data" || b.Type() == "ephemeral"
 }
 
 // SetContext sets the Block.context to the provided ctx. This ctx is also set on the child Blocks as
@@ -867,6 +888,11 @@ func (b *Block) Labels() []string {
 	return b.hclBlock.Labels
 }
 
+// StartLine returns the line in the file of the Block that the Block is defined on.
+func (b *Block) StartLine() int {
+	return b.hclBlock.DefRange.Start.Line
+}
+
 func (b *Block) Context() *Context {
 	return b.context
 }
@@ -0,0 +1,194 @@
+package hcl
+
+import (
+	_ "embed"
+	"fmt"
+	"os"
+
+	ctyyaml "github.com/zclconf/go-cty-yaml"
+	"github.com/zclconf/go-cty/cty"
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed data_stubs.yml
+var builtinDataStubs []byte
+
+const dataStubsFileVersion = "0.1"
+
+// DataStubs supplies the attribute values of data sources, which can't be read without calling the
+// provider. The stubs are keyed by a data source address, e.g. data.aws_ami.ubuntu or
+// module.web.data.aws_ami.ubuntu, or by a data source type, e.g. aws_ami, to stub all the data sources
+// of the type.
+type DataStubs struct {
+	stubs map[string]cty.Value
+}
+
+type dataStubsFile struct {
+	Version string               `yaml:"version"`
+	Data    map[string]yaml.Node `yaml:"data"`
+}
+
+// DefaultDataStubs returns the built-in stubs for the data sources that are commonly used to size
+// resources, such as AMI lookups.
+func DefaultDataStubs() *DataStubs {
+	stubs, err := ParseDataStubs(builtinDataStubs)
+	if err != nil {
+		panic(fmt.Sprintf("invalid built-in data stubs: %s", err))
+	}
+
+	return stubs
+}
+
+// LoadDataStubsFile loads the data stubs from the YAML file at path.
+func LoadDataStubsFile(path string) (*DataStubs, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading data stubs file %w", err)
+	}
+
+	stubs, err := ParseDataStubs(b)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing data stubs file %s: %w", path, err)
+	}
+
+	return stubs, nil
+}
+
+// ParseDataStubs parses the data stubs from YAML, e.g.
+//
+//	version: 0.1
+//	data:
+//	  data.aws_ami.ubuntu:
+//	    id: ami-0123456789abcdef0
+//	  aws_ec2_instance_type:
+//	    default_vcpus: 4
+func ParseDataStubs(b []byte) (*DataStubs, error) {
+	var f dataStubsFile
+	err := yaml.Unmarshal(b, &f)
+	if err != nil {
+		return nil, err
+	}
+
+	if f.Version != dataStubsFileVersion {
+		return nil, fmt.Errorf("invalid version '%s', the version must be %s", f.Version, dataStubsFileVersion)
+	}
+
+	stubs := &DataStubs{stubs: make(map[string]cty.Value, len(f.Data))}
+	for key, node := range f.Data {
+		node := node
+		if node.Kind != yaml.MappingNode {
+			return nil, fmt.Errorf("stub for %s must be a map of attribute values", key)
+		}
+
+		raw, err := yaml.Marshal(&node)
+		if err != nil {
+			return nil, fmt.Errorf("invalid stub for %s: %w", key, err)
+		}
+
+		ty, err := ctyyaml.Standard.ImpliedType(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid stub for %s: %w", key, err)
+		}
+
+		val, err := ctyyaml.Standard.Unmarshal(raw, ty)
+		if err != nil {
+			return nil, fmt.Errorf("invalid stub for %s: %w", key, err)
+		}
+
+		stubs.stubs[key] = val
+	}
+
+	return stubs, nil
+}
+
+// Merge returns the stubs with the stubs of other added to them. The stubs of other take precedence.
+func (s *DataStubs) Merge(other *DataStubs) *DataStubs {
+	merged := &DataStubs{stubs: make(map[string]cty.Value)}
+	for _, stubs := range []*DataStubs{s, other} {
+		if stubs == nil {
+			continue
+		}
+
+		for k, v := range stubs.stubs {
+			merged.stubs[k] = v
+		}
+	}
+
+	return merged
+}
+
+// Lookup returns the stub for the data block. The most specific stub is used, so a stub for the full
+// address of the block is used over a stub for its address within its module, which is used over a
+// stub for its type. Count and for_each indexes are ignored if there is no stub for the indexed address.
+func (s *DataStubs) Lookup(b *Block) (cty.Value, bool) {
+	if s == nil || b.Type() != "data" || len(b.Labels()) < 2 {
+		return cty.NilVal, false
+	}
+
+	keys := append(dataBlockAddresses(b), b.TypeLabel())
+	for _, key := range keys {
+		if v, ok := s.stubs[key]; ok {
+			return v, true
+		}
+	}
+
+	return cty.NilVal, false
+}
+
+// dataBlockAddresses returns the addresses that a data block can be configured by, from the most to
+// the least specific.
+func dataBlockAddresses(b *Block) []string {
+	return []string{
+		b.FullName(),
+		stripCount(b.FullName()),
+		b.LocalName(),
+		stripCount(b.LocalName()),
+	}
+}
+
+// regionalDataStubs build the built-in stubs of the data source types whose values depend on the region
+// that the data source is read in, e.g. the names of the availability zones of the region.
+var regionalDataStubs = map[string]func(region string) cty.Value{
+	"aws_availability_zones": func(region string) cty.Value {
+		return cty.ObjectVal(map[string]cty.Value{
+			"id":    cty.StringVal(region),
+			"names": zoneNames(region, ""),
+		})
+	},
+	"google_compute_zones": func(region string) cty.Value {
+		return cty.ObjectVal(map[string]cty.Value{
+			"names": zoneNames(region, "-"),
+		})
+	},
+}
+
+// zoneNames returns the names of the first three zones of the region, e.g. eu-west-1a, eu-west-1b and
+// eu-west-1c for AWS or europe-west1-a, europe-west1-b and europe-west1-c for Google.
+func zoneNames(region string, sep string) cty.Value {
+	names := make([]cty.Value, 0, 3)
+	for _, zone := range []string{"a", "b", "c"} {
+		names = append(names, cty.StringVal(region+sep+zone))
+	}
+
+	return cty.ListVal(names)
+}
+
+// stubbedValues returns the values of the block with the attributes of the stub added to them. The stub
+// attributes replace any attributes of the same name. Stubs that aren't objects are ignored.
+func stubbedValues(b *Block, stub cty.Value) cty.Value {
+	val := b.Values()
+	if !stub.Type().IsObjectType() {
+		return val
+	}
+
+	values := val.AsValueMap()
+	if values == nil {
+		values = make(map[string]cty.Value)
+	}
+
+	for k, v := range stub.AsValueMap() {
+		values[k] = v
+	}
+
+	return cty.ObjectVal(values)
+}
@@ -0,0 +1,174 @@
+package hcl
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infracost/infracost/internal/hcl/modules"
+	"github.com/infracost/infracost/internal/sync"
+)
+
+func TestParseDataStubs(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{
+			name: "valid",
+			content: `
+version: 0.1
+data:
+  data.aws_ami.ubuntu:
+    id: ami-0123456789abcdef0
+  aws_ec2_instance_type:
+    default_vcpus: 4
+`,
+		},
+		{
+			name: "invalid version",
+			content: `
+version: 0.2
+data: {}
+`,
+			wantErr: "invalid version '0.2', the version must be 0.1",
+		},
+		{
+			name: "stub is not a map",
+			content: `
+version: 0.1
+data:
+  data.aws_ami.ubuntu: ami-0123456789abcdef0
+`,
+			wantErr: "stub for data.aws_ami.ubuntu must be a map of attribute values",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseDataStubs([]byte(tt.content))
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestDataStubs(t *testing.T) {
+	path := createTestFile("test.tf", `
+provider "aws" {
+  region = "eu-west-1"
+}
+
+provider "google" {
+  alias  = "europe"
+  region = "europe-west1"
+}
+
+data "aws_availability_zones" "available" {}
+
+data "google_compute_zones" "europe" {
+  provider = google.europe
+}
+
+data "aws_ami" "ubuntu" {
+  most_recent = true
+}
+
+data "aws_ec2_instance_type" "large" {
+  instance_type = "m5.large"
+}
+
+data "aws_ec2_instance_type" "xlarge" {
+  instance_type = "m5.xlarge"
+}
+
+data "aws_ssm_parameter" "instance_type" {
+  name = "/app/instance_type"
+}
+
+data "aws_caller_identity" "current" {}
+
+resource "aws_instance" "web" {
+  count             = length(data.aws_availability_zones.available.names)
+  availability_zone = data.aws_availability_zones.available.names[count.index]
+  ami               = data.aws_ami.ubuntu.id
+  instance_type     = data.aws_ssm_parameter.instance_type.value
+  cpu_core_count    = data.aws_ec2_instance_type.xlarge.default_vcpus
+}
+
+output "large_vcpus" {
+  value = data.aws_ec2_instance_type.large.default_vcpus
+}
+
+output "europe_zone" {
+  value = data.google_compute_zones.europe.names[0]
+}
+`)
+
+	stubs, err := ParseDataStubs([]byte(`
+version: 0.1
+data:
+  data.aws_ami.ubuntu:
+    id: ami-0123456789abcdef0
+  aws_ec2_instance_type:
+    default_vcpus: 2
+  data.aws_ec2_instance_type.xlarge:
+    default_vcpus: 4
+`))
+	require.NoError(t, err)
+
+	logger := newDiscardLogger()
+	parser := newParser(RootPath{Path: filepath.Dir(path)}, modules.NewModuleLoader(filepath.Dir(path), nil, logger, &sync.KeyMutex{}), logger, OptionWithDataStubs(stubs))
+	module, err := parser.ParseDirectory()
+	require.NoError(t, err)
+
+	instances := module.Blocks.OfType("resource")
+	require.Len(t, instances, 3)
+
+	instance := instances[0]
+	assert.Equal(t, "ami-0123456789abcdef0", instance.GetAttribute("ami").Value().AsString())
+	assert.Equal(t, "eu-west-1b", instances[1].GetAttribute("availability_zone").Value().AsString())
+	cores, _ := instance.GetAttribute("cpu_core_count").Value().AsBigFloat().Int64()
+	assert.Equal(t, int64(4), cores)
+
+	output := module.Blocks.Matching(BlockMatcher{Type: "output", Label: "large_vcpus"})
+	require.NotNil(t, output)
+	vcpus, _ := output.GetAttribute("value").Value().AsBigFloat().Int64()
+	assert.Equal(t, int64(2), vcpus)
+
+	output = module.Blocks.Matching(BlockMatcher{Type: "output", Label: "europe_zone"})
+	require.NotNil(t, output)
+	assert.Equal(t, "europe-west1-a", output.GetAttribute("value").Value().AsString())
+
+	// data.aws_caller_identity.current has no stub either, but isn't referenced by a resource.
+	require.Len(t, module.Warnings, 2)
+	assert.Equal(t, WarningUnresolvedDataSources, module.Warnings[0].Code)
+	assert.Equal(t, []string{"data.aws_ssm_parameter.instance_type"}, module.Warnings[0].Data)
+}
+
+func TestDataStubsWithoutRegion(t *testing.T) {
+	path := createTestFile("test.tf", `
+data "aws_availability_zones" "available" {}
+
+resource "aws_subnet" "private" {
+  availability_zone = data.aws_availability_zones.available.names[0]
+}
+`)
+
+	logger := newDiscardLogger()
+	parser := newParser(RootPath{Path: filepath.Dir(path)}, modules.NewModuleLoader(filepath.Dir(path), nil, logger, &sync.KeyMutex{}), logger)
+	module, err := parser.ParseDirectory()
+	require.NoError(t, err)
+
+	// the availability zones are left unresolved rather than stubbed with the zones of another region.
+	require.NotEmpty(t, module.Warnings)
+	assert.Equal(t, WarningUnresolvedDataSources, module.Warnings[0].Code)
+	assert.Equal(t, []string{"data.aws_availability_zones.available"}, module.Warnings[0].Data)
+}
@@ -0,0 +1,500 @@
+package hcl
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+)
+
+const (
+	// DiagnosticUnknown is the status of attributes whose value is only known after apply, e.g. because they
+	// depend on an attribute that is computed by the provider.
+	DiagnosticUnknown = "unknown"
+	// DiagnosticDefaulted is the status of attributes whose value couldn't be evaluated, so the evaluator
+	// used a mock value in its place.
+	DiagnosticDefaulted = "defaulted"
+)
+
+// AttributeDiagnostic describes a resource attribute that ended up unknown or defaulted after evaluation.
+type AttributeDiagnostic struct {
+	// Address is the full address of the resource, e.g. module.web.aws_instance.app[0].
+	Address string `json:"address"`
+	// Attribute is the name of the attribute. Attributes of nested blocks are prefixed with the
+	// block type, e.g. root_block_device.volume_size.
+	Attribute string `json:"attribute"`
+	Status    string `json:"status"`
+	Reason    string `json:"reason"`
+	// Filename is the file of the attribute relative to the project root.
+	Filename  string `json:"filename"`
+	StartLine int    `json:"startLine"`
+	EndLine   int    `json:"endLine"`
+	// References is the chain of references from the attribute to the reference that couldn't be
+	// resolved, e.g. [local.instance_type, var.size] for an attribute that references
+	// local.instance_type when local.instance_type references var.size and var.size has no value.
+	References []string `json:"references,omitempty"`
+}
+
+// Location returns the file:line range of the attribute.
+func (d AttributeDiagnostic) Location() string {
+	if d.StartLine == d.EndLine {
+		return fmt.Sprintf("%s:%d", d.Filename, d.StartLine)
+	}
+
+	return fmt.Sprintf("%s:%d-%d", d.Filename, d.StartLine, d.EndLine)
+}
+
+// ReferenceChain returns the references of the diagnostic joined with arrows, e.g. local.size -> var.env.
+func (d AttributeDiagnostic) ReferenceChain() string {
+	return strings.Join(d.References, " -> ")
+}
+
+// diagnosticScope is a module that references are resolved in. call is the module block that
+// calls the module and parent is the scope of the calling module, these are nil for the root module.
+type diagnosticScope struct {
+	module *Module
+	call   *Block
+	parent *diagnosticScope
+}
+
+func newDiagnosticScope(module *Module, parent *diagnosticScope) *diagnosticScope {
+	s := &diagnosticScope{module: module, parent: parent}
+
+	for _, b := range module.Blocks {
+		if b.HasModuleBlock() {
+			s.call = b.moduleBlock
+			break
+		}
+	}
+
+	return s
+}
+
+// childScope returns the scope of the module called with the given name, or nil if the module wasn't loaded.
+func (s *diagnosticScope) childScope(name string) *diagnosticScope {
+	for _, child := range s.module.Modules {
+		c := newDiagnosticScope(child, s)
+		if c.call != nil && stripCount(c.call.Label()) == name {
+			return c
+		}
+	}
+
+	return nil
+}
+
+// unresolvedReference is a reference that couldn't be resolved and the chain of references that lead to it.
+type unresolvedReference struct {
+	chain  []string
+	status string
+	reason string
+}
+
+// diagnostician finds the attributes of the resources in a module tree that couldn't be fully evaluated.
+type diagnostician struct {
+	// inputVars are the input variables of the root module.
+	inputVars    map[string]cty.Value
+	resolvesData func(b *Block) bool
+	rootPath     string
+	// mockedData are the addresses of the data sources that have no stub or state and are
+	// referenced by resource attributes, so the attributes are evaluated with mock values.
+	mockedData map[string]bool
+}
+
+// diagnostics returns the diagnostics of the attributes of every resource in the module and its child modules
+// that ended up unknown or defaulted, and the sorted addresses of the data sources that have no stub or state
+// and that these attributes reference. The diagnostics are sorted by resource address and attribute.
+func (e *Evaluator) diagnostics(module *Module) ([]AttributeDiagnostic, []string) {
+	d := &diagnostician{
+		inputVars:    e.inputVars,
+		resolvesData: e.resolvesData,
+		rootPath:     module.RootPath,
+		mockedData:   make(map[string]bool),
+	}
+
+	var diags []AttributeDiagnostic
+
+	var walk func(s *diagnosticScope)
+	walk = func(s *diagnosticScope) {
+		for _, b := range s.module.Blocks.OfType("resource") {
+			diags = append(diags, d.blockDiagnostics(s, b, b, "")...)
+		}
+
+		for _, child := range s.module.Modules {
+			walk(newDiagnosticScope(child, s))
+		}
+	}
+	walk(newDiagnosticScope(module, nil))
+
+	sort.SliceStable(diags, func(i, j int) bool {
+		if diags[i].Address != diags[j].Address {
+			return diags[i].Address < diags[j].Address
+		}
+
+		return diags[i].Attribute < diags[j].Attribute
+	})
+
+	mockedData := make([]string, 0, len(d.mockedData))
+	for addr := range d.mockedData {
+		mockedData = append(mockedData, addr)
+	}
+	sort.Strings(mockedData)
+
+	return diags, mockedData
+}
+
+// blockDiagnostics returns the diagnostics of the attributes of b and its child blocks. resource is
+// the resource block that b belongs to and prefix is the prefix of the attribute names of b.
+func (d *diagnostician) blockDiagnostics(s *diagnosticScope, resource *Block, b *Block, prefix string) []AttributeDiagnostic {
+	var diags []AttributeDiagnostic
+
+	for _, attr := range b.GetAttributes() {
+		name := attr.Name()
+		if prefix == "" && (name == "depends_on" || name == "provider") {
+			continue
+		}
+
+		// the content of dynamic blocks references the iterator, which is only set when the block is expanded.
+		if b.Type() == "dynamic" && name != "for_each" {
+			continue
+		}
+
+		if diag, ok := d.attributeDiagnostic(s, resource, attr, prefix+name); ok {
+			diags = append(diags, diag)
+		}
+	}
+
+	for _, child := range b.Children() {
+		switch child.Type() {
+		case "lifecycle", "provisioner", "connection", "content":
+			continue
+		}
+
+		childPrefix := prefix + child.Type() + "."
+		if child.Type() == "dynamic" {
+			childPrefix = prefix + "dynamic." + child.TypeLabel() + "."
+		}
+
+		diags = append(diags, d.blockDiagnostics(s, resource, child, childPrefix)...)
+	}
+
+	return diags
+}
+
+// attributeDiagnostic returns the diagnostic of the attribute if its value is unknown or defaulted. Attributes
+// that reference something that can't be resolved are defaulted by the evaluator, so the references are
+// checked first as the evaluation context already holds the mocked values.
+func (d *diagnostician) attributeDiagnostic(s *diagnosticScope, resource *Block, attr *Attribute, name string) (AttributeDiagnostic, bool) {
+	diag := AttributeDiagnostic{
+		Address:   resource.FullName(),
+		Attribute: name,
+		Filename:  d.relativeFilename(attr.HCLAttr.Range.Filename),
+		StartLine: attr.HCLAttr.Range.Start.Line,
+		EndLine:   attr.HCLAttr.Range.End.Line,
+	}
+
+	if u := d.unresolvedExpression(s, attr.HCLAttr.Expr, map[string]bool{}); u != nil {
+		diag.Status = u.status
+		diag.Reason = u.reason
+		diag.References = u.chain
+		return diag, true
+	}
+
+	val, hclDiags := attr.HCLAttr.Expr.Value(attr.Ctx.Inner())
+	if hclDiags.HasErrors() {
+		diag.Status = DiagnosticDefaulted
+		diag.Reason = fmt.Sprintf("could not evaluate expression: %s", hclDiags[0].Summary)
+		return diag, true
+	}
+
+	if !val.IsWhollyKnown() {
+		diag.Status = DiagnosticUnknown
+		diag.Reason = "the value is unknown"
+		return diag, true
+	}
+
+	return diag, false
+}
+
+func (d *diagnostician) relativeFilename(filename string) string {
+	rel, err := filepath.Rel(d.rootPath, filename)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return filename
+	}
+
+	return rel
+}
+
+// unresolvedExpression returns the first reference of the expression that can't be resolved, or nil
+// if all the references of the expression can be resolved. visiting holds the references that are
+// being resolved so that reference cycles are skipped.
+func (d *diagnostician) unresolvedExpression(s *diagnosticScope, expr hcl.Expression, visiting map[string]bool) *unresolvedReference {
+	for _, traversal := range expressionTraversals(expr) {
+		if u := d.unresolvedTraversal(s, traversal, visiting); u != nil {
+			return u
+		}
+	}
+
+	return nil
+}
+
+// expressionTraversals returns the traversals of the variables that the expression references. The traversals
+// of splat expressions include the attribute of the elements, e.g. aws_instance.app[*].id rather than aws_instance.app.
+func expressionTraversals(expr hcl.Expression) []hcl.Traversal {
+	traversals := expr.Variables()
+
+	syntaxExpr, ok := expr.(hclsyntax.Expression)
+	if !ok {
+		return traversals
+	}
+
+	_ = hclsyntax.VisitAll(syntaxExpr, func(n hclsyntax.Node) hcl.Diagnostics {
+		splat, ok := n.(*hclsyntax.SplatExpr)
+		if !ok {
+			return nil
+		}
+
+		source, ok := splat.Source.(*hclsyntax.ScopeTraversalExpr)
+		if !ok {
+			return nil
+		}
+
+		each, ok := splat.Each.(*hclsyntax.RelativeTraversalExpr)
+		if !ok {
+			return nil
+		}
+
+		traversal := make(hcl.Traversal, 0, len(source.Traversal)+len(each.Traversal)+1)
+		traversal = append(traversal, source.Traversal...)
+		traversal = append(traversal, hcl.TraverseSplat{})
+		traversals = append(traversals, append(traversal, each.Traversal...))
+
+		return nil
+	})
+
+	return traversals
+}
+
+func (d *diagnostician) unresolvedTraversal(s *diagnosticScope, traversal hcl.Traversal, visiting map[string]bool) *unresolvedReference {
+	name := traversalString(traversal)
+
+	key := fmt.Sprintf("%p:%s", s.module, name)
+	if visiting[key] {
+		return nil
+	}
+	visiting[key] = true
+	defer delete(visiting, key)
+
+	next, expr, u := d.resolve(s, traversal)
+	if u == nil && expr != nil {
+		u = d.unresolvedExpression(next, expr, visiting)
+	}
+
+	if u == nil {
+		return nil
+	}
+
+	u.chain = append([]string{name}, u.chain...)
+	return u
+}
+
+// resolve resolves the reference in the scope. It returns the expression that the reference evaluates
+// to and the scope of the expression if the reference is declared in the configuration. It returns an
+// unresolvedReference without a chain if the reference has no value. References that don't refer to a
+// block of the module, e.g. each.key or count.index, are resolved.
+func (d *diagnostician) resolve(s *diagnosticScope, traversal hcl.Traversal) (*diagnosticScope, hcl.Expression, *unresolvedReference) {
+	names := traversalNames(traversal)
+
+	switch traversal.RootName() {
+	case "var":
+		if len(names) == 0 {
+			return nil, nil, nil
+		}
+
+		if s.call != nil {
+			if attr := s.call.GetAttribute(names[0]); attr != nil {
+				return s.parent, attr.HCLAttr.Expr, nil
+			}
+		} else if _, ok := d.inputVars[names[0]]; ok {
+			return nil, nil, nil
+		}
+
+		variable := findBlock(s.module, "variable", names[0])
+		if variable == nil {
+			return nil, nil, defaulted("variable %q is not declared", names[0])
+		}
+
+		if def := variable.GetAttribute("default"); def != nil {
+			return s, def.HCLAttr.Expr, nil
+		}
+
+		return nil, nil, defaulted("variable %q has no value", names[0])
+	case "local":
+		if len(names) == 0 {
+			return nil, nil, nil
+		}
+
+		for _, b := range s.module.Blocks.OfType("locals") {
+			if attr := b.GetAttribute(names[0]); attr != nil {
+				return s, attr.HCLAttr.Expr, nil
+			}
+		}
+
+		return nil, nil, defaulted("local value %q is not declared", names[0])
+	case "module":
+		if len(names) < 2 {
+			return nil, nil, nil
+		}
+
+		child := s.childScope(names[0])
+		if child == nil {
+			return nil, nil, defaulted("module %q could not be loaded", names[0])
+		}
+
+		output := findBlock(child.module, "output", names[1])
+		if output == nil {
+			return nil, nil, defaulted("module %q has no output %q", names[0], names[1])
+		}
+
+		if attr := output.GetAttribute("value"); attr != nil {
+			return child, attr.HCLAttr.Expr, nil
+		}
+
+		return nil, nil, nil
+	case "data":
+		if len(names) < 2 {
+			return nil, nil, nil
+		}
+
+		b := findResourceBlock(s.module, "data", names[0], names[1])
+		if b == nil {
+			return nil, nil, defaulted("data source %q is not declared", "data."+names[0]+"."+names[1])
+		}
+
+		if d.resolvesData(b) {
+			return nil, nil, nil
+		}
+
+		if len(names) > 2 {
+			if attr := b.GetAttribute(names[2]); attr != nil {
+				return s, attr.HCLAttr.Expr, nil
+			}
+		}
+
+		addr := stripCount(b.FullName())
+		d.mockedData[addr] = true
+
+		return nil, nil, defaulted("data source %q has no stub or state", addr)
+	case "ephemeral":
+		if len(names) < 3 {
+			return nil, nil, nil
+		}
+
+		b := findResourceBlock(s.module, "ephemeral", names[0], names[1])
+		if b == nil {
+			return nil, nil, nil
+		}
+
+		if attr := b.GetAttribute(names[2]); attr != nil {
+			return s, attr.HCLAttr.Expr, nil
+		}
+
+		return nil, nil, unknown("%q is only known when the ephemeral resource is opened", names[2])
+	case "count", "each", "path", "terraform", "self":
+		return nil, nil, nil
+	}
+
+	if len(names) < 2 {
+		return nil, nil, nil
+	}
+
+	b := findResourceBlock(s.module, "resource", traversal.RootName(), names[0])
+	if b == nil {
+		return nil, nil, nil
+	}
+
+	if attr := b.GetAttribute(names[1]); attr != nil {
+		return s, attr.HCLAttr.Expr, nil
+	}
+
+	if b.GetChildBlock(names[1]) != nil {
+		return nil, nil, nil
+	}
+
+	return nil, nil, unknown("%q is computed by the provider and only known after apply", names[1])
+}
+
+func defaulted(format string, args ...interface{}) *unresolvedReference {
+	return &unresolvedReference{status: DiagnosticDefaulted, reason: fmt.Sprintf(format, args...)}
+}
+
+func unknown(format string, args ...interface{}) *unresolvedReference {
+	return &unresolvedReference{status: DiagnosticUnknown, reason: fmt.Sprintf(format, args...)}
+}
+
+// findBlock returns the block of the module with the given type and label, e.g. the variable block named size.
+func findBlock(module *Module, blockType string, label string) *Block {
+	for _, b := range module.Blocks.OfType(blockType) {
+		if b.Label() == label {
+			return b
+		}
+	}
+
+	return nil
+}
+
+// findResourceBlock returns the first resource, data or ephemeral block of the module with the given
+// type and name. Blocks expanded by count or for_each match their unexpanded name.
+func findResourceBlock(module *Module, blockType string, typeLabel string, name string) *Block {
+	for _, b := range module.Blocks.OfType(blockType) {
+		if b.TypeLabel() == typeLabel && stripCount(b.NameLabel()) == name {
+			return b
+		}
+	}
+
+	return nil
+}
+
+// traversalNames returns the names of the attributes that the traversal steps through after its root, ignoring
+// any indexes, e.g. [app, id] for aws_instance.app[0].id.
+func traversalNames(traversal hcl.Traversal) []string {
+	var names []string
+	for _, t := range traversal {
+		if v, ok := t.(hcl.TraverseAttr); ok {
+			names = append(names, v.Name)
+		}
+	}
+
+	return names
+}
+
+// traversalString returns the traversal as it's written in the configuration, e.g. local.sizes["prod"].
+func traversalString(traversal hcl.Traversal) string {
+	var sb strings.Builder
+	for _, t := range traversal {
+		switch v := t.(type) {
+		case hcl.TraverseRoot:
+			sb.WriteString(v.Name)
+		case hcl.TraverseAttr:
+			sb.WriteString("." + v.Name)
+		case hcl.TraverseIndex:
+			switch {
+			case !v.Key.IsKnown() || v.Key.IsNull():
+				sb.WriteString("[?]")
+			case v.Key.Type() == cty.String:
+				sb.WriteString(fmt.Sprintf("[%q]", v.Key.AsString()))
+			case v.Key.Type() == cty.Number:
+				sb.WriteString("[" + v.Key.AsBigFloat().Text('f', -1) + "]")
+			default:
+				sb.WriteString("[?]")
+			}
+		case hcl.TraverseSplat:
+			sb.WriteString("[*]")
+		}
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,134 @@
+package hcl
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infracost/infracost/internal/hcl/modules"
+	"github.com/infracost/infracost/internal/sync"
+)
+
+func TestEvaluationDiagnostics(t *testing.T) {
+	path := createTestFile("main.tf", `
+variable "env" {}
+
+variable "disk_size" {
+  default = 100
+}
+
+locals {
+  sizes = {
+    dev  = "t3.micro"
+    prod = "m5.large"
+  }
+
+  instance_type = local.sizes[var.env]
+}
+
+data "aws_ebs_snapshot" "latest" {
+  most_recent = true
+}
+
+resource "aws_instance" "web" {
+  ami           = "ami-674cbc1e"
+  instance_type = local.instance_type
+
+  root_block_device {
+    volume_size = var.disk_size
+  }
+}
+
+resource "aws_ebs_volume" "data" {
+  availability_zone = aws_instance.web.availability_zone
+  size              = data.aws_ebs_snapshot.latest.volume_size
+  type              = lookup({}, "gp3")
+}
+
+module "db" {
+  source = "./db"
+  size   = var.env
+}
+`)
+	dir := filepath.Dir(path)
+
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "db"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "db", "main.tf"), []byte(`
+variable "size" {}
+
+resource "aws_db_instance" "db" {
+  instance_class = "db.${var.size}"
+  engine         = "mysql"
+}
+`), 0600))
+
+	logger := newDiscardLogger()
+	parser := newParser(RootPath{Path: dir}, modules.NewModuleLoader(dir, nil, logger, &sync.KeyMutex{}), logger)
+	module, err := parser.ParseDirectory()
+	require.NoError(t, err)
+
+	var diagnostics []AttributeDiagnostic
+	for _, w := range module.Warnings {
+		if w.Code == WarningEvaluationDiagnostics {
+			diagnostics = w.Data.([]AttributeDiagnostic)
+		}
+	}
+
+	assert.Equal(t, []AttributeDiagnostic{
+		{
+			Address:    "aws_ebs_volume.data",
+			Attribute:  "availability_zone",
+			Status:     DiagnosticUnknown,
+			Reason:     `"availability_zone" is computed by the provider and only known after apply`,
+			Filename:   "main.tf",
+			StartLine:  31,
+			EndLine:    31,
+			References: []string{"aws_instance.web.availability_zone"},
+		},
+		{
+			Address:    "aws_ebs_volume.data",
+			Attribute:  "size",
+			Status:     DiagnosticDefaulted,
+			Reason:     `data source "data.aws_ebs_snapshot.latest" has no stub or state`,
+			Filename:   "main.tf",
+			StartLine:  32,
+			EndLine:    32,
+			References: []string{"data.aws_ebs_snapshot.latest.volume_size"},
+		},
+		{
+			Address:   "aws_ebs_volume.data",
+			Attribute: "type",
+			Status:    DiagnosticDefaulted,
+			Reason:    "could not evaluate expression: Invalid function argument",
+			Filename:  "main.tf",
+			StartLine: 33,
+			EndLine:   33,
+		},
+		{
+			Address:    "aws_instance.web",
+			Attribute:  "instance_type",
+			Status:     DiagnosticDefaulted,
+			Reason:     `variable "env" has no value`,
+			Filename:   "main.tf",
+			StartLine:  23,
+			EndLine:    23,
+			References: []string{"local.instance_type", "var.env"},
+		},
+		{
+			Address:    "module.db.aws_db_instance.db",
+			Attribute:  "instance_class",
+			Status:     DiagnosticDefaulted,
+			Reason:     `variable "env" has no value`,
+			Filename:   filepath.Join("db", "main.tf"),
+			StartLine:  5,
+			EndLine:    5,
+			References: []string{"var.size", "var.env"},
+		},
+	}, diagnostics)
+
+	assert.Equal(t, "main.tf:23", diagnostics[3].Location())
+	assert.Equal(t, "local.instance_type -> var.env", diagnostics[3].ReferenceChain())
+}
@@ -34,10 +34,11 @@ var (
 	nestedModReplace    = regexp.MustCompile(`\.module\.`)
 	modArrayPartReplace = regexp.MustCompile(`\[[^[]*\]`)
 	validBlocksToExpand = map[string]struct{}{
-		"resource": {},
-		"module":   {},
-		"dynamic":  {},
-		"data":     {},
+		"resource":  {},
+		"module":    {},
+		"dynamic":   {},
+		"data":      {},
+		"ephemeral": {},
 	}
 )
 
@@ -71,8 +72,14 @@ type Evaluator struct {
 	workspace string
 	// blockBuilder handles generating blocks in the evaluation step.
 	blockBuilder BlockBuilder
-	newSpinner   ui.SpinnerFunc
-	logger       *logrus.Entry
+	// dataStubs supplies the attribute values of data blocks, which can't be evaluated without calling the provider.
+	dataStubs *DataStubs
+	// remoteStates resolves the outputs of terraform_remote_state data blocks.
+	remoteStates *RemoteStates
+	// providerFunctions are the provider-defined functions that can be called in expressions.
+	providerFunctions *ProviderFunctions
+	newSpinner        ui.SpinnerFunc
+	logger            *logrus.Entry
 }
 
 // NewEvaluator returns an Evaluator with Context initialised with top level variables.
@@ -86,11 +93,14 @@ func NewEvaluator(
 	visitedModules map[string]map[string]cty.Value,
 	workspace string,
 	blockBuilder BlockBuilder,
+	dataStubs *DataStubs,
+	remoteStates *RemoteStates,
+	providerFunctions *ProviderFunctions,
 	spinFunc ui.SpinnerFunc,
 	logger *logrus.Entry,
 ) *Evaluator {
 	ctx := NewContext(&hcl.EvalContext{
-		Functions: expFunctions(module.RootPath, logger),
+		Functions: expFunctions(module.RootPath, providerFunctions, logger),
 	}, nil, logger)
 
 	if visitedModules == nil {
@@ -129,16 +139,19 @@ func NewEvaluator(
 	})
 
 	return &Evaluator{
-		module:         module,
-		ctx:            ctx,
-		inputVars:      inputVars,
-		moduleMetadata: moduleMetadata,
-		visitedModules: visitedModules,
-		workspace:      workspace,
-		workingDir:     workingDir,
-		blockBuilder:   blockBuilder,
-		newSpinner:     spinFunc,
-		logger:         l,
+		module:            module,
+		ctx:               ctx,
+		inputVars:         inputVars,
+		moduleMetadata:    moduleMetadata,
+		visitedModules:    visitedModules,
+		workspace:         workspace,
+		workingDir:        workingDir,
+		blockBuilder:      blockBuilder,
+		dataStubs:         dataStubs,
+		remoteStates:      remoteStates,
+		providerFunctions: providerFunctions,
+		newSpinner:        spinFunc,
+		logger:            l,
 	}
 }
 
@@ -194,8 +207,20 @@ func (e *Evaluator) collectModules() *Module {
 	}
 
 	if v := e.MissingVars(); len(v) > 0 {
-		root.Warnings = []Warning{
-			NewMissingVarsWarning(v),
+		root.Warnings = append(root.Warnings, NewMissingVarsWarning(v))
+	}
+
+	// child modules are walked by the root evaluator so that there is a single warning for the project.
+	if e.module.Parent == nil {
+		// only the data sources whose mock values end up in resource attributes are reported, as
+		// data sources such as aws_iam_policy_document or aws_caller_identity never affect the cost.
+		diagnostics, mockedData := e.diagnostics(&root)
+		if len(mockedData) > 0 {
+			root.Warnings = append(root.Warnings, NewUnresolvedDataSourcesWarning(mockedData))
+		}
+
+		if len(diagnostics) > 0 {
+			root.Warnings = append(root.Warnings, NewEvaluationDiagnosticsWarning(diagnostics))
 		}
 	}
 
@@ -247,6 +272,7 @@ func (e *Evaluator) evaluateStep(i int) {
 	}
 
 	e.ctx.Set(e.getValuesByBlockType("data"), "data")
+	e.ctx.Set(e.getValuesByBlockType("ephemeral"), "ephemeral")
 	e.ctx.Set(e.getValuesByBlockType("output"), "output")
 
 	e.evaluateModules()
@@ -286,6 +312,9 @@ func (e *Evaluator) evaluateModules() {
 			map[string]map[string]cty.Value{},
 			e.workspace,
 			e.blockBuilder,
+			e.dataStubs,
+			e.remoteStates,
+			e.providerFunctions,
 			nil,
 			e.logger,
 		)
@@ -630,7 +659,7 @@ func (e *Evaluator) getValuesByBlockType(blockType string) cty.Value {
 
 			e.logger.Debugf("adding %s %s to the evaluation context", b.Type(), b.Label())
 			values[b.Label()] = b.Values()
-		case "resource", "data":
+		case "resource", "data", "ephemeral":
 			if len(b.Labels()) < 2 {
 				continue
 			}
@@ -666,15 +695,100 @@ func (e *Evaluator) evaluateResource(b *Block, values map[string]cty.Value) cty.
 
 	if k := b.Index(); k != nil {
 		e.logger.Debugf("expanding block %s to be available for index key %d", b.FullName(), *k)
-		valueMap[stripCount(labels[1])] = expandCountBlockToValue(b, valueMap)
+		valueMap[stripCount(labels[1])] = expandCountBlockToValue(b, e.blockValues(b), valueMap)
 		return cty.ObjectVal(valueMap)
 	}
 
-	valueMap[b.Labels()[1]] = b.Values()
+	valueMap[b.Labels()[1]] = e.blockValues(b)
 	return cty.ObjectVal(valueMap)
 }
 
-func expandCountBlockToValue(b *Block, existingValues map[string]cty.Value) cty.Value {
+// blockValues returns the values of the resource or data block. Data blocks with a stub have the
+// stub attributes added to their values, and terraform_remote_state blocks have the outputs of the
+// state they resolve to.
+func (e *Evaluator) blockValues(b *Block) cty.Value {
+	if outputs, ok := e.remoteStateOutputs(b); ok {
+		return remoteStateValues(b, outputs)
+	}
+
+	stub, ok := e.dataStub(b)
+	if !ok {
+		return b.Values()
+	}
+
+	return stubbedValues(b, stub)
+}
+
+// dataStub returns the stub of the data block. Stubs of the data stubs take precedence over the built-in
+// regional stubs, which are only used if the region of the data block is known.
+func (e *Evaluator) dataStub(b *Block) (cty.Value, bool) {
+	if stub, ok := e.dataStubs.Lookup(b); ok {
+		return stub, true
+	}
+
+	build, ok := regionalDataStubs[b.TypeLabel()]
+	if !ok || b.Type() != "data" {
+		return cty.NilVal, false
+	}
+
+	region := b.GetAttribute("region").AsString()
+	if region == "" {
+		region = e.providerRegion(b)
+	}
+
+	if region == "" {
+		return cty.NilVal, false
+	}
+
+	return build(region), true
+}
+
+// providerRegion returns the region of the provider block that the block uses, or an empty string if
+// the provider block or its region can't be found. Provider blocks are inherited from the parent modules,
+// providers passed to module calls with the providers argument are not followed.
+func (e *Evaluator) providerRegion(b *Block) string {
+	providerType, alias, _ := strings.Cut(b.Provider(), ".")
+
+	for m := &e.module; m != nil; m = m.Parent {
+		for _, p := range m.Blocks.OfType("provider") {
+			if p.TypeLabel() != providerType || p.GetAttribute("alias").AsString() != alias {
+				continue
+			}
+
+			return p.GetAttribute("region").AsString()
+		}
+	}
+
+	return ""
+}
+
+// remoteStateOutputs returns the state outputs of a terraform_remote_state block. It returns false if
+// the block isn't a terraform_remote_state block or its state can't be resolved.
+func (e *Evaluator) remoteStateOutputs(b *Block) (cty.Value, bool) {
+	if b.Type() != "data" || b.TypeLabel() != "terraform_remote_state" {
+		return cty.NilVal, false
+	}
+
+	outputs, ok, err := e.remoteStates.outputs(b, e.module.RootPath)
+	if err != nil {
+		e.logger.WithError(err).Debugf("could not resolve the state of %s", b.FullName())
+		return cty.NilVal, false
+	}
+
+	return outputs, ok
+}
+
+// resolvesData returns true if the attributes of the data block can be evaluated from a stub or a state.
+func (e *Evaluator) resolvesData(b *Block) bool {
+	if _, ok := e.remoteStateOutputs(b); ok {
+		return true
+	}
+
+	_, ok := e.dataStub(b)
+	return ok
+}
+
+func expandCountBlockToValue(b *Block, val cty.Value, existingValues map[string]cty.Value) cty.Value {
 	k := b.Index()
 	if k == nil {
 		return cty.NilVal
@@ -693,7 +807,7 @@ func expandCountBlockToValue(b *Block, existingValues map[string]cty.Value) cty.
 		}
 	}
 
-	elements = append(elements, b.Values())
+	elements = append(elements, val)
 	return cty.TupleVal(elements)
 }
 
@@ -713,7 +827,7 @@ func (e *Evaluator) expandedEachBlockToValue(b *Block, existingValues map[string
 				"block": b.Label(),
 			}).Debugf("skipping unexpected cty value type '%s' for existing for_each context value", eachMap.GoString())
 
-			ob[*k] = b.Values()
+			ob[*k] = e.blockValues(b)
 			return cty.ObjectVal(ob)
 		}
 
@@ -722,7 +836,7 @@ func (e *Evaluator) expandedEachBlockToValue(b *Block, existingValues map[string
 		}
 	}
 
-	ob[*k] = b.Values()
+	ob[*k] = e.blockValues(b)
 	return cty.ObjectVal(ob)
 }
 
@@ -819,8 +933,8 @@ func (e *Evaluator) loadModules(lastContext hcl.EvalContext) []*ModuleCall {
 
 // expFunctions returns the set of functions that should be used to when evaluating
 // expressions in the receiving scope.
-func expFunctions(baseDir string, logger *logrus.Entry) map[string]function.Function {
-	return map[string]function.Function{
+func expFunctions(baseDir string, providerFunctions *ProviderFunctions, logger *logrus.Entry) map[string]function.Function {
+	functions := map[string]function.Function{
 		"abs":              stdlib.AbsoluteFunc,
 		"abspath":          funcs.AbsPathFunc,
 		"basename":         funcs.BasenameFunc,
@@ -928,4 +1042,7 @@ func expFunctions(baseDir string, logger *logrus.Entry) map[string]function.Func
 		"zipmap":           stdlib.ZipmapFunc,
 	}
 
+	providerFunctions.addTo(functions)
+
+	return functions
 }
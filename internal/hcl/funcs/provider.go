@@ -0,0 +1,324 @@
+package funcs
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/function"
+)
+
+var (
+	// providerFunctionCallRegex matches the provider::<provider>::<function> names of provider-defined
+	// function calls, which the HCL syntax we parse with doesn't support.
+	providerFunctionCallRegex = regexp.MustCompile(`\bprovider::([A-Za-z_][A-Za-z0-9_-]*)::([A-Za-z_][A-Za-z0-9_-]*)`)
+)
+
+// providerFunctionSeparator replaces the :: of provider-defined function names, so the names are
+// valid identifiers with the same length and the source ranges of the parsed files don't change.
+const providerFunctionSeparator = "--"
+
+// ProviderFunctionName returns the name that the provider::<provider>::<name> function is called
+// by once the source is rewritten with RewriteProviderFunctionCalls.
+func ProviderFunctionName(provider string, name string) string {
+	return strings.Join([]string{"provider", provider, name}, providerFunctionSeparator)
+}
+
+// RewriteProviderFunctionCalls rewrites the provider::<provider>::<function> calls in the source to
+// provider--<provider>--<function>, which is a valid identifier that can be parsed.
+func RewriteProviderFunctionCalls(src []byte) []byte {
+	return providerFunctionCallRegex.ReplaceAllFunc(src, func(match []byte) []byte {
+		return []byte(strings.ReplaceAll(string(match), "::", providerFunctionSeparator))
+	})
+}
+
+// These functions are pure implementations of the provider-defined functions of the AWS, Google
+// and built-in terraform providers, which Terraform 1.8+ configurations call with the
+// provider::<provider>::<function> syntax.
+
+var arnType = cty.Object(map[string]cty.Type{
+	"partition":  cty.String,
+	"service":    cty.String,
+	"region":     cty.String,
+	"account_id": cty.String,
+	"resource":   cty.String,
+})
+
+// AWSARNParseFunc constructs a function that parses an ARN into its partition, service,
+// region, account_id and resource parts, like provider::aws::arn_parse.
+var AWSARNParseFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{
+			Name: "arn",
+			Type: cty.String,
+		},
+	},
+	Type: function.StaticReturnType(arnType),
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		parts, err := parseARN(args[0].AsString())
+		if err != nil {
+			return cty.UnknownVal(retType), err
+		}
+
+		return cty.ObjectVal(map[string]cty.Value{
+			"partition":  cty.StringVal(parts[1]),
+			"service":    cty.StringVal(parts[2]),
+			"region":     cty.StringVal(parts[3]),
+			"account_id": cty.StringVal(parts[4]),
+			"resource":   cty.StringVal(parts[5]),
+		}), nil
+	},
+})
+
+// AWSARNBuildFunc constructs a function that builds an ARN from its partition, service, region,
+// account_id and resource parts, like provider::aws::arn_build.
+var AWSARNBuildFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{Name: "partition", Type: cty.String},
+		{Name: "service", Type: cty.String},
+		{Name: "region", Type: cty.String},
+		{Name: "account_id", Type: cty.String},
+		{Name: "resource", Type: cty.String},
+	},
+	Type: function.StaticReturnType(cty.String),
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		parts := []string{"arn"}
+		for _, arg := range args {
+			parts = append(parts, arg.AsString())
+		}
+
+		return cty.StringVal(strings.Join(parts, ":")), nil
+	},
+})
+
+// AWSTrimIAMRolePathFunc constructs a function that removes the path from an IAM role ARN,
+// like provider::aws::trim_iam_role_path.
+var AWSTrimIAMRolePathFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{
+			Name: "arn",
+			Type: cty.String,
+		},
+	},
+	Type: function.StaticReturnType(cty.String),
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		arn := args[0].AsString()
+		parts, err := parseARN(arn)
+		if err != nil {
+			return cty.UnknownVal(cty.String), err
+		}
+
+		if parts[2] != "iam" || !strings.HasPrefix(parts[5], "role/") {
+			return cty.UnknownVal(cty.String), fmt.Errorf("%q is not an IAM role ARN", arn)
+		}
+
+		segments := strings.Split(parts[5], "/")
+		parts[5] = "role/" + segments[len(segments)-1]
+
+		return cty.StringVal(strings.Join(parts, ":")), nil
+	},
+})
+
+func parseARN(arn string) ([]string, error) {
+	parts := strings.SplitN(arn, ":", 6)
+	if len(parts) != 6 || parts[0] != "arn" {
+		return nil, fmt.Errorf("%q is not a valid ARN", arn)
+	}
+
+	return parts, nil
+}
+
+// GoogleRegionFromZoneFunc constructs a function that returns the region of a zone, like
+// provider::google::region_from_zone.
+var GoogleRegionFromZoneFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{
+			Name: "zone",
+			Type: cty.String,
+		},
+	},
+	Type: function.StaticReturnType(cty.String),
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		zone := args[0].AsString()
+		i := strings.LastIndex(zone, "-")
+		if i <= 0 {
+			return cty.UnknownVal(cty.String), fmt.Errorf("%q is not a valid zone", zone)
+		}
+
+		return cty.StringVal(zone[:i]), nil
+	},
+})
+
+// GoogleNameFromIDFunc constructs a function that returns the name of a resource from its ID or
+// self link, like provider::google::name_from_id.
+var GoogleNameFromIDFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{
+			Name: "id",
+			Type: cty.String,
+		},
+	},
+	Type: function.StaticReturnType(cty.String),
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		segments := strings.Split(strings.TrimSuffix(args[0].AsString(), "/"), "/")
+		return cty.StringVal(segments[len(segments)-1]), nil
+	},
+})
+
+// GoogleProjectFromIDFunc constructs a function that returns the project of a resource from its
+// ID or self link, like provider::google::project_from_id.
+var GoogleProjectFromIDFunc = makeGoogleIDSegmentFunc("project", "projects")
+
+// GoogleLocationFromIDFunc constructs a function that returns the location, region or zone of a
+// resource from its ID or self link, like provider::google::location_from_id.
+var GoogleLocationFromIDFunc = makeGoogleIDSegmentFunc("location", "locations", "regions", "zones")
+
+// GoogleRegionFromIDFunc constructs a function that returns the region of a resource from its ID
+// or self link, like provider::google::region_from_id.
+var GoogleRegionFromIDFunc = makeGoogleIDSegmentFunc("region", "regions")
+
+// GoogleZoneFromIDFunc constructs a function that returns the zone of a resource from its ID or
+// self link, like provider::google::zone_from_id.
+var GoogleZoneFromIDFunc = makeGoogleIDSegmentFunc("zone", "zones")
+
+// makeGoogleIDSegmentFunc constructs a function that returns the segment of a Google resource ID
+// that follows the first of the collection names, e.g. the project of projects/my-project/zones/us-central1-a.
+func makeGoogleIDSegmentFunc(name string, collections ...string) function.Function {
+	return function.New(&function.Spec{
+		Params: []function.Parameter{
+			{
+				Name: "id",
+				Type: cty.String,
+			},
+		},
+		Type: function.StaticReturnType(cty.String),
+		Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+			id := args[0].AsString()
+			segments := strings.Split(id, "/")
+			for i := 0; i < len(segments)-1; i++ {
+				for _, collection := range collections {
+					if segments[i] == collection && segments[i+1] != "" {
+						return cty.StringVal(segments[i+1]), nil
+					}
+				}
+			}
+
+			return cty.UnknownVal(cty.String), fmt.Errorf("could not find the %s in %q", name, id)
+		},
+	})
+}
+
+// TerraformEncodeTfvarsFunc constructs a function that encodes an object as the contents of a
+// .tfvars file, like provider::terraform::encode_tfvars.
+var TerraformEncodeTfvarsFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{
+			Name: "value",
+			Type: cty.DynamicPseudoType,
+		},
+	},
+	Type: function.StaticReturnType(cty.String),
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		val := args[0]
+		if !val.IsWhollyKnown() {
+			return cty.UnknownVal(cty.String), nil
+		}
+
+		if val.IsNull() || !(val.Type().IsObjectType() || val.Type().IsMapType()) {
+			return cty.UnknownVal(cty.String), fmt.Errorf("value must be an object or a map, not %s", val.Type().FriendlyName())
+		}
+
+		values := val.AsValueMap()
+		names := make([]string, 0, len(values))
+		for name := range values {
+			if !hclsyntax.ValidIdentifier(name) {
+				return cty.UnknownVal(cty.String), fmt.Errorf("%q is not a valid variable name", name)
+			}
+
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		f := hclwrite.NewEmptyFile()
+		for _, name := range names {
+			f.Body().SetAttributeValue(name, values[name])
+		}
+
+		return cty.StringVal(string(f.Bytes())), nil
+	},
+})
+
+// TerraformDecodeTfvarsFunc constructs a function that decodes the contents of a .tfvars file
+// into an object, like provider::terraform::decode_tfvars.
+var TerraformDecodeTfvarsFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{
+			Name: "src",
+			Type: cty.String,
+		},
+	},
+	Type: func(args []cty.Value) (cty.Type, error) {
+		if !args[0].IsKnown() {
+			return cty.DynamicPseudoType, nil
+		}
+
+		val, err := decodeTfvars(args[0].AsString())
+		if err != nil {
+			return cty.NilType, err
+		}
+
+		return val.Type(), nil
+	},
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		return decodeTfvars(args[0].AsString())
+	},
+})
+
+func decodeTfvars(src string) (cty.Value, error) {
+	f, diags := hclsyntax.ParseConfig([]byte(src), "<decode_tfvars argument>", hcl.InitialPos)
+	if diags.HasErrors() {
+		return cty.NilVal, fmt.Errorf("invalid tfvars: %s", diags.Error())
+	}
+
+	attrs, diags := f.Body.JustAttributes()
+	if diags.HasErrors() {
+		return cty.NilVal, fmt.Errorf("invalid tfvars: %s", diags.Error())
+	}
+
+	values := make(map[string]cty.Value, len(attrs))
+	for name, attr := range attrs {
+		val, diags := attr.Expr.Value(nil)
+		if diags.HasErrors() {
+			return cty.NilVal, fmt.Errorf("invalid value for %s: %s", name, diags.Error())
+		}
+
+		values[name] = val
+	}
+
+	return cty.ObjectVal(values), nil
+}
+
+// TerraformEncodeExprFunc constructs a function that encodes a value as a Terraform expression,
+// like provider::terraform::encode_expr.
+var TerraformEncodeExprFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{
+			Name:      "value",
+			Type:      cty.DynamicPseudoType,
+			AllowNull: true,
+		},
+	},
+	Type: function.StaticReturnType(cty.String),
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		if !args[0].IsWhollyKnown() {
+			return cty.UnknownVal(cty.String), nil
+		}
+
+		return cty.StringVal(string(hclwrite.TokensForValue(args[0]).Bytes())), nil
+	},
+})
@@ -0,0 +1,156 @@
+package funcs
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/function"
+)
+
+func TestProviderFunctions(t *testing.T) {
+	tests := []struct {
+		Func function.Function
+		Args []cty.Value
+		Want cty.Value
+		Err  bool
+	}{
+		{
+			AWSARNParseFunc,
+			[]cty.Value{cty.StringVal("arn:aws:iam::123456789012:role/path/to/app")},
+			cty.ObjectVal(map[string]cty.Value{
+				"partition":  cty.StringVal("aws"),
+				"service":    cty.StringVal("iam"),
+				"region":     cty.StringVal(""),
+				"account_id": cty.StringVal("123456789012"),
+				"resource":   cty.StringVal("role/path/to/app"),
+			}),
+			false,
+		},
+		{ // Resources can contain colons
+			AWSARNParseFunc,
+			[]cty.Value{cty.StringVal("arn:aws:logs:us-east-1:123456789012:log-group:app:*")},
+			cty.ObjectVal(map[string]cty.Value{
+				"partition":  cty.StringVal("aws"),
+				"service":    cty.StringVal("logs"),
+				"region":     cty.StringVal("us-east-1"),
+				"account_id": cty.StringVal("123456789012"),
+				"resource":   cty.StringVal("log-group:app:*"),
+			}),
+			false,
+		},
+		{
+			AWSARNParseFunc,
+			[]cty.Value{cty.StringVal("not-an-arn")},
+			cty.UnknownVal(arnType),
+			true,
+		},
+		{
+			AWSARNBuildFunc,
+			[]cty.Value{cty.StringVal("aws"), cty.StringVal("s3"), cty.StringVal(""), cty.StringVal(""), cty.StringVal("bucket")},
+			cty.StringVal("arn:aws:s3:::bucket"),
+			false,
+		},
+		{
+			AWSTrimIAMRolePathFunc,
+			[]cty.Value{cty.StringVal("arn:aws:iam::123456789012:role/path/to/app")},
+			cty.StringVal("arn:aws:iam::123456789012:role/app"),
+			false,
+		},
+		{
+			AWSTrimIAMRolePathFunc,
+			[]cty.Value{cty.StringVal("arn:aws:s3:::bucket")},
+			cty.UnknownVal(cty.String),
+			true,
+		},
+		{
+			GoogleRegionFromZoneFunc,
+			[]cty.Value{cty.StringVal("us-central1-a")},
+			cty.StringVal("us-central1"),
+			false,
+		},
+		{
+			GoogleNameFromIDFunc,
+			[]cty.Value{cty.StringVal("projects/my-project/zones/us-central1-a/instances/my-instance")},
+			cty.StringVal("my-instance"),
+			false,
+		},
+		{
+			GoogleProjectFromIDFunc,
+			[]cty.Value{cty.StringVal("https://www.googleapis.com/compute/v1/projects/my-project/zones/us-central1-a/instances/my-instance")},
+			cty.StringVal("my-project"),
+			false,
+		},
+		{
+			GoogleLocationFromIDFunc,
+			[]cty.Value{cty.StringVal("projects/my-project/locations/europe-west1/functions/my-function")},
+			cty.StringVal("europe-west1"),
+			false,
+		},
+		{
+			GoogleZoneFromIDFunc,
+			[]cty.Value{cty.StringVal("projects/my-project/regions/us-central1/subnetworks/my-subnet")},
+			cty.UnknownVal(cty.String),
+			true,
+		},
+		{
+			TerraformEncodeTfvarsFunc,
+			[]cty.Value{cty.ObjectVal(map[string]cty.Value{
+				"size":  cty.NumberIntVal(3),
+				"name":  cty.StringVal("app"),
+				"zones": cty.TupleVal([]cty.Value{cty.StringVal("a"), cty.StringVal("b")}),
+			})},
+			cty.StringVal("name  = \"app\"\nsize  = 3\nzones = [\"a\", \"b\"]\n"),
+			false,
+		},
+		{
+			TerraformEncodeTfvarsFunc,
+			[]cty.Value{cty.StringVal("app")},
+			cty.UnknownVal(cty.String),
+			true,
+		},
+		{
+			TerraformDecodeTfvarsFunc,
+			[]cty.Value{cty.StringVal("name = \"app\"\nsize = 3\n")},
+			cty.ObjectVal(map[string]cty.Value{
+				"name": cty.StringVal("app"),
+				"size": cty.NumberIntVal(3),
+			}),
+			false,
+		},
+		{
+			TerraformEncodeExprFunc,
+			[]cty.Value{cty.ObjectVal(map[string]cty.Value{"size": cty.NumberIntVal(3)})},
+			cty.StringVal("{\n  size = 3\n}"),
+			false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%#v", test.Args), func(t *testing.T) {
+			got, err := test.Func.Call(test.Args)
+
+			if test.Err {
+				if err == nil {
+					t.Fatal("succeeded; want error")
+				}
+				return
+			} else if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if !got.RawEquals(test.Want) {
+				t.Errorf("wrong result\ngot:  %#v\nwant: %#v", got, test.Want)
+			}
+		})
+	}
+}
+
+func TestRewriteProviderFunctionCalls(t *testing.T) {
+	src := `value = provider::aws::arn_parse(var.arn).region != "" ? "${provider::google::name_from_id(var.id)}" : local.provider`
+
+	actual := string(RewriteProviderFunctionCalls([]byte(src)))
+	assert.Equal(t, `value = provider--aws--arn_parse(var.arn).region != "" ? "${provider--google--name_from_id(var.id)}" : local.provider`, actual)
+	assert.Len(t, actual, len(src))
+}
@@ -3,6 +3,9 @@ package hcl
 import (
 	"fmt"
 	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/zclconf/go-cty/cty"
 )
 
 // ModuleCall represents a call to a defined Module by a parent Module.
@@ -36,11 +39,86 @@ type Module struct {
 	HasChanges bool
 }
 
+// Move is a change of address declared with a moved block. From and To are
+// the full addresses of the resource or module before and after the move.
+type Move struct {
+	From string
+	To   string
+}
+
+// Moves returns the moves declared by the moved blocks of the module. Moved
+// blocks whose from or to aren't static addresses are ignored.
+func (m *Module) Moves() []Move {
+	var moves []Move
+
+	for _, b := range m.Blocks.OfType("moved") {
+		from, err := b.addressAttribute("from")
+		if err != nil {
+			b.logger.WithError(err).Debugf("ignoring moved block with an invalid from address")
+			continue
+		}
+
+		to, err := b.addressAttribute("to")
+		if err != nil {
+			b.logger.WithError(err).Debugf("ignoring moved block with an invalid to address")
+			continue
+		}
+
+		if prefix := b.ModuleAddress(); prefix != "" {
+			from = prefix + "." + from
+			to = prefix + "." + to
+		}
+
+		moves = append(moves, Move{From: from, To: to})
+	}
+
+	return moves
+}
+
+// addressAttribute returns the address that the attribute refers to, e.g.
+// module.web.aws_instance.app[0]. The attribute must be a static reference.
+func (b *Block) addressAttribute(name string) (string, error) {
+	attr := b.GetAttribute(name)
+	if attr == nil {
+		return "", fmt.Errorf("missing %s attribute", name)
+	}
+
+	traversal, diags := hcl.AbsTraversalForExpr(attr.HCLAttr.Expr)
+	if diags.HasErrors() {
+		return "", diags
+	}
+
+	var sb strings.Builder
+	for _, t := range traversal {
+		switch v := t.(type) {
+		case hcl.TraverseRoot:
+			sb.WriteString(v.Name)
+		case hcl.TraverseAttr:
+			sb.WriteString("." + v.Name)
+		case hcl.TraverseIndex:
+			switch v.Key.Type() {
+			case cty.String:
+				sb.WriteString(fmt.Sprintf("[%q]", v.Key.AsString()))
+			case cty.Number:
+				sb.WriteString("[" + v.Key.AsBigFloat().Text('f', -1) + "]")
+			default:
+				return "", fmt.Errorf("unsupported index in %s address", name)
+			}
+		default:
+			return "", fmt.Errorf("unsupported traversal in %s address", name)
+		}
+	}
+
+	return sb.String(), nil
+}
+
 // WarningCode is used to delineate warnings across Infracost.
 type WarningCode int
 
 const (
 	WarningMissingVars WarningCode = iota + 1
+	WarningUnresolvedDataSources
+	WarningEvaluationDiagnostics
 )
 
 // Warning holds information about non-critical errors that occurred within a module evaluation.
@@ -68,6 +146,37 @@ func NewMissingVarsWarning(vars []string) Warning {
 	}
 }
 
+// NewUnresolvedDataSourcesWarning returns a Warning using the WarningUnresolvedDataSources error code. It
+// expects that addresses is a list of data blocks that have no stub and are referenced by resource
+// attributes, so these attributes are evaluated with mock values.
+func NewUnresolvedDataSourcesWarning(addresses []string) Warning {
+	return Warning{
+		Code:  WarningUnresolvedDataSources,
+		Title: "Unresolved data sources",
+		Data:  addresses,
+		FriendlyMessage: fmt.Sprintf(
+			"Values could not be resolved for the following data sources: %s. %s",
+			joinQuotes(addresses),
+			"Use a data_stubs_file in the config file to specify their values.",
+		),
+	}
+}
+
+// NewEvaluationDiagnosticsWarning returns a Warning using the WarningEvaluationDiagnostics error code. It
+// expects that diagnostics are the resource attributes that ended up unknown or defaulted after evaluation.
+func NewEvaluationDiagnosticsWarning(diagnostics []AttributeDiagnostic) Warning {
+	return Warning{
+		Code:  WarningEvaluationDiagnostics,
+		Title: "Evaluation diagnostics",
+		Data:  diagnostics,
+		FriendlyMessage: fmt.Sprintf(
+			"%d resource attributes could not be fully evaluated. %s",
+			len(diagnostics),
+			"Use infracost diagnose --path to see them.",
+		),
+	}
+}
+
 func joinQuotes(elems []string) string {
 	quoted := make([]string, len(elems))
 	for i, elem := range elems {
@@ -16,6 +16,7 @@ import (
 	"github.com/sirupsen/logrus"
 	"golang.org/x/sync/errgroup"
 
+	"github.com/infracost/infracost/internal/hcl/funcs"
 	intSync "github.com/infracost/infracost/internal/sync"
 	"github.com/infracost/infracost/internal/ui"
 )
@@ -170,7 +171,7 @@ func (m *ModuleLoader) Load(path string) (man *Manifest, err error) {
 func (m *ModuleLoader) loadModules(path string, prefix string) ([]*ManifestModule, error) {
 	manifestModules := make([]*ManifestModule, 0)
 
-	module, diags := tfconfig.LoadModule(path)
+	module, diags := loadModule(path)
 	if diags.HasErrors() {
 		return nil, fmt.Errorf("failed to inspect module path %s diag: %w", path, diags.Err())
 	}
@@ -237,7 +238,7 @@ func (m *ModuleLoader) loadModule(moduleCall *tfconfig.ModuleCall, parentPath st
 		// Test if we can actually load the module. If not, then we should try re-loading it.
 		// This can happen if the directory the module was downloaded to has been deleted and moved
 		// so the existing manifest.json is out-of-date.
-		_, diags := tfconfig.LoadModule(path.Join(m.cachePath, manifestModule.Dir))
+		_, diags := loadModule(path.Join(m.cachePath, manifestModule.Dir))
 		if !diags.HasErrors() {
 			return manifestModule, err
 		}
@@ -360,3 +361,23 @@ func getProcessCount() int {
 
 	return numWorkers
 }
+
+// providerFunctionFS is a tfconfig.FS that rewrites the provider-defined function calls of the files it reads,
+// since tfconfig can't parse the provider::<provider>::<function> syntax.
+type providerFunctionFS struct {
+	tfconfig.FS
+}
+
+func (fs providerFunctionFS) ReadFile(name string) ([]byte, error) {
+	src, err := fs.FS.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return funcs.RewriteProviderFunctionCalls(src), nil
+}
+
+// loadModule inspects the module at the path with tfconfig.
+func loadModule(path string) (*tfconfig.Module, tfconfig.Diagnostics) {
+	return tfconfig.LoadModuleFromFilesystem(providerFunctionFS{tfconfig.NewOsFs()}, path)
+}
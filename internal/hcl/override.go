@@ -0,0 +1,265 @@
+package hcl
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/sirupsen/logrus"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// isOverrideFile returns true if the file at path is a Terraform override file, i.e. it is named
+// override.tf, override.tf.json or has a _override.tf or _override.tf.json suffix.
+func isOverrideFile(path string) bool {
+	name := filepath.Base(path)
+	name = strings.TrimSuffix(strings.TrimSuffix(name, ".json"), ".tf")
+
+	return name == "override" || strings.HasSuffix(name, "_override")
+}
+
+// mergeOverrides merges the blocks of the override files into the blocks of the primary files
+// using the Terraform override rules, see https://developer.hashicorp.com/terraform/language/files/override.
+// The override blocks are merged in order, so the override files should be loaded in lexical order.
+//
+// A top level override block is merged into the primary block with the same type and labels. The
+// attributes of the override block replace the attributes of the same name, and its nested blocks
+// replace all the nested blocks of the same type. The exceptions are:
+//
+//   - locals blocks, whose values replace the values of the same name wherever they are defined.
+//   - lifecycle blocks of resources and required_providers blocks of terraform blocks, which are
+//     merged attribute by attribute.
+//   - backend and cloud blocks of terraform blocks, which replace each other.
+//
+// Override blocks with no matching primary block are skipped, Terraform reports these as errors.
+func mergeOverrides(blocks hcl.Blocks, overrides hcl.Blocks, logger *logrus.Entry) hcl.Blocks {
+	if len(overrides) == 0 {
+		return blocks
+	}
+
+	merged := make(hcl.Blocks, len(blocks))
+	copy(merged, blocks)
+
+	for _, override := range overrides {
+		if override.Type == "locals" {
+			merged = overrideLocals(merged, override)
+			continue
+		}
+
+		key := overrideKey(override)
+		i := -1
+		for j, block := range merged {
+			if overrideKey(block) == key {
+				i = j
+				break
+			}
+		}
+
+		if i == -1 {
+			if override.Type == "terraform" {
+				merged = append(merged, override)
+				continue
+			}
+
+			logger.Warnf("skipping override block %s in %s, there is no block for it to override", key, override.DefRange.Filename)
+			continue
+		}
+
+		block, ok := mergeBlock(merged[i], override)
+		if !ok {
+			logger.Warnf("skipping override block %s in %s, only native syntax blocks can be merged", key, override.DefRange.Filename)
+			continue
+		}
+
+		merged[i] = block
+	}
+
+	return merged
+}
+
+// overrideLocals removes the local values that the override locals block defines from the
+// locals blocks and then adds the override block, so its values replace the existing ones.
+func overrideLocals(blocks hcl.Blocks, override *hcl.Block) hcl.Blocks {
+	attrs, diags := override.Body.JustAttributes()
+	if diags.HasErrors() || len(attrs) == 0 {
+		return append(blocks, override)
+	}
+
+	for i, block := range blocks {
+		body, ok := block.Body.(*hclsyntax.Body)
+		if block.Type != "locals" || !ok {
+			continue
+		}
+
+		var replaced bool
+		for name := range attrs {
+			if _, ok := body.Attributes[name]; ok {
+				replaced = true
+				break
+			}
+		}
+
+		if !replaced {
+			continue
+		}
+
+		mergedBody := *body
+		mergedBody.Attributes = make(hclsyntax.Attributes, len(body.Attributes))
+		for name, attr := range body.Attributes {
+			if _, ok := attrs[name]; !ok {
+				mergedBody.Attributes[name] = attr
+			}
+		}
+
+		mergedBlock := *block
+		mergedBlock.Body = &mergedBody
+		blocks[i] = &mergedBlock
+	}
+
+	return append(blocks, override)
+}
+
+// overrideKey returns the key that matches an override block with its primary block. Blocks
+// match if they have the same type and labels, and provider blocks also need the same alias.
+func overrideKey(block *hcl.Block) string {
+	key := strings.Join(append([]string{block.Type}, block.Labels...), ".")
+
+	if block.Type == "provider" {
+		if alias := staticStringAttribute(block, "alias"); alias != "" {
+			key += "." + alias
+		}
+	}
+
+	return key
+}
+
+// staticStringAttribute returns the value of the named attribute of the block if it is a string
+// that can be evaluated without any context.
+func staticStringAttribute(block *hcl.Block, name string) string {
+	attrs, _ := block.Body.JustAttributes()
+	attr, ok := attrs[name]
+	if !ok {
+		return ""
+	}
+
+	val, diags := attr.Expr.Value(nil)
+	if diags.HasErrors() || !val.IsKnown() || val.IsNull() || val.Type() != cty.String {
+		return ""
+	}
+
+	return val.AsString()
+}
+
+// mergeBlock returns a copy of the block with the override block merged into it. It returns false
+// if either of the blocks aren't native syntax blocks.
+func mergeBlock(block *hcl.Block, override *hcl.Block) (*hcl.Block, bool) {
+	body, ok := block.Body.(*hclsyntax.Body)
+	if !ok {
+		return nil, false
+	}
+
+	overrideBody, ok := override.Body.(*hclsyntax.Body)
+	if !ok {
+		return nil, false
+	}
+
+	var mergedBody *hclsyntax.Body
+	switch block.Type {
+	case "resource", "data":
+		mergedBody = mergeBody(body, overrideBody, []string{"lifecycle"}, nil)
+	case "terraform":
+		mergedBody = mergeBody(body, overrideBody, []string{"required_providers"}, [][]string{{"backend", "cloud"}})
+	default:
+		mergedBody = mergeBody(body, overrideBody, nil, nil)
+	}
+
+	merged := *block
+	merged.Body = mergedBody
+
+	return &merged, true
+}
+
+// mergeBody returns a copy of the body with the attributes and nested blocks of the override body
+// merged into it. Nested blocks of the mergedTypes are merged attribute by attribute, the nested
+// blocks of the other types replace all the nested blocks of the same type. Nested blocks of the
+// same exclusive group replace each other.
+func mergeBody(body *hclsyntax.Body, override *hclsyntax.Body, mergedTypes []string, exclusiveGroups [][]string) *hclsyntax.Body {
+	merged := *body
+
+	merged.Attributes = make(hclsyntax.Attributes, len(body.Attributes)+len(override.Attributes))
+	for name, attr := range body.Attributes {
+		merged.Attributes[name] = attr
+	}
+	for name, attr := range override.Attributes {
+		merged.Attributes[name] = attr
+	}
+
+	replacedTypes := make(map[string]bool)
+	for _, b := range override.Blocks {
+		t := nestedBlockType(b)
+		if contains(mergedTypes, t) {
+			continue
+		}
+
+		replacedTypes[t] = true
+		for _, group := range exclusiveGroups {
+			if contains(group, t) {
+				for _, g := range group {
+					replacedTypes[g] = true
+				}
+			}
+		}
+	}
+
+	merged.Blocks = make(hclsyntax.Blocks, 0, len(body.Blocks)+len(override.Blocks))
+	for _, b := range body.Blocks {
+		if !replacedTypes[nestedBlockType(b)] {
+			merged.Blocks = append(merged.Blocks, b)
+		}
+	}
+
+	for _, b := range override.Blocks {
+		if !contains(mergedTypes, nestedBlockType(b)) {
+			merged.Blocks = append(merged.Blocks, b)
+			continue
+		}
+
+		var existing bool
+		for i, mb := range merged.Blocks {
+			if mb.Type == b.Type {
+				mergedBlock := *mb
+				mergedBlock.Body = mergeBody(mb.Body, b.Body, nil, nil)
+				merged.Blocks[i] = &mergedBlock
+				existing = true
+				break
+			}
+		}
+
+		if !existing {
+			merged.Blocks = append(merged.Blocks, b)
+		}
+	}
+
+	return &merged
+}
+
+// nestedBlockType returns the type of the nested block, or the type of the blocks that it
+// generates if it is a dynamic block.
+func nestedBlockType(block *hclsyntax.Block) string {
+	if block.Type == "dynamic" && len(block.Labels) > 0 {
+		return block.Labels[0]
+	}
+
+	return block.Type
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
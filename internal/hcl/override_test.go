@@ -0,0 +1,98 @@
+package hcl
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+
+	"github.com/infracost/infracost/internal/hcl/modules"
+	"github.com/infracost/infracost/internal/sync"
+	"github.com/infracost/infracost/internal/testutil"
+)
+
+func Test_OverrideFiles(t *testing.T) {
+	testdata := filepath.Join("testdata", "overrides")
+	entries, err := os.ReadDir(testdata)
+	require.NoError(t, err)
+
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+
+		name := entry.Name()
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(testdata, name)
+			// the module loader writes a modules manifest to the project
+			t.Cleanup(func() {
+				_ = os.RemoveAll(filepath.Join(path, ".infracost"))
+			})
+
+			logger := newDiscardLogger()
+			loader := modules.NewModuleLoader(path, nil, logger, &sync.KeyMutex{})
+			parsers, err := LoadParsers(path, loader, nil, logger, OptionStopOnHCLError(), OptionWithBlockBuilder(BlockBuilder{Logger: logger}))
+			require.NoError(t, err)
+			require.Len(t, parsers, 1)
+
+			module, err := parsers[0].ParseDirectory()
+			require.NoError(t, err)
+
+			var sb strings.Builder
+			writeModuleBlocks(&sb, module)
+
+			testutil.AssertGoldenFile(t, filepath.Join(path, "expected.golden"), []byte(sb.String()))
+		})
+	}
+}
+
+// writeModuleBlocks writes the blocks of the module and its child modules with their evaluated
+// attribute values, so the merged blocks can be compared with a golden file.
+func writeModuleBlocks(sb *strings.Builder, module *Module) {
+	blocks := make(Blocks, len(module.Blocks))
+	copy(blocks, module.Blocks)
+	sort.SliceStable(blocks, func(i, j int) bool {
+		return blocks[i].FullName() < blocks[j].FullName()
+	})
+
+	for _, block := range blocks {
+		writeBlock(sb, block, strings.TrimSuffix(block.FullName(), "."), "")
+	}
+
+	for _, child := range module.Modules {
+		writeModuleBlocks(sb, child)
+	}
+}
+
+func writeBlock(sb *strings.Builder, block *Block, name string, indent string) {
+	sb.WriteString(fmt.Sprintf("%s%s {\n", indent, name))
+
+	attrs := block.GetAttributes()
+	sort.Slice(attrs, func(i, j int) bool {
+		return attrs[i].Name() < attrs[j].Name()
+	})
+
+	for _, attr := range attrs {
+		val := attr.Value()
+		s := "(unknown)"
+		if val.IsWhollyKnown() {
+			b, err := ctyjson.Marshal(val, val.Type())
+			if err == nil {
+				s = string(b)
+			}
+		}
+
+		sb.WriteString(fmt.Sprintf("%s  %s = %s\n", indent, attr.Name(), s))
+	}
+
+	for _, child := range block.Children() {
+		writeBlock(sb, child, strings.Join(append([]string{child.Type()}, child.Labels()...), " "), indent+"  ")
+	}
+
+	sb.WriteString(fmt.Sprintf("%s}\n", indent))
+}
@@ -6,6 +6,7 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/hashicorp/hcl/v2"
@@ -154,6 +155,30 @@ func OptionWithBlockBuilder(blockBuilder BlockBuilder) Option {
 	}
 }
 
+// OptionWithDataStubs adds the data stubs to the built-in stubs that the Parser uses to evaluate data
+// blocks. The provided stubs take precedence over the built-in stubs.
+func OptionWithDataStubs(stubs *DataStubs) Option {
+	return func(p *Parser) {
+		p.dataStubs = p.dataStubs.Merge(stubs)
+	}
+}
+
+// OptionWithRemoteStates sets the RemoteStates that the Parser uses to resolve terraform_remote_state
+// data blocks from state files and other projects. Blocks using the local backend are resolved without it.
+func OptionWithRemoteStates(remoteStates *RemoteStates) Option {
+	return func(p *Parser) {
+		p.remoteStates = remoteStates
+	}
+}
+
+// OptionWithProviderFunctions adds the provider-defined functions to the built-in functions that can be
+// called in expressions. The provided functions take precedence over the built-in functions.
+func OptionWithProviderFunctions(functions *ProviderFunctions) Option {
+	return func(p *Parser) {
+		p.providerFunctions = p.providerFunctions.Merge(functions)
+	}
+}
+
 // OptionWithTerraformWorkspace informs the Parser to use the provided name as the workspace for context evaluation.
 // The Parser exposes this workspace in the evaluation context under the variable named `terraform.workspace`.
 // This is commonly used by users to specify different capacity/configuration in their Terraform, e.g:
@@ -196,6 +221,9 @@ type Parser struct {
 	workspaceName         string
 	moduleLoader          *modules.ModuleLoader
 	blockBuilder          BlockBuilder
+	dataStubs             *DataStubs
+	remoteStates          *RemoteStates
+	providerFunctions     *ProviderFunctions
 	newSpinner            ui.SpinnerFunc
 	remoteVariablesLoader *RemoteVariablesLoader
 	credentialsSource     *modules.CredentialsSource
@@ -231,12 +259,14 @@ func newParser(projectRoot RootPath, moduleLoader *modules.ModuleLoader, logger
 	})
 
 	p := &Parser{
-		initialPath:   projectRoot.Path,
-		hasChanges:    projectRoot.HasChanges,
-		workspaceName: defaultTerraformWorkspaceName,
-		blockBuilder:  BlockBuilder{SetAttributes: []SetAttributesFunc{SetUUIDAttributes}, Logger: logger},
-		logger:        parserLogger,
-		moduleLoader:  moduleLoader,
+		initialPath:       projectRoot.Path,
+		hasChanges:        projectRoot.HasChanges,
+		workspaceName:     defaultTerraformWorkspaceName,
+		blockBuilder:      BlockBuilder{SetAttributes: []SetAttributesFunc{SetUUIDAttributes}, Logger: logger},
+		dataStubs:         DefaultDataStubs(),
+		providerFunctions: DefaultProviderFunctions(),
+		logger:            parserLogger,
+		moduleLoader:      moduleLoader,
 	}
 
 	var defaultVarFiles []string
@@ -330,6 +360,9 @@ func (p *Parser) ParseDirectory() (*Module, error) {
 		nil,
 		p.workspaceName,
 		p.blockBuilder,
+		p.dataStubs,
+		p.remoteStates,
+		p.providerFunctions,
 		p.newSpinner,
 		p.logger,
 	)
@@ -349,7 +382,7 @@ func (p *Parser) Path() string {
 }
 
 func (p *Parser) parseDirectoryFiles(files []file) (Blocks, error) {
-	var blocks Blocks
+	var hclBlocks, overrideBlocks hcl.Blocks
 
 	for _, file := range files {
 		fileBlocks, err := loadBlocksFromFile(file, nil)
@@ -366,12 +399,20 @@ func (p *Parser) parseDirectoryFiles(files []file) (Blocks, error) {
 			p.logger.Debugf("Added %d blocks from %s...", len(fileBlocks), fileBlocks[0].DefRange.Filename)
 		}
 
-		for _, fileBlock := range fileBlocks {
-			blocks = append(
-				blocks,
-				p.blockBuilder.NewBlock(file.path, fileBlock, nil, nil),
-			)
+		if isOverrideFile(file.path) {
+			overrideBlocks = append(overrideBlocks, fileBlocks...)
+			continue
 		}
+
+		hclBlocks = append(hclBlocks, fileBlocks...)
+	}
+
+	var blocks Blocks
+	for _, hclBlock := range mergeOverrides(hclBlocks, overrideBlocks, p.logger) {
+		blocks = append(
+			blocks,
+			p.blockBuilder.NewBlock(hclBlock.DefRange.Filename, hclBlock, nil, nil),
+		)
 	}
 
 	return blocks, nil
@@ -498,22 +539,13 @@ func loadDirectory(logger *logrus.Entry, fullPath string, stopOnHCLError bool) (
 			continue
 		}
 
-		var parseFunc func(filename string) (*hcl.File, hcl.Diagnostics)
-		if strings.HasSuffix(info.Name(), ".tf") {
-			parseFunc = hclParser.ParseHCLFile
-		}
-
-		if strings.HasSuffix(info.Name(), ".tf.json") {
-			parseFunc = hclParser.ParseJSONFile
-		}
-
 		// this is not a file we can parse:
-		if parseFunc == nil {
+		if !isTerraformFile(info.Name()) {
 			continue
 		}
 
 		path := filepath.Join(fullPath, info.Name())
-		_, diag := parseFunc(path)
+		_, diag := parseTerraformFile(hclParser, path)
 		if diag != nil && diag.HasErrors() {
 			if stopOnHCLError {
 				return nil, diag
@@ -529,5 +561,10 @@ func loadDirectory(logger *logrus.Entry, fullPath string, stopOnHCLError bool) (
 		files = append(files, file{hclFile: f, path: filename})
 	}
 
+	// sort the files so that override files are merged in lexical order, as they are by Terraform.
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].path < files[j].path
+	})
+
 	return files, nil
 }
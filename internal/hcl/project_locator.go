@@ -5,7 +5,6 @@ import (
 	"path/filepath"
 	"strings"
 
-	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/hcl/v2/hclparse"
 	"github.com/sirupsen/logrus"
 	"github.com/zclconf/go-cty/cty"
@@ -192,21 +191,12 @@ func (p *ProjectLocator) walkPaths(fullPath string, level int) []string {
 			continue
 		}
 
-		var parseFunc func(filename string) (*hcl.File, hcl.Diagnostics)
-		if strings.HasSuffix(info.Name(), ".tf") {
-			parseFunc = hclParser.ParseHCLFile
-		}
-
-		if strings.HasSuffix(info.Name(), ".tf.json") {
-			parseFunc = hclParser.ParseJSONFile
-		}
-
-		if parseFunc == nil {
+		if !isTerraformFile(info.Name()) {
 			continue
 		}
 
 		path := filepath.Join(fullPath, info.Name())
-		_, diag := parseFunc(path)
+		_, diag := parseTerraformFile(hclParser, path)
 		if diag != nil && diag.HasErrors() {
 			p.logger.Warnf("skipping file: %s hcl parsing err: %s", path, diag.Error())
 			continue
@@ -0,0 +1,105 @@
+package hcl
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclparse"
+	"github.com/zclconf/go-cty/cty/function"
+
+	"github.com/infracost/infracost/internal/hcl/funcs"
+)
+
+// ProviderFunctions is a registry of the provider-defined functions, e.g. provider::aws::arn_parse,
+// that can be called in expressions. Calls to functions that aren't registered can't be evaluated.
+type ProviderFunctions struct {
+	funcs map[string]function.Function
+}
+
+// DefaultProviderFunctions returns a registry with the built-in implementations of the provider-defined
+// functions that are pure, so they can be evaluated without calling the provider.
+func DefaultProviderFunctions() *ProviderFunctions {
+	f := &ProviderFunctions{}
+
+	f.Register("aws", "arn_build", funcs.AWSARNBuildFunc)
+	f.Register("aws", "arn_parse", funcs.AWSARNParseFunc)
+	f.Register("aws", "trim_iam_role_path", funcs.AWSTrimIAMRolePathFunc)
+	f.Register("google", "location_from_id", funcs.GoogleLocationFromIDFunc)
+	f.Register("google", "name_from_id", funcs.GoogleNameFromIDFunc)
+	f.Register("google", "project_from_id", funcs.GoogleProjectFromIDFunc)
+	f.Register("google", "region_from_id", funcs.GoogleRegionFromIDFunc)
+	f.Register("google", "region_from_zone", funcs.GoogleRegionFromZoneFunc)
+	f.Register("google", "zone_from_id", funcs.GoogleZoneFromIDFunc)
+	f.Register("terraform", "decode_tfvars", funcs.TerraformDecodeTfvarsFunc)
+	f.Register("terraform", "encode_expr", funcs.TerraformEncodeExprFunc)
+	f.Register("terraform", "encode_tfvars", funcs.TerraformEncodeTfvarsFunc)
+
+	return f
+}
+
+// Register adds the function to the registry as provider::<provider>::<name>, replacing any function
+// that is already registered with the name.
+func (f *ProviderFunctions) Register(provider string, name string, fn function.Function) {
+	if f.funcs == nil {
+		f.funcs = make(map[string]function.Function)
+	}
+
+	f.funcs[funcs.ProviderFunctionName(provider, name)] = fn
+}
+
+// Merge returns the functions with the functions of other added to them. The functions of other take precedence.
+func (f *ProviderFunctions) Merge(other *ProviderFunctions) *ProviderFunctions {
+	merged := &ProviderFunctions{funcs: make(map[string]function.Function)}
+	for _, fns := range []*ProviderFunctions{f, other} {
+		if fns == nil {
+			continue
+		}
+
+		for k, v := range fns.funcs {
+			merged.funcs[k] = v
+		}
+	}
+
+	return merged
+}
+
+// addTo adds the functions to the functions of an evaluation context under the names that the
+// provider-defined function calls are rewritten to.
+func (f *ProviderFunctions) addTo(functions map[string]function.Function) {
+	if f == nil {
+		return
+	}
+
+	for k, v := range f.funcs {
+		functions[k] = v
+	}
+}
+
+// isTerraformFile returns true if the file is a Terraform file in native or JSON syntax.
+func isTerraformFile(filename string) bool {
+	return strings.HasSuffix(filename, ".tf") || strings.HasSuffix(filename, ".tf.json")
+}
+
+// parseTerraformFile parses the Terraform file with the hclParser. Provider-defined function calls are
+// rewritten before the file is parsed, see funcs.RewriteProviderFunctionCalls.
+func parseTerraformFile(hclParser *hclparse.Parser, filename string) (*hcl.File, hcl.Diagnostics) {
+	src, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, hcl.Diagnostics{
+			{
+				Severity: hcl.DiagError,
+				Summary:  "Failed to read file",
+				Detail:   fmt.Sprintf("The configuration file %q could not be read.", filename),
+			},
+		}
+	}
+
+	src = funcs.RewriteProviderFunctionCalls(src)
+	if strings.HasSuffix(filename, ".json") {
+		return hclParser.ParseJSON(src, filename)
+	}
+
+	return hclParser.ParseHCL(src, filename)
+}
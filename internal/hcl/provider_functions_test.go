@@ -0,0 +1,92 @@
+package hcl
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/function"
+
+	"github.com/infracost/infracost/internal/hcl/modules"
+	"github.com/infracost/infracost/internal/sync"
+)
+
+func TestProviderFunctionsAndNewerBlocks(t *testing.T) {
+	path := createTestFile("main.tf", `
+variable "role_arn" {
+  default = "arn:aws:iam::123456789012:role/path/to/app"
+}
+
+variable "db_password" {
+  default   = "password"
+  ephemeral = true
+}
+
+locals {
+  role     = provider::aws::arn_parse(var.role_arn)
+  tfvars   = provider::terraform::encode_tfvars({ size = 3 })
+  sizes    = provider::terraform::decode_tfvars(local.tfvars)
+  region   = "${provider::google::region_from_zone("us-central1-a")}"
+  instance = provider::custom::instance_type("large")
+}
+
+ephemeral "aws_secretsmanager_secret_version" "db" {
+  secret_id = "db"
+}
+
+resource "aws_instance" "app" {
+  count         = local.sizes.size
+  ami           = "ami-0123456789abcdef0"
+  instance_type = local.instance
+  tags = {
+    Account = local.role.account_id
+    Region  = local.region
+    Secret  = ephemeral.aws_secretsmanager_secret_version.db.secret_id
+  }
+}
+
+import {
+  to = aws_instance.app[0]
+  id = "i-0123456789abcdef0"
+}
+
+removed {
+  from = aws_instance.old
+
+  lifecycle {
+    destroy = false
+  }
+}
+`)
+
+	custom := &ProviderFunctions{}
+	custom.Register("custom", "instance_type", function.New(&function.Spec{
+		Params: []function.Parameter{{Name: "size", Type: cty.String}},
+		Type:   function.StaticReturnType(cty.String),
+		Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+			return cty.StringVal("m5." + args[0].AsString()), nil
+		},
+	}))
+
+	logger := newDiscardLogger()
+	parser := newParser(RootPath{Path: filepath.Dir(path)}, modules.NewModuleLoader(filepath.Dir(path), nil, logger, &sync.KeyMutex{}), logger, OptionStopOnHCLError(), OptionWithProviderFunctions(custom))
+	module, err := parser.ParseDirectory()
+	require.NoError(t, err)
+
+	resources := module.Blocks.OfType("resource")
+	require.Len(t, resources, 3)
+
+	instance := resources[0]
+	assert.Equal(t, "m5.large", instance.GetAttribute("instance_type").Value().AsString())
+
+	tags := instance.GetAttribute("tags").Value().AsValueMap()
+	assert.Equal(t, "123456789012", tags["Account"].AsString())
+	assert.Equal(t, "us-central1", tags["Region"].AsString())
+	assert.Equal(t, "db", tags["Secret"].AsString())
+
+	assert.Len(t, module.Blocks.OfType("ephemeral"), 1)
+	assert.Len(t, module.Blocks.OfType("import"), 1)
+	assert.Len(t, module.Blocks.OfType("removed"), 1)
+}
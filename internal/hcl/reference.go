@@ -143,6 +143,10 @@ var TypeData = Type{
 	name: "data",
 }
 
+var TypeEphemeral = Type{
+	name: "ephemeral",
+}
+
 var TypeResource = Type{
 	name:                  "resource",
 	removeTypeInReference: true,
@@ -176,6 +180,7 @@ var TypeTerraform = Type{
 
 var ValidTypes = []Type{
 	TypeData,
+	TypeEphemeral,
 	TypeLocal,
 	TypeModule,
 	TypeOutput,
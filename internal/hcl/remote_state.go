@@ -0,0 +1,190 @@
+package hcl
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/zclconf/go-cty/cty"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+)
+
+const (
+	defaultLocalStatePath    = "terraform.tfstate"
+	defaultLocalWorkspaceDir = "terraform.tfstate.d"
+)
+
+// RemoteStates resolves the outputs of terraform_remote_state data blocks. A data block is resolved
+// from the state file or the project that its address is mapped to. Data blocks that aren't mapped
+// are resolved from the state file of the local backend if they use it.
+type RemoteStates struct {
+	// StateFiles maps data block addresses, e.g. data.terraform_remote_state.network, to state files.
+	StateFiles map[string]string
+	// Projects maps data block addresses to projects whose outputs are used as the state outputs.
+	Projects map[string]string
+	// ProjectOutputs returns the outputs of a project. It is required when Projects are mapped.
+	ProjectOutputs func(project string) (cty.Value, error)
+}
+
+// outputs returns the state outputs for the terraform_remote_state block. It returns false if the block
+// isn't mapped to a state and doesn't use the local backend, so the outputs can't be resolved.
+func (r *RemoteStates) outputs(b *Block, rootPath string) (cty.Value, bool, error) {
+	if r != nil {
+		for _, addr := range dataBlockAddresses(b) {
+			if path, ok := r.StateFiles[addr]; ok {
+				outputs, err := readStateOutputs(path)
+				return outputs, true, err
+			}
+
+			if project, ok := r.Projects[addr]; ok {
+				if r.ProjectOutputs == nil {
+					return cty.NilVal, true, fmt.Errorf("outputs of project %s can't be loaded", project)
+				}
+
+				outputs, err := r.ProjectOutputs(project)
+				return outputs, true, err
+			}
+		}
+	}
+
+	path, ok := localStatePath(b)
+	if !ok {
+		return cty.NilVal, false, nil
+	}
+
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(rootPath, path)
+	}
+
+	outputs, err := readStateOutputs(path)
+	return outputs, true, err
+}
+
+// localStatePath returns the path of the state file of a terraform_remote_state block that uses the
+// local backend, following the path and workspace_dir settings of the backend.
+func localStatePath(b *Block) (string, bool) {
+	backend := b.GetAttribute("backend")
+	if backend == nil {
+		return "", false
+	}
+
+	if v, ok := knownString(backend.Value()); !ok || v != "local" {
+		return "", false
+	}
+
+	var settings map[string]cty.Value
+	if attr := b.GetAttribute("config"); attr != nil {
+		val := attr.Value()
+		if !val.IsWhollyKnown() || val.IsNull() || !(val.Type().IsObjectType() || val.Type().IsMapType()) {
+			return "", false
+		}
+
+		settings = val.AsValueMap()
+	}
+
+	workspace := defaultTerraformWorkspaceName
+	if attr := b.GetAttribute("workspace"); attr != nil {
+		v, ok := knownString(attr.Value())
+		if !ok {
+			return "", false
+		}
+
+		workspace = v
+	}
+
+	if workspace != defaultTerraformWorkspaceName {
+		dir := defaultLocalWorkspaceDir
+		if val, ok := settings["workspace_dir"]; ok {
+			if dir, ok = knownString(val); !ok {
+				return "", false
+			}
+		}
+
+		return filepath.Join(dir, workspace, defaultLocalStatePath), true
+	}
+
+	if val, ok := settings["path"]; ok {
+		return knownString(val)
+	}
+
+	return defaultLocalStatePath, true
+}
+
+// knownString returns the string of the value, or false if the value isn't a known string.
+func knownString(val cty.Value) (string, bool) {
+	if !val.IsKnown() || val.IsNull() || val.Type() != cty.String {
+		return "", false
+	}
+
+	return val.AsString(), true
+}
+
+type stateFileOutputs struct {
+	Outputs map[string]struct {
+		Value json.RawMessage `json:"value"`
+		Type  json.RawMessage `json:"type"`
+	} `json:"outputs"`
+}
+
+// readStateOutputs reads the outputs of the Terraform state file at path as an object value.
+func readStateOutputs(path string) (cty.Value, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return cty.NilVal, fmt.Errorf("error reading state file %w", err)
+	}
+
+	var state stateFileOutputs
+	err = json.Unmarshal(b, &state)
+	if err != nil {
+		return cty.NilVal, fmt.Errorf("error parsing state file %s: %w", path, err)
+	}
+
+	outputs := make(map[string]cty.Value, len(state.Outputs))
+	for name, output := range state.Outputs {
+		var ty cty.Type
+		if len(output.Type) > 0 {
+			ty, err = ctyjson.UnmarshalType(output.Type)
+		} else {
+			ty, err = ctyjson.ImpliedType(output.Value)
+		}
+		if err != nil {
+			return cty.NilVal, fmt.Errorf("invalid type of output %s in state file %s: %w", name, path, err)
+		}
+
+		val, err := ctyjson.Unmarshal(output.Value, ty)
+		if err != nil {
+			return cty.NilVal, fmt.Errorf("invalid value of output %s in state file %s: %w", name, path, err)
+		}
+
+		outputs[name] = val
+	}
+
+	return cty.ObjectVal(outputs), nil
+}
+
+// remoteStateValues returns the values of the terraform_remote_state block with its outputs set to
+// the resolved state outputs, which take precedence over the defaults of the block.
+func remoteStateValues(b *Block, outputs cty.Value) cty.Value {
+	values := b.Values().AsValueMap()
+	if values == nil {
+		values = make(map[string]cty.Value)
+	}
+
+	merged := make(map[string]cty.Value)
+	if defaults, ok := values["defaults"]; ok && defaults.IsKnown() && !defaults.IsNull() &&
+		(defaults.Type().IsObjectType() || defaults.Type().IsMapType()) {
+		for k, v := range defaults.AsValueMap() {
+			merged[k] = v
+		}
+	}
+
+	if outputs.Type().IsObjectType() || outputs.Type().IsMapType() {
+		for k, v := range outputs.AsValueMap() {
+			merged[k] = v
+		}
+	}
+
+	values["outputs"] = cty.ObjectVal(merged)
+	return cty.ObjectVal(values)
+}
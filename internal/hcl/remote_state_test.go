@@ -0,0 +1,157 @@
+package hcl
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infracost/infracost/internal/hcl/modules"
+	"github.com/infracost/infracost/internal/sync"
+)
+
+const testStateFile = `{
+  "version": 4,
+  "outputs": {
+    "instance_type": {
+      "value": "m5.large",
+      "type": "string"
+    },
+    "subnet_ids": {
+      "value": ["subnet-a", "subnet-b"],
+      "type": ["list", "string"]
+    }
+  },
+  "resources": []
+}`
+
+func TestRemoteStates(t *testing.T) {
+	path := createTestFile("main.tf", `
+data "terraform_remote_state" "local" {
+  backend = "local"
+
+  config = {
+    path = "${path.module}/network/terraform.tfstate"
+  }
+}
+
+data "terraform_remote_state" "mapped" {
+  backend = "s3"
+
+  config = {
+    bucket = "terraform-state"
+    key    = "network/terraform.tfstate"
+  }
+
+  defaults = {
+    instance_type = "t3.micro"
+    disk_size     = 100
+  }
+}
+
+data "terraform_remote_state" "unmapped" {
+  backend = "s3"
+}
+
+output "local_subnets" {
+  value = length(data.terraform_remote_state.local.outputs.subnet_ids)
+}
+
+output "mapped_instance_type" {
+  value = data.terraform_remote_state.mapped.outputs.instance_type
+}
+
+output "mapped_disk_size" {
+  value = data.terraform_remote_state.mapped.outputs.disk_size
+}
+
+resource "aws_instance" "web" {
+  instance_type = data.terraform_remote_state.unmapped.outputs.instance_type
+}
+`)
+	dir := filepath.Dir(path)
+
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "network"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "network", "terraform.tfstate"), []byte(testStateFile), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "mapped.tfstate"), []byte(testStateFile), 0600))
+
+	logger := newDiscardLogger()
+	parser := newParser(RootPath{Path: dir}, modules.NewModuleLoader(dir, nil, logger, &sync.KeyMutex{}), logger, OptionWithRemoteStates(&RemoteStates{
+		StateFiles: map[string]string{
+			"data.terraform_remote_state.mapped": filepath.Join(dir, "mapped.tfstate"),
+		},
+	}))
+	module, err := parser.ParseDirectory()
+	require.NoError(t, err)
+
+	outputs := module.Blocks.Outputs(false).AsValueMap()
+
+	subnets, _ := outputs["local_subnets"].AsBigFloat().Int64()
+	assert.Equal(t, int64(2), subnets)
+	assert.Equal(t, "m5.large", outputs["mapped_instance_type"].AsString())
+	diskSize, _ := outputs["mapped_disk_size"].AsBigFloat().Int64()
+	assert.Equal(t, int64(100), diskSize)
+
+	require.Len(t, module.Warnings, 2)
+	assert.Equal(t, WarningUnresolvedDataSources, module.Warnings[0].Code)
+	assert.Equal(t, []string{"data.terraform_remote_state.unmapped"}, module.Warnings[0].Data)
+}
+
+func TestLocalStatePath(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   string
+		expected string
+		ok       bool
+	}{
+		{
+			name:     "default path",
+			config:   `backend = "local"`,
+			expected: "terraform.tfstate",
+			ok:       true,
+		},
+		{
+			name: "workspace",
+			config: `backend = "local"
+  workspace = "staging"`,
+			expected: filepath.Join("terraform.tfstate.d", "staging", "terraform.tfstate"),
+			ok:       true,
+		},
+		{
+			name: "workspace dir",
+			config: `backend = "local"
+  workspace = "staging"
+  config = {
+    workspace_dir = "states"
+  }`,
+			expected: filepath.Join("states", "staging", "terraform.tfstate"),
+			ok:       true,
+		},
+		{
+			name:   "other backend",
+			config: `backend = "s3"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := createTestFile("main.tf", `
+data "terraform_remote_state" "network" {
+  `+tt.config+`
+}
+`)
+
+			logger := newDiscardLogger()
+			parser := newParser(RootPath{Path: filepath.Dir(path)}, modules.NewModuleLoader(filepath.Dir(path), nil, logger, &sync.KeyMutex{}), logger)
+			module, err := parser.ParseDirectory()
+			require.NoError(t, err)
+
+			block := module.Blocks.OfType("data")[0]
+			actual, ok := localStatePath(block)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.expected, actual)
+		})
+	}
+}
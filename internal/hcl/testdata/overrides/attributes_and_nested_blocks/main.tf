@@ -0,0 +1,36 @@
+resource "aws_instance" "web" {
+  ami           = "ami-123"
+  instance_type = "t3.micro"
+
+  root_block_device {
+    volume_size = 20
+  }
+
+  ebs_block_device {
+    device_name = "/dev/sdb"
+    volume_size = 50
+  }
+
+  ebs_block_device {
+    device_name = "/dev/sdc"
+    volume_size = 50
+  }
+
+  lifecycle {
+    create_before_destroy = true
+    prevent_destroy       = false
+  }
+}
+
+resource "aws_instance" "worker" {
+  ami           = "ami-123"
+  instance_type = "t3.micro"
+
+  dynamic "ebs_block_device" {
+    for_each = ["/dev/sdb"]
+    content {
+      device_name = ebs_block_device.value
+      volume_size = 10
+    }
+  }
+}
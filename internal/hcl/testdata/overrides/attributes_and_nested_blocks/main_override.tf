@@ -0,0 +1,24 @@
+resource "aws_instance" "web" {
+  instance_type = "m5.large"
+
+  ebs_block_device {
+    device_name = "/dev/sdd"
+    volume_size = 500
+  }
+
+  lifecycle {
+    prevent_destroy = true
+  }
+}
+
+resource "aws_instance" "worker" {
+  ebs_block_device {
+    device_name = "/dev/sde"
+    volume_size = 100
+  }
+}
+
+# There is no primary block for this so it is skipped
+resource "aws_instance" "missing" {
+  instance_type = "m5.large"
+}
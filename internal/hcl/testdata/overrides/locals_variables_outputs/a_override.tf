@@ -0,0 +1,7 @@
+variable "instance_type" {
+  default = "m5.large"
+}
+
+locals {
+  env = "staging"
+}
@@ -0,0 +1,23 @@
+variable "instance_type" {
+  type    = string
+  default = "t3.micro"
+}
+
+locals {
+  name = "app"
+  env  = "dev"
+}
+
+resource "aws_instance" "web" {
+  ami           = "ami-123"
+  instance_type = var.instance_type
+
+  tags = {
+    Name = "${local.name}-${local.env}"
+  }
+}
+
+output "instance_type" {
+  value       = aws_instance.web.instance_type
+  description = "The instance type"
+}
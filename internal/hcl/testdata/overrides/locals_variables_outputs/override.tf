@@ -0,0 +1,11 @@
+variable "instance_type" {
+  default = "m5.xlarge"
+}
+
+locals {
+  env = "prod"
+}
+
+output "instance_type" {
+  value = "${aws_instance.web.instance_type} (overridden)"
+}
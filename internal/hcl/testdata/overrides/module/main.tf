@@ -0,0 +1,4 @@
+module "app" {
+  source        = "./modules/app"
+  instance_type = "t3.micro"
+}
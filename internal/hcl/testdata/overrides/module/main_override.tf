@@ -0,0 +1,3 @@
+module "app" {
+  instance_type = "m5.large"
+}
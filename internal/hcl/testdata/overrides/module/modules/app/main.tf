@@ -0,0 +1,8 @@
+variable "instance_type" {
+  type = string
+}
+
+resource "aws_instance" "app" {
+  ami           = "ami-123"
+  instance_type = var.instance_type
+}
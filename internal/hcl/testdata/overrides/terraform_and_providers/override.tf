@@ -0,0 +1,17 @@
+terraform {
+  backend "local" {
+    path = "local.tfstate"
+  }
+
+  required_providers {
+    aws = {
+      source  = "hashicorp/aws"
+      version = "~> 5.0"
+    }
+  }
+}
+
+provider "aws" {
+  alias  = "west"
+  region = "eu-west-1"
+}
@@ -0,0 +1,86 @@
+package output
+
+import (
+	"fmt"
+
+	"github.com/shopspring/decimal"
+)
+
+// CheckBudgets checks the total monthly costs of the projects against the
+// budgets set in their metadata. Breaches are added to the commentable
+// failures, and also to the blocking failures if the budget level is block.
+// The increase thresholds are only checked for projects with a past
+// breakdown, and the percent threshold is skipped if the past cost is zero.
+func CheckBudgets(out Root) GuardrailCheck {
+	var check GuardrailCheck
+
+	for _, p := range out.Projects {
+		if p.Metadata == nil || p.Metadata.Budget == nil || p.Breakdown == nil {
+			continue
+		}
+
+		b := p.Metadata.Budget
+		failures := make([]string, 0)
+
+		cost := decimal.Zero
+		if p.Breakdown.TotalMonthlyCost != nil {
+			cost = *p.Breakdown.TotalMonthlyCost
+		}
+
+		if b.MonthlyBudget != nil {
+			check.TotalChecked++
+
+			budget := decimal.NewFromFloat(*b.MonthlyBudget)
+			if cost.GreaterThan(budget) {
+				failures = append(failures, fmt.Sprintf("Project %s monthly cost of %s is over its budget of %s", p.Label(), formatCost(out.Currency, &cost), formatCost(out.Currency, &budget)))
+			}
+		}
+
+		if p.PastBreakdown != nil {
+			pastCost := decimal.Zero
+			if p.PastBreakdown.TotalMonthlyCost != nil {
+				pastCost = *p.PastBreakdown.TotalMonthlyCost
+			}
+			increase := cost.Sub(pastCost)
+
+			if b.MaxIncrease != nil {
+				check.TotalChecked++
+
+				maxIncrease := decimal.NewFromFloat(*b.MaxIncrease)
+				if increase.GreaterThan(maxIncrease) {
+					failures = append(failures, fmt.Sprintf("Project %s monthly cost increase of %s is over the maximum of %s", p.Label(), formatCost(out.Currency, &increase), formatCost(out.Currency, &maxIncrease)))
+				}
+			}
+
+			if b.MaxIncreasePercent != nil && pastCost.IsPositive() {
+				check.TotalChecked++
+
+				percent := increase.Div(pastCost).Mul(decimal.NewFromInt(100))
+				maxPercent := decimal.NewFromFloat(*b.MaxIncreasePercent)
+				if percent.GreaterThan(maxPercent) {
+					failures = append(failures, fmt.Sprintf("Project %s monthly cost increase of %s%% is over the maximum of %s%%", p.Label(), percent.Round(1).String(), maxPercent.String()))
+				}
+			}
+		}
+
+		check.CommentableFailures = append(check.CommentableFailures, failures...)
+		if b.IsBlocking() {
+			check.BlockingFailures = append(check.BlockingFailures, failures...)
+		}
+	}
+
+	check.Comment = check.TotalChecked > 0
+
+	return check
+}
+
+// Merge returns a check with the guardrails and failures of both checks, e.g.
+// to combine the guardrails from Infracost Cloud with the local budgets.
+func (c GuardrailCheck) Merge(other GuardrailCheck) GuardrailCheck {
+	return GuardrailCheck{
+		TotalChecked:        c.TotalChecked + other.TotalChecked,
+		Comment:             c.Comment || other.Comment,
+		CommentableFailures: append(append(GuardrailFailures{}, c.CommentableFailures...), other.CommentableFailures...),
+		BlockingFailures:    append(append(GuardrailFailures{}, c.BlockingFailures...), other.BlockingFailures...),
+	}
+}
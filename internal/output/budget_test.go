@@ -0,0 +1,71 @@
+package output
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func TestCheckBudgets(t *testing.T) {
+	float := func(f float64) *float64 {
+		return &f
+	}
+
+	newProject := func(name string, budget *schema.Budget, pastCost, cost float64) Project {
+		p := Project{
+			Name:      name,
+			Metadata:  &schema.ProjectMetadata{Budget: budget},
+			Breakdown: &Breakdown{TotalMonthlyCost: decimalPtr(decimal.NewFromFloat(cost))},
+		}
+		if pastCost >= 0 {
+			p.PastBreakdown = &Breakdown{TotalMonthlyCost: decimalPtr(decimal.NewFromFloat(pastCost))}
+		}
+
+		return p
+	}
+
+	out := Root{
+		Currency: "USD",
+		Projects: Projects{
+			// Over budget but within the increase thresholds, only a warning
+			newProject("web", &schema.Budget{MonthlyBudget: float(1000), MaxIncrease: float(500)}, 1000, 1200),
+			// Over the increase thresholds, blocking
+			newProject("data", &schema.Budget{MaxIncrease: float(100), MaxIncreasePercent: float(10), Level: schema.BudgetLevelBlock}, 500, 800),
+			// No past breakdown so only the budget is checked
+			newProject("new", &schema.Budget{MonthlyBudget: float(100), MaxIncrease: float(10)}, -1, 50),
+			// The percent threshold is skipped if the past cost is zero
+			newProject("empty", &schema.Budget{MaxIncreasePercent: float(10)}, 0, 50),
+			newProject("no-budget", nil, 0, 5000),
+		},
+	}
+
+	check := CheckBudgets(out)
+	assert.Equal(t, int64(5), check.TotalChecked)
+	assert.True(t, check.Comment)
+	assert.Equal(t, GuardrailFailures{
+		"Project web monthly cost of $1,200 is over its budget of $1,000",
+		"Project data monthly cost increase of $300 is over the maximum of $100",
+		"Project data monthly cost increase of 60% is over the maximum of 10%",
+	}, check.CommentableFailures)
+	assert.Equal(t, GuardrailFailures{
+		"Project data monthly cost increase of $300 is over the maximum of $100",
+		"Project data monthly cost increase of 60% is over the maximum of 10%",
+	}, check.BlockingFailures)
+
+	b, err := ToMarkdown(out, Options{GuardrailCheck: check}, MarkdownOptions{})
+	require.NoError(t, err)
+	assert.Contains(t, string(b), "Guardrail checks failed")
+	assert.Contains(t, string(b), "> - Project web monthly cost of $1,200 is over its budget of $1,000")
+
+	check = CheckBudgets(Root{Projects: Projects{newProject("no-budget", nil, 0, 5000)}})
+	assert.Equal(t, GuardrailCheck{}, check)
+
+	merged := GuardrailCheck{TotalChecked: 2, Comment: true, BlockingFailures: GuardrailFailures{"cloud"}}.Merge(CheckBudgets(out))
+	assert.Equal(t, int64(7), merged.TotalChecked)
+	assert.Len(t, merged.CommentableFailures, 3)
+	assert.Equal(t, GuardrailFailures{"cloud", "Project data monthly cost increase of $300 is over the maximum of $100", "Project data monthly cost increase of 60% is over the maximum of 10%"}, merged.BlockingFailures)
+}
@@ -0,0 +1,45 @@
+package output
+
+import (
+	"github.com/shopspring/decimal"
+)
+
+// calculateTotalCarbon returns the total monthly carbon of the resources, or
+// nil if none of the resources have a carbon estimate.
+func calculateTotalCarbon(resources []Resource) *decimal.Decimal {
+	var total *decimal.Decimal
+
+	for _, r := range resources {
+		total = addOptionalDecimals(total, r.MonthlyCarbon)
+	}
+
+	return total
+}
+
+// formatCarbon formats the carbon emissions in kgCO2e.
+func formatCarbon(d *decimal.Decimal) string {
+	if d == nil {
+		return "-"
+	}
+
+	return formatQuantity(decimalPtr(d.Round(2))) + " kgCO2e"
+}
+
+// formatCarbonChange formats the change in carbon emissions from pastCarbon
+// to carbon with a sign, e.g. +12.5 kgCO2e.
+func formatCarbonChange(pastCarbon, carbon *decimal.Decimal) string {
+	d := decimal.Zero
+	if carbon != nil {
+		d = *carbon
+	}
+	if pastCarbon != nil {
+		d = d.Sub(*pastCarbon)
+	}
+
+	plusMinus := "+"
+	if d.IsNegative() {
+		plusMinus = ""
+	}
+
+	return plusMinus + formatCarbon(&d)
+}
@@ -0,0 +1,83 @@
+package output
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/infracost/infracost/internal/ui"
+)
+
+func TestCarbon(t *testing.T) {
+	newResource := func(name string, hours int64) *schema.Resource {
+		qty := decimal.NewFromInt(hours)
+		c := &schema.CostComponent{
+			Name:            "Instance usage",
+			Unit:            "hours",
+			UnitMultiplier:  decimal.NewFromInt(1),
+			MonthlyQuantity: &qty,
+		}
+		c.SetPrice(decimal.NewFromInt(1))
+		c.SetCarbonIntensity(decimal.NewFromFloat(0.01))
+
+		return &schema.Resource{Name: name, CostComponents: []*schema.CostComponent{c}}
+	}
+
+	past := &schema.Project{
+		Name:      "app",
+		Metadata:  &schema.ProjectMetadata{},
+		Resources: []*schema.Resource{newResource("aws_instance.web", 730)},
+	}
+	schema.CalculateCosts(past)
+
+	project := &schema.Project{
+		Name:      "app",
+		Metadata:  &schema.ProjectMetadata{},
+		Resources: []*schema.Resource{newResource("aws_instance.web", 730), newResource("aws_instance.worker", 365)},
+	}
+	schema.CalculateCosts(project)
+
+	out, err := ToOutputFormat([]*schema.Project{project})
+	require.NoError(t, err)
+	out.Currency = "USD"
+
+	assert.Equal(t, "7.3", out.Projects[0].Breakdown.Resources[0].MonthlyCarbon.String())
+	assert.Equal(t, "7.3", out.Projects[0].Breakdown.Resources[0].CostComponents[0].MonthlyCarbon.String())
+	assert.Equal(t, "10.95", out.Projects[0].Breakdown.TotalMonthlyCarbon.String())
+	assert.Equal(t, "10.95", out.TotalMonthlyCarbon.String())
+
+	b, err := ToTable(out, Options{Fields: []string{"monthlyQuantity", "unit", "monthlyCost", "monthlyCarbon"}})
+	require.NoError(t, err)
+	table := ui.StripColor(string(b))
+	assert.Contains(t, table, "Monthly kgCO2e")
+	assert.Regexp(t, `Instance usage\s+730\s+hours\s+\$730\.00\s+7\.3`, table)
+	assert.Regexp(t, `OVERALL MONTHLY CARBON\s+10\.95 kgCO2e`, table)
+
+	b, err = ToTable(out, Options{Fields: []string{"monthlyQuantity", "unit", "monthlyCost"}})
+	require.NoError(t, err)
+	assert.NotContains(t, ui.StripColor(string(b)), "Monthly kgCO2e")
+
+	b, err = ToHTML(out, Options{Fields: []string{"monthlyQuantity", "unit", "monthlyCost", "monthlyCarbon"}})
+	require.NoError(t, err)
+	assert.Contains(t, string(b), `<td class="monthly-carbon">10.95</td>`)
+
+	prior, err := ToOutputFormat([]*schema.Project{past})
+	require.NoError(t, err)
+	prior.Currency = "USD"
+
+	diff, err := CompareTo(out, prior, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "7.3", diff.PastTotalMonthlyCarbon.String())
+	assert.Equal(t, "3.65", diff.DiffTotalMonthlyCarbon.String())
+
+	b, err = ToMarkdown(diff, Options{}, MarkdownOptions{})
+	require.NoError(t, err)
+	assert.Contains(t, string(b), "Monthly carbon emissions: **10.95 kgCO2e** (+3.65 kgCO2e)")
+
+	b, err = ToDiff(diff, Options{})
+	require.NoError(t, err)
+	assert.Contains(t, ui.StripColor(string(b)), "Carbon:  +3.65 kgCO2e (7.3 kgCO2e → 10.95 kgCO2e)")
+}
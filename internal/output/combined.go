@@ -17,6 +17,7 @@ import (
 	"golang.org/x/mod/semver"
 
 	"github.com/infracost/infracost/internal/clierror"
+	"github.com/infracost/infracost/internal/fxrates"
 	"github.com/infracost/infracost/internal/schema"
 	log "github.com/sirupsen/logrus"
 )
@@ -109,7 +110,21 @@ func LoadPaths(paths []string) ([]ReportInput, error) {
 // Each project in current Root will have all past resources overwritten with the matching projects
 // in the prior Root. If we can't find a matching project then we assume that the project
 // has been newly created and will show a 100% increase in the output Root.
-func CompareTo(current, prior Root) (Root, error) {
+// If rates is set and the prior Root is in a different currency, it is
+// converted to the currency of the current Root.
+func CompareTo(current, prior Root, rates *fxrates.Rates) (Root, error) {
+	if rates != nil && !sameCurrency(current.Currency, prior.Currency) {
+		var err error
+		prior, err = ConvertCurrency(prior, rates, currencyOrDefault(current.Currency))
+		if err != nil {
+			return Root{}, err
+		}
+	}
+
+	if _, err := checkCurrency(currencyOrDefault(current.Currency), prior.Currency); err != nil {
+		return Root{}, err
+	}
+
 	priorProjects := make(map[string]*schema.Project)
 	for _, p := range prior.Projects {
 		if _, ok := priorProjects[p.LabelWithMetadata()]; ok {
@@ -151,11 +166,70 @@ func CompareTo(current, prior Root) (Root, error) {
 		return out, err
 	}
 
+	out = withUsageCosts(out, current)
 	out.Currency = current.Currency
 	return out, nil
 }
 
-func Combine(inputs []ReportInput) (Root, error) {
+// withUsageCosts copies the cost ranges of the usage scenarios and the forecast
+// costs of the resources of current to the matching projects of out, and sums
+// them up. This is needed since they are lost when the projects are converted
+// to schema projects to compare them.
+func withUsageCosts(out Root, current Root) Root {
+	resources := make(map[string]map[string]Resource)
+	for _, p := range current.Projects {
+		if p.Breakdown == nil || (p.Breakdown.TotalMonthlyCostRange == nil && p.Breakdown.ForecastMonthlyCosts == nil) {
+			continue
+		}
+
+		m := make(map[string]Resource)
+		for _, r := range p.Breakdown.Resources {
+			m[r.Name] = r
+		}
+		resources[p.LabelWithMetadata()] = m
+	}
+
+	if len(resources) == 0 {
+		return out
+	}
+
+	costRanges := make([]*CostRange, len(out.Projects))
+	costs := make([]*decimal.Decimal, len(out.Projects))
+	forecasts := make([][]*decimal.Decimal, len(out.Projects))
+
+	for i, p := range out.Projects {
+		if p.Breakdown == nil {
+			continue
+		}
+
+		costs[i] = p.Breakdown.TotalMonthlyCost
+
+		m, ok := resources[p.LabelWithMetadata()]
+		if !ok {
+			continue
+		}
+
+		for j, r := range p.Breakdown.Resources {
+			p.Breakdown.Resources[j].MonthlyCostRange = m[r.Name].MonthlyCostRange
+			p.Breakdown.Resources[j].ForecastMonthlyCosts = m[r.Name].ForecastMonthlyCosts
+		}
+
+		p.Breakdown.TotalMonthlyCostRange = calculateTotalCostRange(p.Breakdown.Resources)
+		p.Breakdown.ForecastMonthlyCosts = calculateTotalForecast(p.Breakdown.Resources)
+		costRanges[i] = p.Breakdown.TotalMonthlyCostRange
+		forecasts[i] = p.Breakdown.ForecastMonthlyCosts
+	}
+
+	out.TotalMonthlyCostRange = sumCostRanges(costRanges, costs)
+	out.Forecast = forecastMonths(sumForecasts(forecasts, costs))
+
+	return out
+}
+
+// Combine merges the reports into a single Root. If rates is set, reports in
+// a different currency than the first report are converted to its currency,
+// otherwise an error is returned for them.
+func Combine(inputs []ReportInput, rates *fxrates.Rates) (Root, error) {
 	var combined Root
 
 	var totalHourlyCost *decimal.Decimal
@@ -164,16 +238,33 @@ func Combine(inputs []ReportInput) (Root, error) {
 	var pastTotalMonthlyCost *decimal.Decimal
 	var diffTotalHourlyCost *decimal.Decimal
 	var diffTotalMonthlyCost *decimal.Decimal
+	var totalCommitmentSavings *decimal.Decimal
+	var totalDiscount *decimal.Decimal
+	var totalMonthlyCarbon, pastTotalMonthlyCarbon, diffTotalMonthlyCarbon *decimal.Decimal
+	var totalOverlapCost *decimal.Decimal
 
 	projects := make([]Project, 0)
+	costRanges := make([]*CostRange, 0, len(inputs))
+	costs := make([]*decimal.Decimal, 0, len(inputs))
+	forecasts := make([][]*decimal.Decimal, 0, len(inputs))
 	summaries := make([]*Summary, 0, len(inputs))
+	tagPolicyChecks := make([]*TagPolicyCheck, 0, len(inputs))
 	currency := ""
 
 	var metadata Metadata
-	var invalidMetadata bool
+	var invalidMetadata, converted bool
 	builder := strings.Builder{}
 	for i, input := range inputs {
 		var err error
+		if rates != nil && currency != "" && !sameCurrency(currency, input.Root.Currency) {
+			input.Root, err = ConvertCurrency(input.Root, rates, currency)
+			if err != nil {
+				return combined, err
+			}
+
+			converted = true
+		}
+
 		currency, err = checkCurrency(currency, input.Root.Currency)
 		if err != nil {
 			return combined, err
@@ -182,6 +273,10 @@ func Combine(inputs []ReportInput) (Root, error) {
 		projects = append(projects, input.Root.Projects...)
 
 		summaries = append(summaries, input.Root.Summary)
+		tagPolicyChecks = append(tagPolicyChecks, input.Root.TagPolicy)
+		costRanges = append(costRanges, input.Root.TotalMonthlyCostRange)
+		costs = append(costs, input.Root.TotalMonthlyCost)
+		forecasts = append(forecasts, forecastCosts(input.Root.Forecast))
 
 		if input.Root.TotalHourlyCost != nil {
 			if totalHourlyCost == nil {
@@ -227,6 +322,13 @@ func Combine(inputs []ReportInput) (Root, error) {
 			diffTotalHourlyCost = decimalPtr(diffTotalHourlyCost.Add(*input.Root.DiffTotalHourlyCost))
 		}
 
+		totalCommitmentSavings = addCostDifference(totalCommitmentSavings, input.Root.TotalOnDemandMonthlyCost, input.Root.TotalMonthlyCost)
+		totalDiscount = addCostDifference(totalDiscount, input.Root.TotalListMonthlyCost, input.Root.TotalMonthlyCost)
+		totalMonthlyCarbon = addOptionalDecimals(totalMonthlyCarbon, input.Root.TotalMonthlyCarbon)
+		pastTotalMonthlyCarbon = addOptionalDecimals(pastTotalMonthlyCarbon, input.Root.PastTotalMonthlyCarbon)
+		diffTotalMonthlyCarbon = addOptionalDecimals(diffTotalMonthlyCarbon, input.Root.DiffTotalMonthlyCarbon)
+		totalOverlapCost = addOptionalDecimals(totalOverlapCost, input.Root.TotalOverlapCost)
+
 		if i != 0 && metadata.VCSRepositoryURL != input.Root.Metadata.VCSRepositoryURL {
 			invalidMetadata = true
 		}
@@ -244,9 +346,26 @@ func Combine(inputs []ReportInput) (Root, error) {
 	combined.PastTotalMonthlyCost = pastTotalMonthlyCost
 	combined.DiffTotalHourlyCost = diffTotalHourlyCost
 	combined.DiffTotalMonthlyCost = diffTotalMonthlyCost
+	if totalCommitmentSavings != nil && totalMonthlyCost != nil {
+		combined.TotalOnDemandMonthlyCost = decimalPtr(totalMonthlyCost.Add(*totalCommitmentSavings))
+	}
+	if totalDiscount != nil && totalMonthlyCost != nil {
+		combined.TotalListMonthlyCost = decimalPtr(totalMonthlyCost.Add(*totalDiscount))
+	}
+	combined.TotalMonthlyCostRange = sumCostRanges(costRanges, costs)
+	combined.Forecast = forecastMonths(sumForecasts(forecasts, costs))
+	combined.TotalMonthlyCarbon = totalMonthlyCarbon
+	combined.PastTotalMonthlyCarbon = pastTotalMonthlyCarbon
+	combined.DiffTotalMonthlyCarbon = diffTotalMonthlyCarbon
+	combined.TotalOverlapCost = totalOverlapCost
 	combined.TimeGenerated = time.Now().UTC()
 	combined.Summary = MergeSummaries(summaries)
+	combined.TagPolicy = mergeTagPolicyChecks(tagPolicyChecks)
 	combined.Metadata = metadata
+	if converted {
+		combined.Metadata.FXRatesSource = rates.Source
+		combined.Metadata.FXRatesEffectiveDate = rates.FormattedEffectiveDate()
+	}
 
 	if invalidMetadata {
 		return combined, clierror.NewWarningF(
@@ -270,12 +389,24 @@ func checkCurrency(inputCurrency, fileCurrency string) (string, error) {
 	}
 
 	if inputCurrency != fileCurrency {
-		return "", fmt.Errorf("Invalid Infracost JSON file currency mismatch.  Can't combine %s and %s", inputCurrency, fileCurrency)
+		return "", fmt.Errorf("Invalid Infracost JSON file currency mismatch.  Can't combine %s and %s, use --fx-rates-file to convert between them", inputCurrency, fileCurrency)
 	}
 
 	return inputCurrency, nil
 }
 
+func currencyOrDefault(currency string) string {
+	if currency == "" {
+		return "USD"
+	}
+
+	return currency
+}
+
+func sameCurrency(a, b string) bool {
+	return strings.EqualFold(currencyOrDefault(a), currencyOrDefault(b))
+}
+
 func checkOutputVersion(v string) bool {
 	if !strings.HasPrefix(v, "v") {
 		v = "v" + v
@@ -297,6 +428,8 @@ func FormatOutput(format string, r Root, opts Options) ([]byte, error) {
 		b, err = ToJSON(r, opts)
 	case "html":
 		b, err = ToHTML(r, opts)
+	case "csv":
+		b, err = ToCSV(r, opts)
 	case "diff":
 		b, err = ToDiff(r, opts)
 	case "github-comment":
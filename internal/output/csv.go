@@ -0,0 +1,72 @@
+package output
+
+import (
+	"bytes"
+	"encoding/csv"
+
+	"github.com/shopspring/decimal"
+)
+
+// ToCSV returns the monthly cost of each resource as CSV. If the costs are
+// forecast there is a column with the monthly cost of each month of the
+// forecast. The last row is the total of all the projects.
+func ToCSV(out Root, opts Options) ([]byte, error) {
+	months := len(out.Forecast)
+
+	header := []string{"Project", "Resource", "Monthly Cost"}
+	for m := 1; m <= months; m++ {
+		header = append(header, forecastMonthLabel(m))
+	}
+
+	rows := [][]string{header}
+
+	for _, p := range out.Projects {
+		if p.Breakdown == nil {
+			continue
+		}
+
+		for _, r := range p.Breakdown.Resources {
+			rows = append(rows, csvRow(p.Label(), r.Name, r.MonthlyCost, r.ForecastMonthlyCosts, months))
+		}
+	}
+
+	rows = append(rows, csvRow("", "Total", out.TotalMonthlyCost, forecastCosts(out.Forecast), months))
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+
+	err := w.WriteAll(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// csvRow returns a row with the monthly cost and the monthly cost of each
+// month of the forecast. If the forecast isn't set the monthly cost is used
+// for each month.
+func csvRow(project, name string, cost *decimal.Decimal, forecast []*decimal.Decimal, months int) []string {
+	row := []string{project, name, csvCost(cost)}
+
+	for m := 0; m < months; m++ {
+		switch {
+		case len(forecast) == 0:
+			row = append(row, csvCost(cost))
+		case m < len(forecast):
+			row = append(row, csvCost(forecast[m]))
+		default:
+			row = append(row, csvCost(forecast[len(forecast)-1]))
+		}
+	}
+
+	return row
+}
+
+func csvCost(d *decimal.Decimal) string {
+	if d == nil {
+		return ""
+	}
+
+	return d.StringFixed(2)
+}
@@ -0,0 +1,146 @@
+package output
+
+import (
+	"github.com/shopspring/decimal"
+
+	"github.com/infracost/infracost/internal/fxrates"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+// ConvertCurrency returns a copy of r with all of its prices and costs
+// converted to currency using the exchange rates. The rates are recorded in
+// the metadata of the returned Root.
+func ConvertCurrency(r Root, rates *fxrates.Rates, currency string) (Root, error) {
+	from := r.Currency
+	if from == "" {
+		from = fxrates.BaseCurrency
+	}
+
+	factor, err := rates.Factor(from, currency)
+	if err != nil {
+		return r, err
+	}
+
+	r.Currency = currency
+	r.Metadata.FXRatesSource = rates.Source
+	r.Metadata.FXRatesEffectiveDate = rates.FormattedEffectiveDate()
+
+	if factor.Equal(decimal.NewFromInt(1)) {
+		return r, nil
+	}
+
+	convert := func(d *decimal.Decimal) *decimal.Decimal {
+		if d == nil {
+			return nil
+		}
+
+		return decimalPtr(d.Mul(factor))
+	}
+
+	r.TotalHourlyCost = convert(r.TotalHourlyCost)
+	r.TotalMonthlyCost = convert(r.TotalMonthlyCost)
+	r.PastTotalHourlyCost = convert(r.PastTotalHourlyCost)
+	r.PastTotalMonthlyCost = convert(r.PastTotalMonthlyCost)
+	r.DiffTotalHourlyCost = convert(r.DiffTotalHourlyCost)
+	r.DiffTotalMonthlyCost = convert(r.DiffTotalMonthlyCost)
+	r.TotalOnDemandMonthlyCost = convert(r.TotalOnDemandMonthlyCost)
+	r.TotalListMonthlyCost = convert(r.TotalListMonthlyCost)
+	r.TotalMonthlyCostRange = convertCostRange(r.TotalMonthlyCostRange, convert)
+	r.Forecast = forecastMonths(convertForecast(forecastCosts(r.Forecast), convert))
+	r.TotalOverlapCost = convert(r.TotalOverlapCost)
+
+	projects := make(Projects, len(r.Projects))
+	for i, p := range r.Projects {
+		p.PastBreakdown = convertBreakdown(p.PastBreakdown, convert)
+		p.Breakdown = convertBreakdown(p.Breakdown, convert)
+		p.Diff = convertBreakdown(p.Diff, convert)
+		projects[i] = p
+	}
+	r.Projects = projects
+
+	return r, nil
+}
+
+func convertBreakdown(b *Breakdown, convert func(d *decimal.Decimal) *decimal.Decimal) *Breakdown {
+	if b == nil {
+		return nil
+	}
+
+	return &Breakdown{
+		Resources:                convertResources(b.Resources, convert),
+		TotalHourlyCost:          convert(b.TotalHourlyCost),
+		TotalMonthlyCost:         convert(b.TotalMonthlyCost),
+		TotalOnDemandMonthlyCost: convert(b.TotalOnDemandMonthlyCost),
+		TotalListMonthlyCost:     convert(b.TotalListMonthlyCost),
+
+		TotalPriceChangeMonthlyCost:    convert(b.TotalPriceChangeMonthlyCost),
+		TotalQuantityChangeMonthlyCost: convert(b.TotalQuantityChangeMonthlyCost),
+		TotalMonthlyCostRange:          convertCostRange(b.TotalMonthlyCostRange, convert),
+		ForecastMonthlyCosts:           convertForecast(b.ForecastMonthlyCosts, convert),
+		TotalMonthlyCarbon:             b.TotalMonthlyCarbon,
+		TotalOverlapCost:               convert(b.TotalOverlapCost),
+	}
+}
+
+func convertResources(resources []Resource, convert func(d *decimal.Decimal) *decimal.Decimal) []Resource {
+	if resources == nil {
+		return nil
+	}
+
+	converted := make([]Resource, len(resources))
+	for i, r := range resources {
+		r.HourlyCost = convert(r.HourlyCost)
+		r.MonthlyCost = convert(r.MonthlyCost)
+		r.CostComponents = convertCostComponentCosts(r.CostComponents, convert)
+		r.SubResources = convertResources(r.SubResources, convert)
+		r.MonthlyCostRange = convertCostRange(r.MonthlyCostRange, convert)
+		r.ForecastMonthlyCosts = convertForecast(r.ForecastMonthlyCosts, convert)
+		r.OverlapCost = convert(r.OverlapCost)
+
+		if r.ActualCosts != nil {
+			actualCosts := make([]ActualCosts, len(r.ActualCosts))
+			for j, ac := range r.ActualCosts {
+				ac.CostComponents = convertCostComponentCosts(ac.CostComponents, convert)
+				actualCosts[j] = ac
+			}
+			r.ActualCosts = actualCosts
+		}
+
+		converted[i] = r
+	}
+
+	return converted
+}
+
+func convertCostComponentCosts(components []CostComponent, convert func(d *decimal.Decimal) *decimal.Decimal) []CostComponent {
+	if components == nil {
+		return nil
+	}
+
+	converted := make([]CostComponent, len(components))
+	for i, c := range components {
+		c.Price = *convert(&c.Price)
+		c.HourlyCost = convert(c.HourlyCost)
+		c.MonthlyCost = convert(c.MonthlyCost)
+		c.OnDemandHourlyCost = convert(c.OnDemandHourlyCost)
+		c.OnDemandMonthlyCost = convert(c.OnDemandMonthlyCost)
+		c.ListMonthlyCost = convert(c.ListMonthlyCost)
+		c.PriceChangeMonthlyCost = convert(c.PriceChangeMonthlyCost)
+		c.QuantityChangeMonthlyCost = convert(c.QuantityChangeMonthlyCost)
+
+		if c.TierData != nil {
+			tiers := make([]schema.PriceTier, len(c.TierData))
+			for j, t := range c.TierData {
+				t.Price = *convert(&t.Price)
+				t.HourlyCost = convert(t.HourlyCost)
+				t.MonthlyCost = convert(t.MonthlyCost)
+				tiers[j] = t
+			}
+			c.TierData = tiers
+		}
+
+		converted[i] = c
+	}
+
+	return converted
+}
@@ -59,7 +59,12 @@ func ToDiff(out Root, opts Options) ([]byte, error) {
 		s += "\n"
 
 		for _, diffResource := range project.Diff.Resources {
-			oldResource := findResourceByName(project.PastBreakdown.Resources, diffResource.Name)
+			pastName := diffResource.Name
+			if diffResource.MovedFrom != "" {
+				pastName = diffResource.MovedFrom
+			}
+
+			oldResource := findResourceByName(project.PastBreakdown.Resources, pastName)
 			newResource := findResourceByName(project.Breakdown.Resources, diffResource.Name)
 
 			s += resourceToDiff(out.Currency, diffResource, oldResource, newResource, true)
@@ -90,6 +95,34 @@ func ToDiff(out Root, opts Options) ([]byte, error) {
 			)
 		}
 
+		if project.Diff.TotalPriceChangeMonthlyCost != nil {
+			s += fmt.Sprintf("\nFrom price changes:    %s\nFrom quantity changes: %s",
+				formatCostChange(out.Currency, project.Diff.TotalPriceChangeMonthlyCost),
+				formatCostChange(out.Currency, project.Diff.TotalQuantityChangeMonthlyCost),
+			)
+		}
+
+		if project.Diff.TotalMonthlyCarbon != nil {
+			var oldCarbon, newCarbon *decimal.Decimal
+			if project.PastBreakdown != nil {
+				oldCarbon = project.PastBreakdown.TotalMonthlyCarbon
+			}
+			if project.Breakdown != nil {
+				newCarbon = project.Breakdown.TotalMonthlyCarbon
+			}
+
+			s += fmt.Sprintf("\nCarbon:  %s %s",
+				formatCarbonChange(oldCarbon, newCarbon),
+				ui.FaintStringf("(%s → %s)", formatCarbon(oldCarbon), formatCarbon(newCarbon)),
+			)
+		}
+
+		if project.Diff.TotalOverlapCost != nil {
+			s += fmt.Sprintf("\nOne-off overlap cost of replacements: %s",
+				formatCost(out.Currency, project.Diff.TotalOverlapCost),
+			)
+		}
+
 		s += "\n\n"
 	}
 
@@ -145,6 +178,14 @@ func resourceToDiff(currency string, diffResource Resource, oldResource *Resourc
 		nameLabel = ui.BoldString(nameLabel)
 	}
 
+	if diffResource.MovedFrom != "" && op == UPDATED {
+		nameLabel += ui.FaintStringf(" (moved from %s)", diffResource.MovedFrom)
+	}
+
+	if diffResource.Replacement != "" && op == UPDATED {
+		nameLabel += ui.FaintStringf(" (replaced, %s)", replacementLabel(diffResource.Replacement))
+	}
+
 	s += fmt.Sprintf("%s %s\n", opChar(op), nameLabel)
 
 	if isTopLevel {
@@ -156,6 +197,12 @@ func resourceToDiff(currency string, diffResource Resource, oldResource *Resourc
 				ui.FaintString(formatCostChangeDetails(currency, oldCost, newCost)),
 			)
 		}
+
+		if diffResource.OverlapCost != nil {
+			s += fmt.Sprintf("  %s one-off overlap cost while both copies run\n",
+				formatCost(currency, diffResource.OverlapCost),
+			)
+		}
 	}
 
 	for _, diffComponent := range diffResource.CostComponents {
@@ -229,6 +276,13 @@ func costComponentToDiff(currency string, diffComponent CostComponent, oldCompon
 		)
 	}
 
+	if diffComponent.PriceChangeMonthlyCost != nil {
+		s += fmt.Sprintf("  %s\n", ui.FaintStringf("%s from price change, %s from quantity change",
+			formatCostChange(currency, diffComponent.PriceChangeMonthlyCost),
+			formatCostChange(currency, diffComponent.QuantityChangeMonthlyCost),
+		))
+	}
+
 	return s
 }
 
@@ -0,0 +1,177 @@
+package output
+
+import (
+	"fmt"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/jedib0t/go-pretty/v6/text"
+	"github.com/shopspring/decimal"
+
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/infracost/infracost/internal/ui"
+)
+
+// ForecastMonth is the total cost of a month of the forecast.
+type ForecastMonth struct {
+	Month            int              `json:"month"`
+	TotalMonthlyCost *decimal.Decimal `json:"totalMonthlyCost"`
+	CumulativeCost   *decimal.Decimal `json:"cumulativeCost"`
+}
+
+// resourceForecast returns the monthly cost of each month of the forecast of
+// the resource, or nil if the costs haven't been forecast.
+func resourceForecast(r *schema.Resource) []*decimal.Decimal {
+	if len(r.ForecastMonths) == 0 {
+		return nil
+	}
+
+	costs := make([]*decimal.Decimal, len(r.ForecastMonths))
+	for i, f := range r.ForecastMonths {
+		costs[i] = decimalPtr(decimal.Zero)
+		if f.MonthlyCost != nil {
+			costs[i] = f.MonthlyCost
+		}
+	}
+
+	return costs
+}
+
+// sumForecasts adds up the forecasts month by month. Where a forecast isn't
+// set the matching cost is added to every month, and where a forecast is
+// shorter than the others its last month is used for the remaining months. It
+// returns nil if none of the forecasts are set.
+func sumForecasts(forecasts [][]*decimal.Decimal, costs []*decimal.Decimal) []*decimal.Decimal {
+	months := 0
+	for _, f := range forecasts {
+		if len(f) > months {
+			months = len(f)
+		}
+	}
+
+	if months == 0 {
+		return nil
+	}
+
+	total := make([]*decimal.Decimal, months)
+	for m := range total {
+		sum := decimal.Zero
+
+		for i, f := range forecasts {
+			switch {
+			case len(f) == 0:
+				if costs[i] != nil {
+					sum = sum.Add(*costs[i])
+				}
+			case m < len(f):
+				sum = sum.Add(*f[m])
+			default:
+				sum = sum.Add(*f[len(f)-1])
+			}
+		}
+
+		total[m] = decimalPtr(sum)
+	}
+
+	return total
+}
+
+// calculateTotalForecast returns the total monthly cost of the resources for
+// each month of the forecast, or nil if the costs haven't been forecast.
+func calculateTotalForecast(resources []Resource) []*decimal.Decimal {
+	forecasts := make([][]*decimal.Decimal, len(resources))
+	costs := make([]*decimal.Decimal, len(resources))
+
+	for i, r := range resources {
+		forecasts[i] = r.ForecastMonthlyCosts
+		costs[i] = r.MonthlyCost
+	}
+
+	return sumForecasts(forecasts, costs)
+}
+
+// forecastMonths returns the months of the forecast with their cumulative
+// costs from the total monthly cost of each month.
+func forecastMonths(costs []*decimal.Decimal) []ForecastMonth {
+	if len(costs) == 0 {
+		return nil
+	}
+
+	months := make([]ForecastMonth, len(costs))
+	cumulative := decimal.Zero
+
+	for i, c := range costs {
+		cumulative = cumulative.Add(*c)
+		months[i] = ForecastMonth{
+			Month:            i + 1,
+			TotalMonthlyCost: c,
+			CumulativeCost:   decimalPtr(cumulative),
+		}
+	}
+
+	return months
+}
+
+// forecastCosts returns the total monthly cost of each month of the forecast.
+func forecastCosts(months []ForecastMonth) []*decimal.Decimal {
+	if len(months) == 0 {
+		return nil
+	}
+
+	costs := make([]*decimal.Decimal, len(months))
+	for i, m := range months {
+		costs[i] = m.TotalMonthlyCost
+	}
+
+	return costs
+}
+
+func convertForecast(costs []*decimal.Decimal, convert func(d *decimal.Decimal) *decimal.Decimal) []*decimal.Decimal {
+	if costs == nil {
+		return nil
+	}
+
+	converted := make([]*decimal.Decimal, len(costs))
+	for i, c := range costs {
+		converted[i] = convert(c)
+	}
+
+	return converted
+}
+
+// tableForForecast renders a table of the total and cumulative costs of each
+// month of the forecast.
+func tableForForecast(out Root) string {
+	t := table.NewWriter()
+	t.Style().Options.DrawBorder = false
+	t.Style().Options.SeparateColumns = false
+	t.Style().Options.SeparateRows = false
+	t.Style().Options.SeparateHeader = false
+	t.Style().Format.Header = text.FormatDefault
+
+	t.SetColumnConfigs([]table.ColumnConfig{
+		{Number: 1, Align: text.AlignLeft, AlignHeader: text.AlignLeft},
+		{Number: 2, Align: text.AlignRight, AlignHeader: text.AlignRight},
+		{Number: 3, Align: text.AlignRight, AlignHeader: text.AlignRight},
+	})
+
+	t.AppendHeader(table.Row{
+		ui.UnderlineString("Forecast"),
+		ui.UnderlineString(formatTitleWithCurrency("Monthly Cost", out.Currency)),
+		ui.UnderlineString(formatTitleWithCurrency("Cumulative Cost", out.Currency)),
+	})
+	t.AppendRow(table.Row{""})
+
+	for _, m := range out.Forecast {
+		t.AppendRow(table.Row{
+			ui.BoldString(forecastMonthLabel(m.Month)),
+			FormatCost2DP(out.Currency, m.TotalMonthlyCost),
+			FormatCost2DP(out.Currency, m.CumulativeCost),
+		})
+	}
+
+	return t.Render()
+}
+
+func forecastMonthLabel(month int) string {
+	return fmt.Sprintf("Month %d", month)
+}
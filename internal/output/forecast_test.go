@@ -0,0 +1,89 @@
+package output
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/infracost/infracost/internal/ui"
+)
+
+func TestForecast(t *testing.T) {
+	newResource := func(name string, storage int64) *schema.Resource {
+		qty := decimal.NewFromInt(storage)
+		c := &schema.CostComponent{
+			Name:            "Storage",
+			Unit:            "GB",
+			UnitMultiplier:  decimal.NewFromInt(1),
+			MonthlyQuantity: &qty,
+		}
+		c.SetPrice(decimal.NewFromInt(1))
+
+		return &schema.Resource{Name: name, CostComponents: []*schema.CostComponent{c}}
+	}
+
+	growing := newResource("aws_s3_bucket.growing", 100)
+	growing.ForecastMonths = []*schema.Resource{
+		newResource("aws_s3_bucket.growing", 100),
+		newResource("aws_s3_bucket.growing", 150),
+		newResource("aws_s3_bucket.growing", 200),
+	}
+	static := newResource("aws_s3_bucket.static", 10)
+	static.ForecastMonths = []*schema.Resource{static, static, static}
+
+	project := &schema.Project{
+		Name:      "app",
+		Metadata:  &schema.ProjectMetadata{},
+		Resources: []*schema.Resource{growing, static},
+	}
+	schema.CalculateCosts(project)
+
+	out, err := ToOutputFormat([]*schema.Project{project})
+	require.NoError(t, err)
+	out.Currency = "USD"
+
+	costs := func(d []*decimal.Decimal) []string {
+		s := make([]string, len(d))
+		for i, c := range d {
+			s[i] = c.String()
+		}
+		return s
+	}
+
+	assert.Equal(t, []string{"100", "150", "200"}, costs(out.Projects[0].Breakdown.Resources[0].ForecastMonthlyCosts))
+	assert.Equal(t, []string{"110", "160", "210"}, costs(out.Projects[0].Breakdown.ForecastMonthlyCosts))
+	require.Len(t, out.Forecast, 3)
+	assert.Equal(t, 3, out.Forecast[2].Month)
+	assert.Equal(t, "210", out.Forecast[2].TotalMonthlyCost.String())
+	assert.Equal(t, "480", out.Forecast[2].CumulativeCost.String())
+
+	b, err := ToTable(out, Options{Fields: []string{"monthlyQuantity", "unit", "monthlyCost"}})
+	require.NoError(t, err)
+	assert.Regexp(t, `Month 3\s+\$210\.00\s+\$480\.00`, ui.StripColor(string(b)))
+
+	b, err = ToCSV(out, Options{})
+	require.NoError(t, err)
+	assert.Equal(t, `Project,Resource,Monthly Cost,Month 1,Month 2,Month 3
+app,aws_s3_bucket.growing,100.00,100.00,150.00,200.00
+app,aws_s3_bucket.static,10.00,10.00,10.00,10.00
+,Total,110.00,110.00,160.00,210.00
+`, string(b))
+
+	// A report without a forecast adds its monthly cost to every month
+	other := &schema.Project{Name: "other", Metadata: &schema.ProjectMetadata{}, Resources: []*schema.Resource{newResource("aws_s3_bucket.other", 5)}}
+	schema.CalculateCosts(other)
+	otherOut, err := ToOutputFormat([]*schema.Project{other})
+	require.NoError(t, err)
+
+	combined, err := Combine([]ReportInput{{Root: out}, {Root: otherOut}}, nil)
+	require.NoError(t, err)
+	require.Len(t, combined.Forecast, 3)
+	assert.Equal(t, "215", combined.Forecast[2].TotalMonthlyCost.String())
+
+	compared, err := CompareTo(out, Root{}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "480", compared.Forecast[2].CumulativeCost.String())
+}
@@ -0,0 +1,214 @@
+package output
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/jedib0t/go-pretty/v6/text"
+	"github.com/shopspring/decimal"
+
+	"github.com/infracost/infracost/internal/ui"
+)
+
+const (
+	tagGroupByPrefix = "tag:"
+
+	// untaggedGroup is the group of the resources that don't have the tag that
+	// is grouped by.
+	untaggedGroup = "untagged"
+	// rootModuleGroup is the group of the resources that aren't in a module.
+	rootModuleGroup = "root"
+	// unknownRegionGroup is the group of the resources with no region, e.g.
+	// resources from reports that were generated before the region was added.
+	unknownRegionGroup = "unknown"
+)
+
+// GroupByKeys are the keys that costs can be grouped by, in addition to
+// tag:<key>.
+var GroupByKeys = []string{"module", "resource_type", "provider", "region"}
+
+var modulePrefixRegex = regexp.MustCompile(`^((?:module\.[^.\[]+(?:\[[^\]]*\])?\.)*)`)
+
+// CostGroup is the total cost of the resources that have the same value for a
+// group by key, e.g. the resources with the same team tag.
+type CostGroup struct {
+	Key           string           `json:"key"`
+	Value         string           `json:"value"`
+	ResourceCount int              `json:"resourceCount"`
+	HourlyCost    *decimal.Decimal `json:"hourlyCost"`
+	MonthlyCost   *decimal.Decimal `json:"monthlyCost"`
+}
+
+// ValidateGroupBy returns an error if any of the keys can't be grouped by.
+func ValidateGroupBy(keys []string) error {
+	for _, key := range keys {
+		if strings.HasPrefix(key, tagGroupByPrefix) {
+			if strings.TrimPrefix(key, tagGroupByPrefix) == "" {
+				return fmt.Errorf("Invalid group by key '%s', the tag key is missing", key)
+			}
+
+			continue
+		}
+
+		if !contains(GroupByKeys, key) {
+			return fmt.Errorf("Invalid group by key '%s', valid keys are: %s or tag:<key>", key, strings.Join(GroupByKeys, ", "))
+		}
+	}
+
+	return nil
+}
+
+// GroupKeys returns the group by keys of the groups in the order they were
+// requested.
+func (r Root) GroupKeys() []string {
+	keys := make([]string, 0)
+	for _, g := range r.Groups {
+		if !contains(keys, g.Key) {
+			keys = append(keys, g.Key)
+		}
+	}
+
+	return keys
+}
+
+// GroupsWithKey returns the groups of the group by key.
+func (r Root) GroupsWithKey(key string) []CostGroup {
+	groups := make([]CostGroup, 0)
+	for _, g := range r.Groups {
+		if g.Key == key {
+			groups = append(groups, g)
+		}
+	}
+
+	return groups
+}
+
+// withGroups returns a copy of out with the costs of the resources of all its
+// projects grouped by each of the group by keys.
+func withGroups(out Root, groupBy []string) Root {
+	if len(groupBy) == 0 {
+		return out
+	}
+
+	groups := make([]CostGroup, 0)
+	for _, key := range groupBy {
+		groups = append(groups, groupResources(out.Projects, key)...)
+	}
+	out.Groups = groups
+
+	return out
+}
+
+func groupResources(projects []Project, key string) []CostGroup {
+	groups := make([]CostGroup, 0)
+	indexes := make(map[string]int)
+
+	for _, p := range projects {
+		if p.Breakdown == nil {
+			continue
+		}
+
+		for _, r := range p.Breakdown.Resources {
+			value := groupValue(r, key)
+
+			i, ok := indexes[value]
+			if !ok {
+				i = len(groups)
+				indexes[value] = i
+				groups = append(groups, CostGroup{
+					Key:         key,
+					Value:       value,
+					HourlyCost:  decimalPtr(decimal.Zero),
+					MonthlyCost: decimalPtr(decimal.Zero),
+				})
+			}
+
+			g := &groups[i]
+			g.ResourceCount++
+			if r.HourlyCost != nil {
+				g.HourlyCost = decimalPtr(g.HourlyCost.Add(*r.HourlyCost))
+			}
+			if r.MonthlyCost != nil {
+				g.MonthlyCost = decimalPtr(g.MonthlyCost.Add(*r.MonthlyCost))
+			}
+		}
+	}
+
+	sort.SliceStable(groups, func(i, j int) bool {
+		if !groups[i].MonthlyCost.Equal(*groups[j].MonthlyCost) {
+			return groups[i].MonthlyCost.GreaterThan(*groups[j].MonthlyCost)
+		}
+
+		return groups[i].Value < groups[j].Value
+	})
+
+	return groups
+}
+
+func groupValue(r Resource, key string) string {
+	if strings.HasPrefix(key, tagGroupByPrefix) {
+		if v := r.Tags[strings.TrimPrefix(key, tagGroupByPrefix)]; v != "" {
+			return v
+		}
+
+		return untaggedGroup
+	}
+
+	switch key {
+	case "module":
+		if m := strings.TrimSuffix(modulePrefixRegex.FindString(r.Name), "."); m != "" {
+			return m
+		}
+
+		return rootModuleGroup
+	case "resource_type":
+		return r.ResourceType()
+	case "provider":
+		return strings.SplitN(r.ResourceType(), "_", 2)[0]
+	case "region":
+		if r.Region != "" {
+			return r.Region
+		}
+
+		return unknownRegionGroup
+	}
+
+	return ""
+}
+
+// tableForGroups renders a table with the costs of the groups of a group by
+// key.
+func tableForGroups(currency string, key string, groups []CostGroup) string {
+	t := table.NewWriter()
+	t.Style().Options.DrawBorder = false
+	t.Style().Options.SeparateColumns = false
+	t.Style().Options.SeparateRows = false
+	t.Style().Options.SeparateHeader = false
+	t.Style().Format.Header = text.FormatDefault
+
+	t.SetColumnConfigs([]table.ColumnConfig{
+		{Number: 1, Align: text.AlignLeft, AlignHeader: text.AlignLeft},
+		{Number: 2, Align: text.AlignRight, AlignHeader: text.AlignRight},
+		{Number: 3, Align: text.AlignRight, AlignHeader: text.AlignRight},
+	})
+
+	t.AppendHeader(table.Row{
+		ui.UnderlineString(key),
+		ui.UnderlineString("Resources"),
+		ui.UnderlineString(formatTitleWithCurrency("Monthly Cost", currency)),
+	})
+	t.AppendRow(table.Row{""})
+
+	for _, g := range groups {
+		t.AppendRow(table.Row{
+			ui.BoldString(g.Value),
+			g.ResourceCount,
+			FormatCost2DP(currency, g.MonthlyCost),
+		})
+	}
+
+	return t.Render()
+}
@@ -0,0 +1,92 @@
+package output
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/infracost/infracost/internal/ui"
+)
+
+func TestValidateGroupBy(t *testing.T) {
+	assert.NoError(t, ValidateGroupBy([]string{"module", "resource_type", "provider", "region", "tag:team"}))
+	assert.EqualError(t, ValidateGroupBy([]string{"tag:"}), "Invalid group by key 'tag:', the tag key is missing")
+	assert.EqualError(t, ValidateGroupBy([]string{"team"}), "Invalid group by key 'team', valid keys are: module, resource_type, provider, region or tag:<key>")
+}
+
+func TestGroupBy(t *testing.T) {
+	newResource := func(name, region string, price int64, tags map[string]string) *schema.Resource {
+		qty := decimal.NewFromInt(730)
+		c := &schema.CostComponent{
+			Name:            "Instance usage",
+			Unit:            "hours",
+			UnitMultiplier:  decimal.NewFromInt(1),
+			MonthlyQuantity: &qty,
+		}
+		c.SetPrice(decimal.NewFromInt(price))
+
+		return &schema.Resource{Name: name, Region: region, Tags: tags, CostComponents: []*schema.CostComponent{c}}
+	}
+
+	newRoot := func(name string, resources ...*schema.Resource) Root {
+		project := &schema.Project{
+			Name:      name,
+			Metadata:  &schema.ProjectMetadata{},
+			Resources: resources,
+		}
+		schema.CalculateCosts(project)
+
+		out, err := ToOutputFormat([]*schema.Project{project})
+		require.NoError(t, err)
+		out.Currency = "USD"
+		return out
+	}
+
+	combined, err := Combine([]ReportInput{
+		{Root: newRoot("app",
+			newResource("aws_instance.web", "us-east-1", 2, map[string]string{"team": "web"}),
+			newResource(`module.db["main.eu"].aws_db_instance.db`, "eu-west-1", 3, map[string]string{"team": "data"}),
+		)},
+		{Root: newRoot("jobs",
+			newResource("google_compute_instance.worker", "us-central1", 1, map[string]string{"team": "data"}),
+			newResource("aws_instance.untagged", "", 1, nil),
+		)},
+	}, nil)
+	require.NoError(t, err)
+
+	out := withGroups(combined, []string{"tag:team", "module", "provider", "region"})
+	assert.Equal(t, []string{"tag:team", "module", "provider", "region"}, out.GroupKeys())
+
+	values := func(key string) map[string]string {
+		m := make(map[string]string)
+		for _, g := range out.GroupsWithKey(key) {
+			m[g.Value] = g.MonthlyCost.String()
+		}
+		return m
+	}
+
+	assert.Equal(t, map[string]string{"data": "2920", "web": "1460", "untagged": "730"}, values("tag:team"))
+	assert.Equal(t, map[string]string{`module.db["main.eu"]`: "2190", "root": "2920"}, values("module"))
+	assert.Equal(t, map[string]string{"aws": "4380", "google": "730"}, values("provider"))
+	assert.Equal(t, map[string]string{"eu-west-1": "2190", "us-east-1": "1460", "us-central1": "730", "unknown": "730"}, values("region"))
+
+	// Groups are sorted by their cost
+	teams := out.GroupsWithKey("tag:team")
+	assert.Equal(t, "data", teams[0].Value)
+	assert.Equal(t, 2, teams[0].ResourceCount)
+
+	// The input isn't modified
+	assert.Nil(t, combined.Groups)
+
+	b, err := ToTable(combined, Options{Fields: []string{"monthlyQuantity", "unit", "monthlyCost"}, GroupBy: []string{"tag:team"}})
+	require.NoError(t, err)
+	assert.Regexp(t, `tag:team\s+Resources\s+Monthly Cost`, ui.StripColor(string(b)))
+	assert.Regexp(t, `untagged\s+1\s+\$730\.00`, ui.StripColor(string(b)))
+
+	b, err = ToMarkdown(combined, Options{GroupBy: []string{"tag:team"}}, MarkdownOptions{BasicSyntax: true})
+	require.NoError(t, err)
+	assert.Contains(t, string(b), "| data | 2 | $2,920 |")
+}
@@ -17,6 +17,12 @@ import (
 )
 
 func ToHTML(out Root, opts Options) ([]byte, error) {
+	out = withPeriodTotals(out, opts.Fields)
+	out = withGroups(out, opts.GroupBy)
+
+	// The daily and annual totals are added as rows rather than columns
+	opts.Fields = columnFields(opts.Fields)
+
 	var buf bytes.Buffer
 	bufw := bufio.NewWriter(&buf)
 
@@ -47,6 +53,14 @@ func ToHTML(out Root, opts Options) ([]byte, error) {
 		"formatPrice":             func(d decimal.Decimal) string { return formatPrice(out.Currency, d) },
 		"formatTitleWithCurrency": func(title string) string { return formatTitleWithCurrency(title, out.Currency) },
 		"formatQuantity":          formatQuantity,
+		// The totals are in the monthly cost column, so the carbon column
+		// after it isn't spanned by the name of the total rows
+		"totalColspan": func(fields []string) int {
+			if contains(fields, "monthlyCarbon") {
+				return len(fields) - 1
+			}
+			return len(fields)
+		},
 		"projectLabel": func(p Project) string {
 			return p.Label()
 		},
@@ -5,5 +5,5 @@ import (
 )
 
 func ToJSON(out Root, opts Options) ([]byte, error) {
-	return json.Marshal(out)
+	return json.Marshal(withGroups(withPeriodTotals(out, opts.Fields), opts.GroupBy))
 }
@@ -110,6 +110,9 @@ func calculateMetadataToDisplay(projects []Project) (hasModulePath bool, hasWork
 func ToMarkdown(out Root, opts Options, markdownOpts MarkdownOptions) ([]byte, error) {
 	var diffMsg string
 
+	out = withPeriodTotals(out, opts.Fields)
+	out = withGroups(out, opts.GroupBy)
+
 	if opts.diffMsg != "" {
 		diffMsg = opts.diffMsg
 	} else {
@@ -138,7 +141,13 @@ func ToMarkdown(out Root, opts Options, markdownOpts MarkdownOptions) ([]byte, e
 		"formatCostChange": func(pastCost, cost *decimal.Decimal) string {
 			return formatMarkdownCostChange(out.Currency, pastCost, cost, false)
 		},
+		"formatCostDiff": func(d *decimal.Decimal) string {
+			return formatCostChange(out.Currency, d)
+		},
 		"formatCostChangeSentence": formatCostChangeSentence,
+		"formatCarbon":             formatCarbon,
+		"formatCarbonChange":       formatCarbonChange,
+		"replacementLabel":         replacementLabel,
 		"showProject": func(p Project) bool {
 			if opts.ShowOnlyChanges {
 				// only return true if the project has code changes so the table can also show
@@ -221,6 +230,8 @@ func ToMarkdown(out Root, opts Options, markdownOpts MarkdownOptions) ([]byte, e
 		SkippedProjectCount          int
 		SkippedUnchangedProjectCount int
 		DiffOutput                   string
+		Replacements                 []ReplacedResource
+		PriceChanges                 []PriceChangedComponent
 		Options                      Options
 		MarkdownOptions              MarkdownOptions
 	}{
@@ -228,6 +239,8 @@ func ToMarkdown(out Root, opts Options, markdownOpts MarkdownOptions) ([]byte, e
 		skippedProjectCount,
 		skippedUnchangedProjectCount,
 		diffMsg,
+		replacedResources(out),
+		priceChangedComponents(out),
 		opts,
 		markdownOpts})
 	if err != nil {
@@ -255,3 +268,54 @@ func ToMarkdown(out Root, opts Options, markdownOpts MarkdownOptions) ([]byte, e
 func hasCodeChanges(options Options, project Project) bool {
 	return options.ShowOnlyChanges && project.Metadata.VCSCodeChanged != nil && *project.Metadata.VCSCodeChanged
 }
+
+// PriceChangedComponent is a cost component whose price changed between the
+// compared runs. It is called out in the markdown comment with its cost change
+// split into the part caused by the price change and the part caused by the
+// quantity change.
+type PriceChangedComponent struct {
+	Project        string
+	Name           string
+	PriceChange    *decimal.Decimal
+	QuantityChange *decimal.Decimal
+}
+
+// priceChangedComponents returns the cost components of the diffs of the
+// projects that have a price change.
+func priceChangedComponents(out Root) []PriceChangedComponent {
+	var changed []PriceChangedComponent
+
+	var addResource func(project string, prefix string, r Resource)
+	addResource = func(project string, prefix string, r Resource) {
+		name := prefix + r.Name
+
+		for _, c := range r.CostComponents {
+			if c.PriceChangeMonthlyCost == nil {
+				continue
+			}
+
+			changed = append(changed, PriceChangedComponent{
+				Project:        project,
+				Name:           name + " → " + c.Name,
+				PriceChange:    c.PriceChangeMonthlyCost,
+				QuantityChange: c.QuantityChangeMonthlyCost,
+			})
+		}
+
+		for _, s := range r.SubResources {
+			addResource(project, name+" → ", s)
+		}
+	}
+
+	for _, p := range out.Projects {
+		if p.Diff == nil {
+			continue
+		}
+
+		for _, r := range p.Diff.Resources {
+			addResource(p.Name, "", r)
+		}
+	}
+
+	return changed
+}
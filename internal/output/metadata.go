@@ -3,6 +3,7 @@ package output
 import (
 	"time"
 
+	"github.com/infracost/infracost/internal/carbon"
 	"github.com/infracost/infracost/internal/config"
 )
 
@@ -26,6 +27,15 @@ type Metadata struct {
 	VCSPullRequestLabels []string `json:"vcsPullRequestLabels,omitempty"`
 	VCSPipelineRunID     string   `json:"vcsPipelineRunId,omitempty"`
 	VCSPullRequestID     string   `json:"vcsPullRequestId,omitempty"`
+
+	// FXRatesSource and FXRatesEffectiveDate are set when the costs were
+	// converted to the currency with a local exchange rates file.
+	FXRatesSource        string `json:"fxRatesSource,omitempty"`
+	FXRatesEffectiveDate string `json:"fxRatesEffectiveDate,omitempty"`
+
+	// CarbonCoefficientsVersion is the version of the coefficients dataset the
+	// carbon emissions were estimated with.
+	CarbonCoefficientsVersion string `json:"carbonCoefficientsVersion,omitempty"`
 }
 
 // NewMetadata returns a Metadata struct filled with information built from the RunContext.
@@ -55,5 +65,16 @@ func NewMetadata(ctx *config.RunContext) Metadata {
 		m.VCSPipelineRunID = ctx.VCSMetadata.Pipeline.ID
 	}
 
+	// The rates have already been loaded to price the run so any error has
+	// already been returned.
+	if rates, err := ctx.Config.LoadFXRates(); err == nil && rates != nil {
+		m.FXRatesSource = rates.Source
+		m.FXRatesEffectiveDate = rates.FormattedEffectiveDate()
+	}
+
+	if ctx.Config.EstimateCarbon() {
+		m.CarbonCoefficientsVersion = carbon.CoefficientsVersion()
+	}
+
 	return m
 }
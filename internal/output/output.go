@@ -30,10 +30,44 @@ type Root struct {
 	PastTotalMonthlyCost *decimal.Decimal `json:"pastTotalMonthlyCost"`
 	DiffTotalHourlyCost  *decimal.Decimal `json:"diffTotalHourlyCost"`
 	DiffTotalMonthlyCost *decimal.Decimal `json:"diffTotalMonthlyCost"`
+	// TotalOnDemandMonthlyCost is only set if commitments cover some of the costs
+	TotalOnDemandMonthlyCost *decimal.Decimal `json:"totalOnDemandMonthlyCost,omitempty"`
+	// TotalListMonthlyCost is only set if discount rules apply to some of the costs
+	TotalListMonthlyCost *decimal.Decimal `json:"totalListMonthlyCost,omitempty"`
 	TimeGenerated        time.Time        `json:"timeGenerated"`
 	Summary              *Summary         `json:"summary"`
 	FullSummary          *Summary         `json:"-"`
 	IsCIRun              bool             `json:"-"`
+
+	// TotalDailyCost and TotalAnnualCost are only set if the dailyCost and
+	// annualCost output fields are requested
+	TotalDailyCost  *decimal.Decimal `json:"totalDailyCost,omitempty"`
+	TotalAnnualCost *decimal.Decimal `json:"totalAnnualCost,omitempty"`
+
+	// Groups are only set if the costs are grouped by with the --group-by flag
+	Groups []CostGroup `json:"groups,omitempty"`
+
+	// TagPolicy is only set if the config file has a tag policy
+	TagPolicy *TagPolicyCheck `json:"tagPolicy,omitempty"`
+
+	// TotalMonthlyCostRange is only set if some of the resources have usage
+	// scenarios in the usage file
+	TotalMonthlyCostRange *CostRange `json:"totalMonthlyCostRange,omitempty"`
+
+	// Forecast is only set if the costs are forecast with the --forecast-months flag
+	Forecast []ForecastMonth `json:"forecast,omitempty"`
+
+	// TotalMonthlyCarbon, PastTotalMonthlyCarbon and DiffTotalMonthlyCarbon are
+	// the estimated emissions in kgCO2e, they are only set if the monthlyCarbon
+	// output field is requested
+	TotalMonthlyCarbon     *decimal.Decimal `json:"totalMonthlyCarbon,omitempty"`
+	PastTotalMonthlyCarbon *decimal.Decimal `json:"pastTotalMonthlyCarbon,omitempty"`
+	DiffTotalMonthlyCarbon *decimal.Decimal `json:"diffTotalMonthlyCarbon,omitempty"`
+
+	// TotalOverlapCost is only set if the replacement overlap is configured and
+	// some resources are replaced with create_before_destroy, it is the one-off
+	// cost of running both copies of them while they are replaced.
+	TotalOverlapCost *decimal.Decimal `json:"totalOverlapCost,omitempty"`
 }
 
 type Project struct {
@@ -74,11 +108,16 @@ func convertOutputResources(outResources []Resource) []*schema.Resource {
 	for i, resource := range outResources {
 		resources[i] = &schema.Resource{
 			Name:           resource.Name,
+			Tags:           resource.Tags,
+			Region:         resource.Region,
 			CostComponents: convertCostComponents(resource.CostComponents),
 			ActualCosts:    convertActualCosts(resource.ActualCosts),
 			SubResources:   convertOutputResources(resource.SubResources),
 			HourlyCost:     resource.HourlyCost,
 			MonthlyCost:    resource.MonthlyCost,
+			MonthlyCarbon:  resource.MonthlyCarbon,
+			MovedFrom:      resource.MovedFrom,
+			Replacement:    resource.Replacement,
 			ResourceType:   resource.ResourceType(),
 		}
 	}
@@ -91,16 +130,27 @@ func convertCostComponents(outComponents []CostComponent) []*schema.CostComponen
 
 	for i, c := range outComponents {
 		sc := &schema.CostComponent{
-			Name:            c.Name,
-			Unit:            c.Unit,
-			UnitMultiplier:  decimal.NewFromInt(1),
-			HourlyCost:      c.HourlyCost,
-			MonthlyCost:     c.MonthlyCost,
-			HourlyQuantity:  c.HourlyQuantity,
-			MonthlyQuantity: c.MonthlyQuantity,
+			Name:                c.Name,
+			Unit:                c.Unit,
+			UnitMultiplier:      decimal.NewFromInt(1),
+			HourlyCost:          c.HourlyCost,
+			MonthlyCost:         c.MonthlyCost,
+			HourlyQuantity:      c.HourlyQuantity,
+			MonthlyQuantity:     c.MonthlyQuantity,
+			Commitment:          c.Commitment,
+			OnDemandHourlyCost:  c.OnDemandHourlyCost,
+			OnDemandMonthlyCost: c.OnDemandMonthlyCost,
+			DiscountRule:        c.DiscountRule,
+			ListMonthlyCost:     c.ListMonthlyCost,
+			PriceOverride:       c.PriceOverride,
+			MonthlyCarbon:       c.MonthlyCarbon,
 		}
 		sc.SetPrice(c.Price)
 
+		if c.PriceUnavailable {
+			sc.PriceUnavailableReason = "Price unavailable"
+		}
+
 		components[i] = sc
 	}
 
@@ -142,6 +192,12 @@ func (r *Root) ExampleProjectName() string {
 	return r.Projects[0].Name
 }
 
+// IsLowerBound returns true if the price of some cost components could not be
+// retrieved, so the actual costs may be higher than the reported costs.
+func (r Root) IsLowerBound() bool {
+	return r.Summary != nil && r.Summary.TotalPriceUnavailableComponents != nil && *r.Summary.TotalPriceUnavailableComponents > 0
+}
+
 // HasDiff returns true if any project has a difference in monthly cost or resources
 func (r *Root) HasDiff() bool {
 	for _, p := range r.Projects {
@@ -180,17 +236,65 @@ type Breakdown struct {
 	Resources        []Resource       `json:"resources"`
 	TotalHourlyCost  *decimal.Decimal `json:"totalHourlyCost"`
 	TotalMonthlyCost *decimal.Decimal `json:"totalMonthlyCost"`
+	// TotalOnDemandMonthlyCost is only set if commitments cover some of the costs
+	TotalOnDemandMonthlyCost *decimal.Decimal `json:"totalOnDemandMonthlyCost,omitempty"`
+	// TotalListMonthlyCost is only set if discount rules apply to some of the costs
+	TotalListMonthlyCost *decimal.Decimal `json:"totalListMonthlyCost,omitempty"`
+	// TotalPriceChangeMonthlyCost and TotalQuantityChangeMonthlyCost are only
+	// set on diffs where the price of some of the cost components changed
+	TotalPriceChangeMonthlyCost    *decimal.Decimal `json:"totalPriceChangeMonthlyCost,omitempty"`
+	TotalQuantityChangeMonthlyCost *decimal.Decimal `json:"totalQuantityChangeMonthlyCost,omitempty"`
+	// TotalDailyCost and TotalAnnualCost are only set if the dailyCost and
+	// annualCost output fields are requested
+	TotalDailyCost  *decimal.Decimal `json:"totalDailyCost,omitempty"`
+	TotalAnnualCost *decimal.Decimal `json:"totalAnnualCost,omitempty"`
+	// TotalMonthlyCostRange is only set if some of the resources have usage
+	// scenarios in the usage file
+	TotalMonthlyCostRange *CostRange `json:"totalMonthlyCostRange,omitempty"`
+	// ForecastMonthlyCosts are only set if the costs are forecast with the
+	// --forecast-months flag, they are the total monthly cost of each month.
+	ForecastMonthlyCosts []*decimal.Decimal `json:"forecastMonthlyCosts,omitempty"`
+	// TotalMonthlyCarbon is the estimated emissions in kgCO2e, it is only set if
+	// the monthlyCarbon output field is requested
+	TotalMonthlyCarbon *decimal.Decimal `json:"totalMonthlyCarbon,omitempty"`
+	// TotalOverlapCost is only set on diffs with replacement overlap costs, it
+	// is the one-off cost of running both copies of the replaced resources.
+	TotalOverlapCost *decimal.Decimal `json:"totalOverlapCost,omitempty"`
 }
 
 type CostComponent struct {
-	Name            string             `json:"name"`
-	Unit            string             `json:"unit"`
-	HourlyQuantity  *decimal.Decimal   `json:"hourlyQuantity"`
-	MonthlyQuantity *decimal.Decimal   `json:"monthlyQuantity"`
-	Price           decimal.Decimal    `json:"price"`
-	HourlyCost      *decimal.Decimal   `json:"hourlyCost"`
-	MonthlyCost     *decimal.Decimal   `json:"monthlyCost"`
-	TierData        []schema.PriceTier `json:"tiers,omitempty"`
+	Name             string             `json:"name"`
+	Unit             string             `json:"unit"`
+	HourlyQuantity   *decimal.Decimal   `json:"hourlyQuantity"`
+	MonthlyQuantity  *decimal.Decimal   `json:"monthlyQuantity"`
+	Price            decimal.Decimal    `json:"price"`
+	HourlyCost       *decimal.Decimal   `json:"hourlyCost"`
+	MonthlyCost      *decimal.Decimal   `json:"monthlyCost"`
+	TierData         []schema.PriceTier `json:"tiers,omitempty"`
+	PriceUnavailable bool               `json:"priceUnavailable,omitempty"`
+	// PriceNotFound is only used by the region comparison, it isn't part of the
+	// JSON output.
+	PriceNotFound bool `json:"-"`
+	// Commitment describes the Reserved Instances or Savings Plans covering the
+	// cost component, the on-demand costs are the costs without them.
+	Commitment          string           `json:"commitment,omitempty"`
+	OnDemandHourlyCost  *decimal.Decimal `json:"onDemandHourlyCost,omitempty"`
+	OnDemandMonthlyCost *decimal.Decimal `json:"onDemandMonthlyCost,omitempty"`
+	// DiscountRule names the discount rules applied to the cost component, the
+	// list monthly cost is the monthly cost before the discount.
+	DiscountRule    string           `json:"discountRule,omitempty"`
+	ListMonthlyCost *decimal.Decimal `json:"listMonthlyCost,omitempty"`
+	// PriceOverride names the price override that set the price of the cost component
+	PriceOverride string `json:"priceOverride,omitempty"`
+	// PriceChangeMonthlyCost and QuantityChangeMonthlyCost are only set on diff
+	// cost components whose price changed, they split the monthly cost change
+	// into the part caused by the price change and the part caused by the
+	// quantity change.
+	PriceChangeMonthlyCost    *decimal.Decimal `json:"priceChangeMonthlyCost,omitempty"`
+	QuantityChangeMonthlyCost *decimal.Decimal `json:"quantityChangeMonthlyCost,omitempty"`
+	// MonthlyCarbon is the estimated emissions in kgCO2e, it is only set if the
+	// monthlyCarbon output field is requested.
+	MonthlyCarbon *decimal.Decimal `json:"monthlyCarbon,omitempty"`
 }
 
 type ActualCosts struct {
@@ -203,12 +307,32 @@ type ActualCosts struct {
 type Resource struct {
 	Name           string                 `json:"name"`
 	Tags           map[string]string      `json:"tags,omitempty"`
+	Region         string                 `json:"region,omitempty"`
 	Metadata       map[string]interface{} `json:"metadata"`
 	HourlyCost     *decimal.Decimal       `json:"hourlyCost"`
 	MonthlyCost    *decimal.Decimal       `json:"monthlyCost"`
 	CostComponents []CostComponent        `json:"costComponents,omitempty"`
 	ActualCosts    []ActualCosts          `json:"actualCosts,omitempty"`
 	SubResources   []Resource             `json:"subresources,omitempty"`
+	// MonthlyCostRange is only set if the resource has usage scenarios in the
+	// usage file, the monthly cost is the cost of the expected scenario.
+	MonthlyCostRange *CostRange `json:"monthlyCostRange,omitempty"`
+	// ForecastMonthlyCosts are only set if the costs are forecast with the
+	// --forecast-months flag, they are the monthly cost of each month.
+	ForecastMonthlyCosts []*decimal.Decimal `json:"forecastMonthlyCosts,omitempty"`
+	// MonthlyCarbon is the estimated emissions in kgCO2e, it is only set if the
+	// monthlyCarbon output field is requested.
+	MonthlyCarbon *decimal.Decimal `json:"monthlyCarbon,omitempty"`
+	// MovedFrom is only set if the address of the resource has changed, e.g.
+	// with a moved block, it is the past name of the resource.
+	MovedFrom string `json:"movedFrom,omitempty"`
+	// Replacement is only set on diff resources that the plan replaces, it is
+	// either create_before_destroy or destroy_before_create.
+	Replacement string `json:"replacement,omitempty"`
+	// OverlapCost is only set if the replacement overlap is configured and the
+	// resource is replaced with create_before_destroy, it is the one-off cost of
+	// running its past copy for the overlap.
+	OverlapCost *decimal.Decimal `json:"overlapCost,omitempty"`
 }
 
 func (r Resource) ResourceType() string {
@@ -229,6 +353,15 @@ type Summary struct {
 	TotalUsageBasedResources  *int `json:"totalUsageBasedResources,omitempty"`
 	TotalNoPriceResources     *int `json:"totalNoPriceResources,omitempty"`
 
+	// TotalPriceUnavailableComponents is the number of cost components whose
+	// price could not be retrieved. If it is non-zero the costs are a lower bound.
+	TotalPriceUnavailableComponents *int `json:"totalPriceUnavailableComponents,omitempty"`
+
+	// TotalDailyCost and TotalAnnualCost are only set if the dailyCost and
+	// annualCost output fields are requested.
+	TotalDailyCost  *decimal.Decimal `json:"totalDailyCost,omitempty"`
+	TotalAnnualCost *decimal.Decimal `json:"totalAnnualCost,omitempty"`
+
 	SupportedResourceCounts   *map[string]int `json:"supportedResourceCounts,omitempty"`
 	UnsupportedResourceCounts *map[string]int `json:"unsupportedResourceCounts,omitempty"`
 	NoPriceResourceCounts     *map[string]int `json:"noPriceResourceCounts,omitempty"`
@@ -251,6 +384,7 @@ type Options struct {
 	ShowAllProjects   bool
 	ShowOnlyChanges   bool
 	Fields            []string
+	GroupBy           []string
 	IncludeHTML       bool
 	PolicyChecks      PolicyCheck
 	GuardrailCheck    GuardrailCheck
@@ -347,11 +481,23 @@ func outputBreakdown(resources []*schema.Resource) *Breakdown {
 
 	totalMonthlyCost, totalHourlyCost := calculateTotalCosts(arr)
 
-	return &Breakdown{
-		Resources:        arr,
-		TotalHourlyCost:  totalMonthlyCost,
-		TotalMonthlyCost: totalHourlyCost,
+	b := &Breakdown{
+		Resources:                arr,
+		TotalHourlyCost:          totalMonthlyCost,
+		TotalMonthlyCost:         totalHourlyCost,
+		TotalOnDemandMonthlyCost: calculateTotalMonthlyCostWith(arr, func(c CostComponent) *decimal.Decimal { return c.OnDemandMonthlyCost }),
+		TotalListMonthlyCost:     calculateTotalMonthlyCostWith(arr, func(c CostComponent) *decimal.Decimal { return c.ListMonthlyCost }),
+		TotalMonthlyCostRange:    calculateTotalCostRange(arr),
+		ForecastMonthlyCosts:     calculateTotalForecast(arr),
+		TotalMonthlyCarbon:       calculateTotalCarbon(arr),
 	}
+
+	b.TotalPriceChangeMonthlyCost = calculateTotalPriceChange(arr)
+	if b.TotalPriceChangeMonthlyCost != nil && b.TotalMonthlyCost != nil {
+		b.TotalQuantityChangeMonthlyCost = decimalPtr(b.TotalMonthlyCost.Sub(*b.TotalPriceChangeMonthlyCost))
+	}
+
+	return b
 }
 
 func outputResource(r *schema.Resource) Resource {
@@ -372,14 +518,20 @@ func outputResource(r *schema.Resource) Resource {
 	}
 
 	return Resource{
-		Name:           r.Name,
-		Metadata:       metadata,
-		Tags:           r.Tags,
-		HourlyCost:     r.HourlyCost,
-		MonthlyCost:    r.MonthlyCost,
-		CostComponents: comps,
-		ActualCosts:    actualCosts,
-		SubResources:   subresources,
+		Name:                 r.Name,
+		Metadata:             metadata,
+		Tags:                 r.Tags,
+		Region:               r.Region,
+		HourlyCost:           r.HourlyCost,
+		MonthlyCost:          r.MonthlyCost,
+		CostComponents:       comps,
+		ActualCosts:          actualCosts,
+		SubResources:         subresources,
+		MonthlyCostRange:     resourceCostRange(r),
+		ForecastMonthlyCosts: resourceForecast(r),
+		MonthlyCarbon:        r.MonthlyCarbon,
+		MovedFrom:            r.MovedFrom,
+		Replacement:          r.Replacement,
 	}
 }
 
@@ -387,14 +539,26 @@ func outputCostComponents(costComponents []*schema.CostComponent) []CostComponen
 	comps := make([]CostComponent, 0, len(costComponents))
 	for _, c := range costComponents {
 		comps = append(comps, CostComponent{
-			Name:            c.Name,
-			Unit:            c.Unit,
-			HourlyQuantity:  c.UnitMultiplierHourlyQuantity(),
-			MonthlyQuantity: c.UnitMultiplierMonthlyQuantity(),
-			Price:           c.UnitMultiplierPrice(),
-			HourlyCost:      c.HourlyCost,
-			MonthlyCost:     c.MonthlyCost,
-			TierData:        c.PriceTiers(),
+			Name:                c.Name,
+			Unit:                c.Unit,
+			HourlyQuantity:      c.UnitMultiplierHourlyQuantity(),
+			MonthlyQuantity:     c.UnitMultiplierMonthlyQuantity(),
+			Price:               c.UnitMultiplierPrice(),
+			HourlyCost:          c.HourlyCost,
+			MonthlyCost:         c.MonthlyCost,
+			TierData:            c.PriceTiers(),
+			PriceUnavailable:    c.PriceUnavailable(),
+			PriceNotFound:       c.PriceNotFound,
+			Commitment:          c.Commitment,
+			OnDemandHourlyCost:  c.OnDemandHourlyCost,
+			OnDemandMonthlyCost: c.OnDemandMonthlyCost,
+			DiscountRule:        c.DiscountRule,
+			ListMonthlyCost:     c.ListMonthlyCost,
+			PriceOverride:       c.PriceOverride,
+
+			PriceChangeMonthlyCost:    c.PriceChangeMonthlyCost,
+			QuantityChangeMonthlyCost: c.QuantityChangeMonthlyCost,
+			MonthlyCarbon:             c.MonthlyCarbon,
 		})
 	}
 	return comps
@@ -418,7 +582,14 @@ func ToOutputFormat(projects []*schema.Project) (Root, error) {
 		pastTotalMonthlyCost, pastTotalHourlyCost,
 		diffTotalMonthlyCost, diffTotalHourlyCost *decimal.Decimal
 
+	var totalOnDemandMonthlyCost, totalCommitmentSavings *decimal.Decimal
+	var totalListMonthlyCost, totalDiscount *decimal.Decimal
+	var totalMonthlyCarbon, pastTotalMonthlyCarbon, diffTotalMonthlyCarbon *decimal.Decimal
+
 	outProjects := make([]Project, 0, len(projects))
+	costRanges := make([]*CostRange, 0, len(projects))
+	costs := make([]*decimal.Decimal, 0, len(projects))
+	forecasts := make([][]*decimal.Decimal, 0, len(projects))
 	summaries := make([]*Summary, 0, len(projects))
 	fullSummaries := make([]*Summary, 0, len(projects))
 
@@ -441,6 +612,14 @@ func ToOutputFormat(projects []*schema.Project) (Root, error) {
 				}
 				totalMonthlyCost = decimalPtr(totalMonthlyCost.Add(*breakdown.TotalMonthlyCost))
 			}
+
+			totalCommitmentSavings = addCostDifference(totalCommitmentSavings, breakdown.TotalOnDemandMonthlyCost, breakdown.TotalMonthlyCost)
+			totalDiscount = addCostDifference(totalDiscount, breakdown.TotalListMonthlyCost, breakdown.TotalMonthlyCost)
+
+			costRanges = append(costRanges, breakdown.TotalMonthlyCostRange)
+			costs = append(costs, breakdown.TotalMonthlyCost)
+			forecasts = append(forecasts, breakdown.ForecastMonthlyCosts)
+			totalMonthlyCarbon = addOptionalDecimals(totalMonthlyCarbon, breakdown.TotalMonthlyCarbon)
 		}
 
 		if project.HasDiff {
@@ -461,6 +640,8 @@ func ToOutputFormat(projects []*schema.Project) (Root, error) {
 					}
 					pastTotalMonthlyCost = decimalPtr(pastTotalMonthlyCost.Add(*pastBreakdown.TotalMonthlyCost))
 				}
+
+				pastTotalMonthlyCarbon = addOptionalDecimals(pastTotalMonthlyCarbon, pastBreakdown.TotalMonthlyCarbon)
 			}
 
 			if diff != nil {
@@ -477,6 +658,8 @@ func ToOutputFormat(projects []*schema.Project) (Root, error) {
 					}
 					diffTotalMonthlyCost = decimalPtr(diffTotalMonthlyCost.Add(*diff.TotalMonthlyCost))
 				}
+
+				diffTotalMonthlyCarbon = addOptionalDecimals(diffTotalMonthlyCarbon, diff.TotalMonthlyCarbon)
 			}
 		}
 
@@ -487,6 +670,7 @@ func ToOutputFormat(projects []*schema.Project) (Root, error) {
 				"TotalUnsupportedResources",
 				"TotalUsageBasedResources",
 				"TotalNoPriceResources",
+				"TotalPriceUnavailableComponents",
 				"UnsupportedResourceCounts",
 				"NoPriceResourceCounts",
 			},
@@ -513,18 +697,33 @@ func ToOutputFormat(projects []*schema.Project) (Root, error) {
 		})
 	}
 
+	if totalCommitmentSavings != nil && totalMonthlyCost != nil {
+		totalOnDemandMonthlyCost = decimalPtr(totalMonthlyCost.Add(*totalCommitmentSavings))
+	}
+
+	if totalDiscount != nil && totalMonthlyCost != nil {
+		totalListMonthlyCost = decimalPtr(totalMonthlyCost.Add(*totalDiscount))
+	}
+
 	out := Root{
-		Version:              outputVersion,
-		Projects:             outProjects,
-		TotalHourlyCost:      totalHourlyCost,
-		TotalMonthlyCost:     totalMonthlyCost,
-		PastTotalHourlyCost:  pastTotalHourlyCost,
-		PastTotalMonthlyCost: pastTotalMonthlyCost,
-		DiffTotalHourlyCost:  diffTotalHourlyCost,
-		DiffTotalMonthlyCost: diffTotalMonthlyCost,
-		TimeGenerated:        time.Now().UTC(),
-		Summary:              MergeSummaries(summaries),
-		FullSummary:          MergeSummaries(fullSummaries),
+		Version:                  outputVersion,
+		Projects:                 outProjects,
+		TotalHourlyCost:          totalHourlyCost,
+		TotalMonthlyCost:         totalMonthlyCost,
+		PastTotalHourlyCost:      pastTotalHourlyCost,
+		PastTotalMonthlyCost:     pastTotalMonthlyCost,
+		DiffTotalHourlyCost:      diffTotalHourlyCost,
+		DiffTotalMonthlyCost:     diffTotalMonthlyCost,
+		TotalOnDemandMonthlyCost: totalOnDemandMonthlyCost,
+		TotalListMonthlyCost:     totalListMonthlyCost,
+		TotalMonthlyCostRange:    sumCostRanges(costRanges, costs),
+		Forecast:                 forecastMonths(sumForecasts(forecasts, costs)),
+		TotalMonthlyCarbon:       totalMonthlyCarbon,
+		PastTotalMonthlyCarbon:   pastTotalMonthlyCarbon,
+		DiffTotalMonthlyCarbon:   diffTotalMonthlyCarbon,
+		TimeGenerated:            time.Now().UTC(),
+		Summary:                  MergeSummaries(summaries),
+		FullSummary:              MergeSummaries(fullSummaries),
 	}
 
 	return out, nil
@@ -592,6 +791,14 @@ func (r *Root) summaryMessage(showSkipped bool) string {
 		}
 	}
 
+	if r.IsLowerBound() {
+		count := "1 cost component"
+		if *r.Summary.TotalPriceUnavailableComponents > 1 {
+			count = fmt.Sprintf("%d cost components", *r.Summary.TotalPriceUnavailableComponents)
+		}
+		msg += fmt.Sprintf("\n∙ Prices for %s could not be retrieved, costs are a lower bound", count)
+	}
+
 	if r.Summary.TotalUnsupportedResources != nil && *r.Summary.TotalUnsupportedResources > 0 {
 		count := "1 is"
 		if *r.Summary.TotalUnsupportedResources > 1 {
@@ -660,6 +867,7 @@ func BuildSummary(resources []*schema.Resource, opts SummaryOptions) (*Summary,
 	totalUnsupportedResources := 0
 	totalUsageBasedResources := 0
 	totalNoPriceResources := 0
+	totalPriceUnavailableComponents := 0
 
 	estimatedUsageCounts := make(map[string]int)
 	unestimatedUsageCounts := make(map[string]int)
@@ -677,6 +885,7 @@ func BuildSummary(resources []*schema.Resource, opts SummaryOptions) (*Summary,
 		}
 
 		totalDetectedResources++
+		totalPriceUnavailableComponents += countPriceUnavailableComponents(r)
 
 		if r.NoPrice {
 			totalNoPriceResources++
@@ -740,6 +949,10 @@ func BuildSummary(resources []*schema.Resource, opts SummaryOptions) (*Summary,
 	if len(opts.OnlyFields) == 0 || contains(opts.OnlyFields, "TotalNoPriceResources") {
 		s.TotalNoPriceResources = &totalNoPriceResources
 	}
+	// Only set when prices are missing so that complete results are unchanged
+	if totalPriceUnavailableComponents > 0 && (len(opts.OnlyFields) == 0 || contains(opts.OnlyFields, "TotalPriceUnavailableComponents")) {
+		s.TotalPriceUnavailableComponents = &totalPriceUnavailableComponents
+	}
 	if len(opts.OnlyFields) == 0 || contains(opts.OnlyFields, "SupportedResourceCounts") {
 		s.SupportedResourceCounts = &supportedResourceCounts
 	}
@@ -766,6 +979,21 @@ func BuildSummary(resources []*schema.Resource, opts SummaryOptions) (*Summary,
 	return s, nil
 }
 
+func countPriceUnavailableComponents(r *schema.Resource) int {
+	count := 0
+	for _, c := range r.CostComponents {
+		if c.PriceUnavailable() {
+			count++
+		}
+	}
+
+	for _, s := range r.SubResources {
+		count += countPriceUnavailableComponents(s)
+	}
+
+	return count
+}
+
 func MergeSummaries(summaries []*Summary) *Summary {
 	merged := &Summary{}
 
@@ -780,6 +1008,7 @@ func MergeSummaries(summaries []*Summary) *Summary {
 		merged.TotalUnsupportedResources = addIntPtrs(merged.TotalUnsupportedResources, s.TotalUnsupportedResources)
 		merged.TotalUsageBasedResources = addIntPtrs(merged.TotalUsageBasedResources, s.TotalUsageBasedResources)
 		merged.TotalNoPriceResources = addIntPtrs(merged.TotalNoPriceResources, s.TotalNoPriceResources)
+		merged.TotalPriceUnavailableComponents = addIntPtrs(merged.TotalPriceUnavailableComponents, s.TotalPriceUnavailableComponents)
 		merged.SupportedResourceCounts = mergeCounts(merged.SupportedResourceCounts, s.SupportedResourceCounts)
 		merged.UnsupportedResourceCounts = mergeCounts(merged.UnsupportedResourceCounts, s.UnsupportedResourceCounts)
 		merged.NoPriceResourceCounts = mergeCounts(merged.NoPriceResourceCounts, s.NoPriceResourceCounts)
@@ -818,6 +1047,146 @@ func calculateTotalCosts(resources []Resource) (*decimal.Decimal, *decimal.Decim
 	return totalHourlyCost, totalMonthlyCost
 }
 
+// calculateTotalMonthlyCostWith returns the total monthly cost of the
+// resources when the monthly cost of each cost component is replaced by the
+// cost returned by costFunc, e.g. the on-demand cost without any commitments.
+// It returns nil if costFunc returns nil for all the cost components.
+func calculateTotalMonthlyCostWith(resources []Resource, costFunc func(c CostComponent) *decimal.Decimal) *decimal.Decimal {
+	var difference *decimal.Decimal
+
+	var addDifference func(r Resource)
+	addDifference = func(r Resource) {
+		for _, c := range r.CostComponents {
+			difference = addCostDifference(difference, costFunc(c), c.MonthlyCost)
+		}
+
+		for _, s := range r.SubResources {
+			addDifference(s)
+		}
+	}
+
+	for _, r := range resources {
+		addDifference(r)
+	}
+
+	if difference == nil {
+		return nil
+	}
+
+	_, totalMonthlyCost := calculateTotalCosts(resources)
+	return decimalPtr(totalMonthlyCost.Add(*difference))
+}
+
+// calculateTotalPriceChange returns the sum of the price changes of the cost
+// components, or nil if none of them have a price change.
+func calculateTotalPriceChange(resources []Resource) *decimal.Decimal {
+	var total *decimal.Decimal
+
+	var addPriceChange func(r Resource)
+	addPriceChange = func(r Resource) {
+		for _, c := range r.CostComponents {
+			if c.PriceChangeMonthlyCost == nil {
+				continue
+			}
+
+			if total == nil {
+				total = decimalPtr(decimal.Zero)
+			}
+			total = decimalPtr(total.Add(*c.PriceChangeMonthlyCost))
+		}
+
+		for _, s := range r.SubResources {
+			addPriceChange(s)
+		}
+	}
+
+	for _, r := range resources {
+		addPriceChange(r)
+	}
+
+	return total
+}
+
+// addCostDifference adds the difference between cost and monthlyCost to total.
+// The total is left unchanged if either of the costs is nil.
+func addCostDifference(total, cost, monthlyCost *decimal.Decimal) *decimal.Decimal {
+	if cost == nil || monthlyCost == nil {
+		return total
+	}
+
+	if total == nil {
+		total = decimalPtr(decimal.Zero)
+	}
+
+	return decimalPtr(total.Add(cost.Sub(*monthlyCost)))
+}
+
+// columnFields returns the fields that are shown as columns of the breakdown
+// tables, leaving out the dailyCost and annualCost fields which are only
+// shown as totals.
+func columnFields(fields []string) []string {
+	columns := make([]string, 0, len(fields))
+	for _, f := range fields {
+		if f != "dailyCost" && f != "annualCost" {
+			columns = append(columns, f)
+		}
+	}
+
+	return columns
+}
+
+// withPeriodTotals returns a copy of out with the daily and annual totals of
+// the root, its project breakdowns and summaries set for the dailyCost and
+// annualCost fields. A day is 24 hours of the hourly cost and a year is 12
+// months of the monthly cost, so both follow the hours per month that the
+// costs were calculated with.
+func withPeriodTotals(out Root, fields []string) Root {
+	daily := contains(fields, "dailyCost")
+	annual := contains(fields, "annualCost")
+	if !daily && !annual {
+		return out
+	}
+
+	periodTotals := func(hourlyCost, monthlyCost *decimal.Decimal) (*decimal.Decimal, *decimal.Decimal) {
+		var dailyCost, annualCost *decimal.Decimal
+		if daily && hourlyCost != nil {
+			dailyCost = decimalPtr(hourlyCost.Mul(decimal.NewFromInt(24)))
+		}
+		if annual && monthlyCost != nil {
+			annualCost = decimalPtr(monthlyCost.Mul(decimal.NewFromInt(12)))
+		}
+		return dailyCost, annualCost
+	}
+
+	out.TotalDailyCost, out.TotalAnnualCost = periodTotals(out.TotalHourlyCost, out.TotalMonthlyCost)
+
+	if out.Summary != nil {
+		summary := *out.Summary
+		summary.TotalDailyCost, summary.TotalAnnualCost = out.TotalDailyCost, out.TotalAnnualCost
+		out.Summary = &summary
+	}
+
+	projects := make(Projects, len(out.Projects))
+	for i, p := range out.Projects {
+		if p.Breakdown != nil {
+			breakdown := *p.Breakdown
+			breakdown.TotalDailyCost, breakdown.TotalAnnualCost = periodTotals(breakdown.TotalHourlyCost, breakdown.TotalMonthlyCost)
+			p.Breakdown = &breakdown
+
+			if p.Summary != nil {
+				summary := *p.Summary
+				summary.TotalDailyCost, summary.TotalAnnualCost = breakdown.TotalDailyCost, breakdown.TotalAnnualCost
+				p.Summary = &summary
+			}
+		}
+
+		projects[i] = p
+	}
+	out.Projects = projects
+
+	return out
+}
+
 func sortResources(resources []Resource, groupKey string) {
 	sort.Slice(resources, func(i, j int) bool {
 		// If an empty group key is passed just sort by name
@@ -848,6 +1217,20 @@ func decimalPtr(d decimal.Decimal) *decimal.Decimal {
 	return &d
 }
 
+// addOptionalDecimals returns the sum of total and d, treating a nil total as
+// zero. If d is nil total is returned unchanged.
+func addOptionalDecimals(total, d *decimal.Decimal) *decimal.Decimal {
+	if d == nil {
+		return total
+	}
+
+	if total == nil {
+		total = decimalPtr(decimal.Zero)
+	}
+
+	return decimalPtr(total.Add(*d))
+}
+
 func mergeCounts(c1 *map[string]int, c2 *map[string]int) *map[string]int {
 	if c1 == nil && c2 == nil {
 		return nil
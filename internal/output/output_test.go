@@ -1,10 +1,17 @@
 package output
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infracost/infracost/internal/fxrates"
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/infracost/infracost/internal/ui"
 )
 
 func TestCalculateTotalCosts(t *testing.T) {
@@ -32,3 +39,247 @@ func TestCalculateTotalCosts(t *testing.T) {
 	actual, _ = totalMonthlyCost.Float64()
 	assert.Equal(t, expected, actual)
 }
+
+func TestToOutputFormatPriceUnavailable(t *testing.T) {
+	qty := decimal.NewFromInt(730)
+	project := &schema.Project{
+		Name:     "test",
+		Metadata: &schema.ProjectMetadata{},
+		Resources: []*schema.Resource{
+			{
+				Name:         "aws_instance.web",
+				ResourceType: "aws_instance",
+				CostComponents: []*schema.CostComponent{
+					{
+						Name:                   "Instance usage",
+						Unit:                   "hours",
+						UnitMultiplier:         decimal.NewFromInt(1),
+						MonthlyQuantity:        &qty,
+						PriceUnavailableReason: "503 Service Unavailable",
+					},
+				},
+			},
+		},
+	}
+	schema.CalculateCosts(project)
+
+	out, err := ToOutputFormat([]*schema.Project{project})
+	require.NoError(t, err)
+	out.Currency = "USD"
+
+	assert.True(t, out.IsLowerBound())
+	assert.Equal(t, 1, *out.Summary.TotalPriceUnavailableComponents)
+	assert.True(t, out.Projects[0].Breakdown.Resources[0].CostComponents[0].PriceUnavailable)
+
+	b, err := ToTable(out, Options{Fields: []string{"monthlyQuantity", "unit", "monthlyCost"}})
+	require.NoError(t, err)
+	assert.Contains(t, string(b), "≥ $0.00")
+	assert.Contains(t, string(b), "Prices for 1 cost component could not be retrieved, costs are a lower bound")
+}
+
+func TestCombineConvertsCurrency(t *testing.T) {
+	p := filepath.Join(t.TempDir(), "rates.yml")
+	require.NoError(t, os.WriteFile(p, []byte("effective_date: 2023-06-30\nrates:\n  EUR: 0.5\n"), 0600))
+	rates, err := fxrates.Load(p)
+	require.NoError(t, err)
+
+	newRoot := func(name, currency string) Root {
+		qty := decimal.NewFromInt(730)
+		c := &schema.CostComponent{
+			Name:            "Instance usage",
+			Unit:            "hours",
+			UnitMultiplier:  decimal.NewFromInt(1),
+			MonthlyQuantity: &qty,
+		}
+		c.SetPrice(decimal.NewFromInt(1))
+
+		project := &schema.Project{
+			Name:     name,
+			Metadata: &schema.ProjectMetadata{},
+			Resources: []*schema.Resource{
+				{Name: "aws_instance.web", ResourceType: "aws_instance", CostComponents: []*schema.CostComponent{c}},
+			},
+		}
+		schema.CalculateCosts(project)
+
+		out, err := ToOutputFormat([]*schema.Project{project})
+		require.NoError(t, err)
+		out.Currency = currency
+		return out
+	}
+
+	inputs := []ReportInput{{Root: newRoot("eur", "EUR")}, {Root: newRoot("usd", "USD")}}
+
+	_, err = Combine(inputs, nil)
+	assert.ErrorContains(t, err, "currency mismatch")
+
+	combined, err := Combine(inputs, rates)
+	require.NoError(t, err)
+
+	assert.Equal(t, "EUR", combined.Currency)
+	assert.Equal(t, "1095", combined.TotalMonthlyCost.String())
+	assert.Equal(t, "0.5", combined.Projects[1].Breakdown.Resources[0].CostComponents[0].Price.String())
+	assert.Equal(t, p, combined.Metadata.FXRatesSource)
+	assert.Equal(t, "2023-06-30", combined.Metadata.FXRatesEffectiveDate)
+
+	// The input isn't modified by the conversion
+	assert.Equal(t, "730", inputs[1].Root.TotalMonthlyCost.String())
+
+	_, err = CompareTo(newRoot("eur", "EUR"), newRoot("eur", "USD"), nil)
+	assert.ErrorContains(t, err, "currency mismatch")
+
+	compared, err := CompareTo(newRoot("eur", "EUR"), newRoot("eur", "USD"), rates)
+	require.NoError(t, err)
+	assert.Equal(t, "365", compared.PastTotalMonthlyCost.String())
+	assert.Equal(t, "365", compared.DiffTotalMonthlyCost.String())
+}
+
+func TestCompareToPriceChange(t *testing.T) {
+	newRoot := func(quantity, price int64) Root {
+		qty := decimal.NewFromInt(quantity)
+		c := &schema.CostComponent{
+			Name:            "Instance usage",
+			Unit:            "hours",
+			UnitMultiplier:  decimal.NewFromInt(1),
+			MonthlyQuantity: &qty,
+		}
+		c.SetPrice(decimal.NewFromInt(price))
+
+		project := &schema.Project{
+			Name:     "test",
+			Metadata: &schema.ProjectMetadata{},
+			Resources: []*schema.Resource{
+				{Name: "aws_instance.web", ResourceType: "aws_instance", CostComponents: []*schema.CostComponent{c}},
+			},
+		}
+		schema.CalculateCosts(project)
+
+		out, err := ToOutputFormat([]*schema.Project{project})
+		require.NoError(t, err)
+		return out
+	}
+
+	compared, err := CompareTo(newRoot(1460, 2), newRoot(730, 1), nil)
+	require.NoError(t, err)
+	compared.Currency = "USD"
+
+	diff := compared.Projects[0].Diff
+	assert.Equal(t, "2190", diff.TotalMonthlyCost.String())
+	assert.Equal(t, "730", diff.TotalPriceChangeMonthlyCost.String())
+	assert.Equal(t, "1460", diff.TotalQuantityChangeMonthlyCost.String())
+
+	c := diff.Resources[0].CostComponents[0]
+	assert.Equal(t, "730", c.PriceChangeMonthlyCost.String())
+	assert.Equal(t, "1460", c.QuantityChangeMonthlyCost.String())
+
+	out, err := ToDiff(compared, Options{})
+	require.NoError(t, err)
+	assert.Contains(t, ui.StripColor(string(out)), "+$730 from price change, +$1,460 from quantity change")
+	assert.Contains(t, ui.StripColor(string(out)), "From price changes:    +$730\nFrom quantity changes: +$1,460")
+
+	// Quantity only changes aren't split
+	compared, err = CompareTo(newRoot(1460, 1), newRoot(730, 1), nil)
+	require.NoError(t, err)
+	assert.Nil(t, compared.Projects[0].Diff.TotalPriceChangeMonthlyCost)
+	assert.Nil(t, compared.Projects[0].Diff.Resources[0].CostComponents[0].PriceChangeMonthlyCost)
+}
+
+func TestCompareToMovedResource(t *testing.T) {
+	newRoot := func(name, movedFrom string, quantity int64) Root {
+		qty := decimal.NewFromInt(quantity)
+		c := &schema.CostComponent{
+			Name:            "Instance usage",
+			Unit:            "hours",
+			UnitMultiplier:  decimal.NewFromInt(1),
+			MonthlyQuantity: &qty,
+		}
+		c.SetPrice(decimal.NewFromInt(1))
+
+		project := &schema.Project{
+			Name:     "test",
+			Metadata: &schema.ProjectMetadata{},
+			Resources: []*schema.Resource{
+				{Name: name, MovedFrom: movedFrom, ResourceType: "aws_instance", CostComponents: []*schema.CostComponent{c}},
+			},
+		}
+		schema.CalculateCosts(project)
+
+		out, err := ToOutputFormat([]*schema.Project{project})
+		require.NoError(t, err)
+		return out
+	}
+
+	prior := newRoot("aws_instance.web", "", 730)
+
+	compared, err := CompareTo(newRoot("module.app.aws_instance.web", "aws_instance.web", 1460), prior, nil)
+	require.NoError(t, err)
+	compared.Currency = "USD"
+
+	diff := compared.Projects[0].Diff
+	require.Len(t, diff.Resources, 1)
+	assert.Equal(t, "aws_instance.web", diff.Resources[0].MovedFrom)
+	assert.Equal(t, "730", diff.TotalMonthlyCost.String())
+
+	out, err := ToDiff(compared, Options{})
+	require.NoError(t, err)
+	assert.Contains(t, ui.StripColor(string(out)), "~ module.app.aws_instance.web (moved from aws_instance.web)\n  +$730 ($730 → $1,460)")
+
+	// Moves without a cost change aren't in the diff
+	compared, err = CompareTo(newRoot("module.app.aws_instance.web", "aws_instance.web", 730), prior, nil)
+	require.NoError(t, err)
+	assert.Empty(t, compared.Projects[0].Diff.Resources)
+}
+
+func TestPeriodTotals(t *testing.T) {
+	defer func(m decimal.Decimal) { schema.HourToMonthUnitMultiplier = m }(schema.HourToMonthUnitMultiplier)
+	schema.HourToMonthUnitMultiplier = decimal.NewFromInt(720)
+
+	qty := decimal.NewFromInt(1)
+	c := &schema.CostComponent{
+		Name:           "Instance usage",
+		Unit:           "hours",
+		UnitMultiplier: decimal.NewFromInt(1),
+		HourlyQuantity: &qty,
+	}
+	c.SetPrice(decimal.NewFromInt(2))
+
+	project := &schema.Project{
+		Name:     "test",
+		Metadata: &schema.ProjectMetadata{},
+		Resources: []*schema.Resource{
+			{Name: "aws_instance.web", ResourceType: "aws_instance", CostComponents: []*schema.CostComponent{c}},
+		},
+	}
+	schema.CalculateCosts(project)
+
+	out, err := ToOutputFormat([]*schema.Project{project})
+	require.NoError(t, err)
+	out.Currency = "USD"
+
+	assert.Equal(t, "1440", out.TotalMonthlyCost.String())
+
+	// The totals are only added if the fields are requested
+	withTotals := withPeriodTotals(out, []string{"monthlyCost"})
+	assert.Nil(t, withTotals.TotalDailyCost)
+	assert.Nil(t, withTotals.TotalAnnualCost)
+
+	withTotals = withPeriodTotals(out, []string{"monthlyCost", "dailyCost", "annualCost"})
+	assert.Equal(t, "48", withTotals.TotalDailyCost.String())
+	assert.Equal(t, "17280", withTotals.TotalAnnualCost.String())
+	assert.Equal(t, "48", withTotals.Projects[0].Breakdown.TotalDailyCost.String())
+	assert.Equal(t, "17280", withTotals.Summary.TotalAnnualCost.String())
+
+	// The input isn't modified
+	assert.Nil(t, out.Projects[0].Breakdown.TotalDailyCost)
+	assert.Nil(t, out.Summary.TotalAnnualCost)
+
+	b, err := ToTable(out, Options{Fields: []string{"monthlyQuantity", "unit", "monthlyCost", "annualCost"}})
+	require.NoError(t, err)
+	assert.Regexp(t, `OVERALL ANNUAL TOTAL\s+\$17,280\.00`, ui.StripColor(string(b)))
+	assert.NotContains(t, ui.StripColor(string(b)), "OVERALL DAILY TOTAL")
+
+	b, err = ToJSON(out, Options{Fields: []string{"dailyCost"}})
+	require.NoError(t, err)
+	assert.Contains(t, string(b), `"totalDailyCost":"48"`)
+	assert.NotContains(t, string(b), "totalAnnualCost")
+}
@@ -0,0 +1,239 @@
+package output
+
+import (
+	"fmt"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/jedib0t/go-pretty/v6/text"
+
+	"github.com/infracost/infracost/internal/ui"
+)
+
+// RegionBreakdown is the output of the same projects priced in a region.
+type RegionBreakdown struct {
+	Region string
+	Root   Root
+	// NotCompared are the addresses of the resources of each project that
+	// can't be priced in the region, e.g. because they belong to a different
+	// provider than the region. They are keyed by the project label with
+	// metadata and aren't included in the totals of the region.
+	NotCompared map[string][]string
+}
+
+// ToRegionMatrix renders a table for each project with a row for each
+// resource and a column for each region, followed by the totals of the
+// regions. Resource costs that include cost components with no price in the
+// region are marked with a * and the cost components are listed after the
+// tables. Resources that aren't compared in a region are marked as such.
+func ToRegionMatrix(breakdowns []RegionBreakdown) ([]byte, error) {
+	if len(breakdowns) == 0 {
+		return []byte{}, nil
+	}
+
+	currency := breakdowns[0].Root.Currency
+
+	s := ""
+	notFound := make([]string, 0)
+	hasNotCompared := false
+
+	for i, project := range breakdowns[0].Root.Projects {
+		if i != 0 {
+			s += "──────────────────────────────────\n"
+		}
+
+		s += fmt.Sprintf("%s %s\n\n",
+			ui.BoldString("Project:"),
+			project.Label(),
+		)
+
+		label := project.LabelWithMetadata()
+
+		regionProjects := make([]*Project, len(breakdowns))
+		notCompared := make([]map[string]bool, len(breakdowns))
+		for j, b := range breakdowns {
+			regionProjects[j] = findProjectByLabel(b.Root.Projects, label)
+
+			notCompared[j] = make(map[string]bool)
+			for _, addr := range b.NotCompared[label] {
+				notCompared[j][addr] = true
+			}
+		}
+
+		t := newRegionTable(breakdowns)
+
+		for _, name := range regionResourceNames(regionProjects, breakdowns, label) {
+			row := table.Row{ui.BoldString(name)}
+
+			for j, p := range regionProjects {
+				if notCompared[j][name] {
+					hasNotCompared = true
+					row = append(row, ui.FaintString("not compared"))
+					continue
+				}
+
+				var r *Resource
+				if p != nil && p.Breakdown != nil {
+					r = findResourceByName(p.Breakdown.Resources, name)
+				}
+
+				if r == nil {
+					row = append(row, "-")
+					continue
+				}
+
+				cell := FormatCost2DP(currency, r.MonthlyCost)
+
+				components := priceNotFoundComponents(*r, r.Name)
+				if len(components) > 0 {
+					cell += " *"
+
+					for _, c := range components {
+						notFound = append(notFound, fmt.Sprintf("%s: %s", breakdowns[j].Region, c))
+					}
+				}
+
+				row = append(row, cell)
+			}
+
+			t.AppendRow(row)
+		}
+
+		totalRow := table.Row{ui.BoldString(formatTitleWithCurrency("Project total", currency))}
+		for _, p := range regionProjects {
+			if p == nil || p.Breakdown == nil {
+				totalRow = append(totalRow, "-")
+				continue
+			}
+
+			totalRow = append(totalRow, FormatCost2DP(currency, p.Breakdown.TotalMonthlyCost))
+		}
+
+		t.AppendRow(table.Row{""})
+		t.AppendRow(totalRow)
+
+		s += t.Render()
+		s += "\n\n"
+	}
+
+	if len(breakdowns[0].Root.Projects) > 1 {
+		s += "──────────────────────────────────\n"
+
+		t := newRegionTable(breakdowns)
+
+		totalRow := table.Row{ui.BoldString(formatTitleWithCurrency("OVERALL TOTAL", currency))}
+		for _, b := range breakdowns {
+			totalRow = append(totalRow, FormatCost2DP(currency, b.Root.TotalMonthlyCost))
+		}
+		t.AppendRow(totalRow)
+
+		s += t.Render()
+		s += "\n\n"
+	}
+
+	if hasNotCompared {
+		s += "──────────────────────────────────\n"
+		s += "Resources that are not compared can't be priced in the region, e.g. because they belong to\n"
+		s += "a different provider, so they aren't included in the totals of the region.\n"
+	}
+
+	if len(notFound) > 0 {
+		s += "──────────────────────────────────\n"
+		s += "* These cost components have no price in the region so they are priced at zero:\n"
+		for _, n := range notFound {
+			s += fmt.Sprintf("  %s\n", n)
+		}
+	}
+
+	return []byte(s), nil
+}
+
+func newRegionTable(breakdowns []RegionBreakdown) table.Writer {
+	t := table.NewWriter()
+	t.Style().Options.DrawBorder = false
+	t.Style().Options.SeparateColumns = false
+	t.Style().Options.SeparateRows = false
+	t.Style().Options.SeparateHeader = false
+	t.Style().Format.Header = text.FormatDefault
+
+	headers := table.Row{ui.UnderlineString("Name")}
+	columns := []table.ColumnConfig{
+		{Number: 1, Align: text.AlignLeft, AlignHeader: text.AlignLeft},
+	}
+
+	for i, b := range breakdowns {
+		headers = append(headers, ui.UnderlineString(b.Region))
+		columns = append(columns, table.ColumnConfig{
+			Number:      i + 2,
+			Align:       text.AlignRight,
+			AlignHeader: text.AlignRight,
+		})
+	}
+
+	t.SetColumnConfigs(columns)
+	t.AppendHeader(headers)
+	t.AppendRow(table.Row{""})
+
+	return t
+}
+
+// regionResourceNames returns the names of the resources of the projects,
+// followed by the resources of the project with the given label that aren't
+// compared in some of the regions, in the order they are first found.
+func regionResourceNames(projects []*Project, breakdowns []RegionBreakdown, label string) []string {
+	names := make([]string, 0)
+	seen := make(map[string]bool)
+
+	add := func(name string) {
+		if !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+
+	for _, p := range projects {
+		if p == nil || p.Breakdown == nil {
+			continue
+		}
+
+		for _, r := range p.Breakdown.Resources {
+			add(r.Name)
+		}
+	}
+
+	for _, b := range breakdowns {
+		for _, addr := range b.NotCompared[label] {
+			add(addr)
+		}
+	}
+
+	return names
+}
+
+// priceNotFoundComponents returns the names of the cost components of the
+// resource and its sub resources that have no price, either because the pricing
+// API has no price for them or because it couldn't be retrieved.
+func priceNotFoundComponents(r Resource, name string) []string {
+	components := make([]string, 0)
+
+	for _, c := range r.CostComponents {
+		if c.PriceNotFound || c.PriceUnavailable {
+			components = append(components, fmt.Sprintf("%s %s", name, c.Name))
+		}
+	}
+
+	for _, sub := range r.SubResources {
+		components = append(components, priceNotFoundComponents(sub, fmt.Sprintf("%s.%s", name, sub.Name))...)
+	}
+
+	return components
+}
+
+func findProjectByLabel(projects []Project, label string) *Project {
+	for _, p := range projects {
+		if p.LabelWithMetadata() == label {
+			return &p
+		}
+	}
+
+	return nil
+}
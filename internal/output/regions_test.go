@@ -0,0 +1,91 @@
+package output
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/infracost/infracost/internal/ui"
+)
+
+func TestToRegionMatrix(t *testing.T) {
+	newRoot := func(price int64, notFound bool) Root {
+		qty := decimal.NewFromInt(730)
+		c := &schema.CostComponent{
+			Name:            "Instance usage",
+			Unit:            "hours",
+			UnitMultiplier:  decimal.NewFromInt(1),
+			MonthlyQuantity: &qty,
+			PriceNotFound:   notFound,
+		}
+		c.SetPrice(decimal.NewFromInt(price))
+
+		project := &schema.Project{
+			Name:     "test",
+			Metadata: &schema.ProjectMetadata{},
+			Resources: []*schema.Resource{
+				{Name: "aws_instance.web", ResourceType: "aws_instance", CostComponents: []*schema.CostComponent{c}},
+			},
+		}
+		schema.CalculateCosts(project)
+
+		out, err := ToOutputFormat([]*schema.Project{project})
+		require.NoError(t, err)
+		out.Currency = "USD"
+		return out
+	}
+
+	b, err := ToRegionMatrix([]RegionBreakdown{
+		{Region: "us-east-1", Root: newRoot(1, false)},
+		{Region: "eu-west-1", Root: newRoot(2, false)},
+		{Region: "me-central-1", Root: newRoot(0, true)},
+	})
+	require.NoError(t, err)
+
+	out := ui.StripColor(string(b))
+	assert.Contains(t, out, "Project: test")
+	assert.Regexp(t, `Name\s+us-east-1\s+eu-west-1\s+me-central-1`, out)
+	assert.Regexp(t, `aws_instance\.web\s+\$730\.00\s+\$1,460\.00\s+\$0\.00 \*`, out)
+	assert.Regexp(t, `Project total\s+\$730\.00\s+\$1,460\.00\s+\$0\.00`, out)
+	assert.Contains(t, out, "  me-central-1: aws_instance.web Instance usage\n")
+}
+
+func TestToRegionMatrixNotCompared(t *testing.T) {
+	newRoot := func(name string, price int64) Root {
+		qty := decimal.NewFromInt(730)
+		c := &schema.CostComponent{
+			Name:            "Instance usage",
+			Unit:            "hours",
+			UnitMultiplier:  decimal.NewFromInt(1),
+			MonthlyQuantity: &qty,
+		}
+		c.SetPrice(decimal.NewFromInt(price))
+
+		project := &schema.Project{
+			Name:      "test",
+			Metadata:  &schema.ProjectMetadata{},
+			Resources: []*schema.Resource{{Name: name, CostComponents: []*schema.CostComponent{c}}},
+		}
+		schema.CalculateCosts(project)
+
+		out, err := ToOutputFormat([]*schema.Project{project})
+		require.NoError(t, err)
+		out.Currency = "USD"
+		return out
+	}
+
+	b, err := ToRegionMatrix([]RegionBreakdown{
+		{Region: "us-east-1", Root: newRoot("aws_instance.web", 1), NotCompared: map[string][]string{"test": {"google_compute_instance.app"}}},
+		{Region: "europe-west1", Root: newRoot("google_compute_instance.app", 2), NotCompared: map[string][]string{"test": {"aws_instance.web"}}},
+	})
+	require.NoError(t, err)
+
+	out := ui.StripColor(string(b))
+	assert.Regexp(t, `aws_instance\.web\s+\$730\.00\s+not compared`, out)
+	assert.Regexp(t, `google_compute_instance\.app\s+not compared\s+\$1,460\.00`, out)
+	assert.Regexp(t, `Project total\s+\$730\.00\s+\$1,460\.00`, out)
+	assert.Contains(t, out, "Resources that are not compared can't be priced in the region")
+}
@@ -0,0 +1,110 @@
+package output
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+// ReplacedResource is a resource that the plan of a project replaces. It is
+// called out in the markdown comment so reviewers notice the replacement.
+type ReplacedResource struct {
+	Project     string
+	Name        string
+	Replacement string
+	OverlapCost *decimal.Decimal
+}
+
+// WithReplacementOverlapCosts estimates the one-off cost of running both
+// copies of the resources that are replaced with create_before_destroy for
+// the overlap duration. The overlap cost of a resource is the hourly cost of
+// its past copy for each hour of the overlap, and is added to the diff
+// resources and totalled on the diff breakdowns and the root.
+func WithReplacementOverlapCosts(out Root, overlap time.Duration) Root {
+	if overlap <= 0 {
+		return out
+	}
+
+	hours := decimal.NewFromFloat(overlap.Hours())
+
+	var total *decimal.Decimal
+	for _, p := range out.Projects {
+		if p.Diff == nil || p.PastBreakdown == nil {
+			continue
+		}
+
+		pastHourlyCosts := make(map[string]*decimal.Decimal, len(p.PastBreakdown.Resources))
+		for _, r := range p.PastBreakdown.Resources {
+			pastHourlyCosts[r.Name] = r.HourlyCost
+		}
+
+		var projectTotal *decimal.Decimal
+		for i, r := range p.Diff.Resources {
+			if r.Replacement != schema.ReplacementCreateBeforeDestroy {
+				continue
+			}
+
+			pastName := r.Name
+			if r.MovedFrom != "" {
+				pastName = r.MovedFrom
+			}
+
+			hourlyCost := pastHourlyCosts[pastName]
+			if hourlyCost == nil {
+				continue
+			}
+
+			overlapCost := hourlyCost.Mul(hours)
+			p.Diff.Resources[i].OverlapCost = &overlapCost
+			projectTotal = addOptionalDecimals(projectTotal, &overlapCost)
+		}
+
+		p.Diff.TotalOverlapCost = projectTotal
+		total = addOptionalDecimals(total, projectTotal)
+	}
+
+	out.TotalOverlapCost = total
+
+	return out
+}
+
+// replacedResources returns the resources that are replaced in the diffs of
+// the projects.
+func replacedResources(out Root) []ReplacedResource {
+	var replaced []ReplacedResource
+
+	for _, p := range out.Projects {
+		if p.Diff == nil {
+			continue
+		}
+
+		for _, r := range p.Diff.Resources {
+			if r.Replacement == "" {
+				continue
+			}
+
+			replaced = append(replaced, ReplacedResource{
+				Project:     p.Name,
+				Name:        r.Name,
+				Replacement: r.Replacement,
+				OverlapCost: r.OverlapCost,
+			})
+		}
+	}
+
+	return replaced
+}
+
+// replacementLabel returns the human readable label of a replacement.
+func replacementLabel(replacement string) string {
+	switch replacement {
+	case schema.ReplacementCreateBeforeDestroy:
+		return "create before destroy"
+	case schema.ReplacementDestroyBeforeCreate:
+		return "destroy before create"
+	}
+
+	return replacement
+}
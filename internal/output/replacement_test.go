@@ -0,0 +1,77 @@
+package output
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/infracost/infracost/internal/ui"
+)
+
+func TestReplacementOverlapCosts(t *testing.T) {
+	newResource := func(name, replacement string, price int64) *schema.Resource {
+		qty := decimal.NewFromInt(730)
+		c := &schema.CostComponent{
+			Name:            "Instance usage",
+			Unit:            "hours",
+			UnitMultiplier:  decimal.NewFromInt(1),
+			HourlyQuantity:  decimalPtr(decimal.NewFromInt(1)),
+			MonthlyQuantity: &qty,
+		}
+		c.SetPrice(decimal.NewFromInt(price))
+
+		return &schema.Resource{Name: name, Replacement: replacement, CostComponents: []*schema.CostComponent{c}}
+	}
+
+	project := &schema.Project{
+		Name:     "app",
+		Metadata: &schema.ProjectMetadata{},
+		HasDiff:  true,
+		PastResources: []*schema.Resource{
+			newResource("aws_db_instance.db", "", 2),
+			newResource("aws_instance.web", "", 1),
+		},
+		Resources: []*schema.Resource{
+			newResource("aws_db_instance.db", schema.ReplacementCreateBeforeDestroy, 2),
+			newResource("aws_instance.web", schema.ReplacementDestroyBeforeCreate, 1),
+		},
+	}
+	schema.CalculateCosts(project)
+	project.CalculateDiff()
+
+	out, err := ToOutputFormat([]*schema.Project{project})
+	require.NoError(t, err)
+	out.Currency = "USD"
+
+	out = WithReplacementOverlapCosts(out, 3*time.Hour)
+
+	diff := out.Projects[0].Diff
+	require.Len(t, diff.Resources, 2)
+	assert.Equal(t, schema.ReplacementCreateBeforeDestroy, diff.Resources[0].Replacement)
+	assert.Equal(t, "6", diff.Resources[0].OverlapCost.String())
+	assert.Equal(t, schema.ReplacementDestroyBeforeCreate, diff.Resources[1].Replacement)
+	assert.Nil(t, diff.Resources[1].OverlapCost)
+	assert.Equal(t, "6", diff.TotalOverlapCost.String())
+	assert.Equal(t, "6", out.TotalOverlapCost.String())
+
+	b, err := ToDiff(out, Options{})
+	require.NoError(t, err)
+	d := ui.StripColor(string(b))
+	assert.Contains(t, d, "~ aws_db_instance.db (replaced, create before destroy)")
+	assert.Contains(t, d, "  $6.00 one-off overlap cost while both copies run")
+	assert.Contains(t, d, "~ aws_instance.web (replaced, destroy before create)")
+	assert.Contains(t, d, "One-off overlap cost of replacements: $6.00")
+
+	b, err = ToMarkdown(out, Options{}, MarkdownOptions{})
+	require.NoError(t, err)
+	assert.Contains(t, string(b), "🔁 **2 resources are replaced**, running both copies while they are replaced is estimated to cost **$6.00** one-off:")
+	assert.Contains(t, string(b), "- `aws_db_instance.db` in app (create before destroy), $6.00 overlap cost")
+	assert.Contains(t, string(b), "- `aws_instance.web` in app (destroy before create)\n")
+
+	unchanged := WithReplacementOverlapCosts(out, 0)
+	assert.Equal(t, out, unchanged)
+}
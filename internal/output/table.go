@@ -6,6 +6,7 @@ import (
 
 	"github.com/jedib0t/go-pretty/v6/table"
 	"github.com/jedib0t/go-pretty/v6/text"
+	"github.com/shopspring/decimal"
 
 	"github.com/infracost/infracost/internal/ui"
 
@@ -15,6 +16,9 @@ import (
 func ToTable(out Root, opts Options) ([]byte, error) {
 	var tableLen int
 
+	out = withPeriodTotals(out, opts.Fields)
+	out = withGroups(out, opts.GroupBy)
+
 	s := ""
 
 	// Don't show the project total if there's only one project result
@@ -72,6 +76,9 @@ func ToTable(out Root, opts Options) ([]byte, error) {
 	}
 
 	totalOut := FormatCost2DP(out.Currency, out.TotalMonthlyCost)
+	if out.IsLowerBound() {
+		totalOut = "≥ " + totalOut
+	}
 
 	overallTitle := formatTitleWithCurrency(" OVERALL TOTAL", out.Currency)
 	s += fmt.Sprintf("%s%s",
@@ -79,6 +86,83 @@ func ToTable(out Root, opts Options) ([]byte, error) {
 		fmt.Sprintf("%*s ", tableLen-(len(overallTitle)+1), totalOut), // pad based on the last line length
 	)
 
+	if out.TotalDailyCost != nil {
+		dailyOut := FormatCost2DP(out.Currency, out.TotalDailyCost)
+		dailyTitle := formatTitleWithCurrency(" OVERALL DAILY TOTAL", out.Currency)
+		s += fmt.Sprintf("\n%s%s",
+			ui.BoldString(dailyTitle),
+			fmt.Sprintf("%*s ", tableLen-(len(dailyTitle)+1), dailyOut),
+		)
+	}
+
+	if out.TotalAnnualCost != nil {
+		annualOut := FormatCost2DP(out.Currency, out.TotalAnnualCost)
+		annualTitle := formatTitleWithCurrency(" OVERALL ANNUAL TOTAL", out.Currency)
+		s += fmt.Sprintf("\n%s%s",
+			ui.BoldString(annualTitle),
+			fmt.Sprintf("%*s ", tableLen-(len(annualTitle)+1), annualOut),
+		)
+	}
+
+	if out.TotalMonthlyCarbon != nil {
+		carbonOut := formatCarbon(out.TotalMonthlyCarbon)
+		carbonTitle := " OVERALL MONTHLY CARBON"
+		s += fmt.Sprintf("\n%s%s",
+			ui.BoldString(carbonTitle),
+			fmt.Sprintf("%*s ", tableLen-(len(carbonTitle)+1), carbonOut),
+		)
+	}
+
+	if out.TotalOnDemandMonthlyCost != nil {
+		onDemandOut := FormatCost2DP(out.Currency, out.TotalOnDemandMonthlyCost)
+		onDemandTitle := formatTitleWithCurrency(" OVERALL ON-DEMAND TOTAL", out.Currency)
+		s += fmt.Sprintf("\n%s%s",
+			ui.BoldString(onDemandTitle),
+			fmt.Sprintf("%*s ", tableLen-(len(onDemandTitle)+1), onDemandOut),
+		)
+	}
+
+	if out.TotalListMonthlyCost != nil && out.TotalMonthlyCost != nil {
+		listOut := FormatCost2DP(out.Currency, out.TotalListMonthlyCost)
+		listTitle := formatTitleWithCurrency(" OVERALL LIST TOTAL", out.Currency)
+		discountOut := FormatCost2DP(out.Currency, decimalPtr(out.TotalListMonthlyCost.Sub(*out.TotalMonthlyCost)))
+		discountTitle := formatTitleWithCurrency(" OVERALL DISCOUNT", out.Currency)
+		s += fmt.Sprintf("\n%s%s\n%s%s",
+			ui.BoldString(listTitle),
+			fmt.Sprintf("%*s ", tableLen-(len(listTitle)+1), listOut),
+			ui.BoldString(discountTitle),
+			fmt.Sprintf("%*s ", tableLen-(len(discountTitle)+1), discountOut),
+		)
+	}
+
+	if out.TotalMonthlyCostRange != nil {
+		rangeOut := fmt.Sprintf("%s - %s", FormatCost2DP(out.Currency, out.TotalMonthlyCostRange.Low), FormatCost2DP(out.Currency, out.TotalMonthlyCostRange.High))
+		rangeTitle := formatTitleWithCurrency(" OVERALL TOTAL RANGE", out.Currency)
+		s += fmt.Sprintf("\n%s%s",
+			ui.BoldString(rangeTitle),
+			fmt.Sprintf("%*s ", tableLen-(len(rangeTitle)+1), rangeOut),
+		)
+
+		s += "\n──────────────────────────────────\n"
+		s += tableForUsageScenarios(out)
+	}
+
+	if len(out.Forecast) > 0 {
+		s += "\n──────────────────────────────────\n"
+		s += tableForForecast(out)
+	}
+
+	for _, key := range out.GroupKeys() {
+		s += "\n──────────────────────────────────\n"
+		s += tableForGroups(out.Currency, key, out.GroupsWithKey(key))
+	}
+
+	if out.TagPolicy.HasFailed() {
+		s += "\n──────────────────────────────────\n"
+		s += fmt.Sprintf("%s %s\n\n", ui.BoldString("Tag policy failed:"), tagPolicySummary(out.TagPolicy))
+		s += tableForTagPolicy(out.TagPolicy)
+	}
+
 	summaryMsg := out.summaryMessage(opts.ShowSkipped)
 
 	if summaryMsg != "" {
@@ -156,6 +240,16 @@ func tableForBreakdown(currency string, breakdown Breakdown, fields []string, in
 		})
 		i++
 	}
+	showCarbon := contains(fields, "monthlyCarbon")
+	if showCarbon {
+		headers = append(headers, ui.UnderlineString("Monthly kgCO2e"))
+		columns = append(columns, table.ColumnConfig{
+			Number:      i,
+			Align:       text.AlignRight,
+			AlignHeader: text.AlignRight,
+		})
+		i++
+	}
 
 	t.AppendRow(table.Row{""})
 
@@ -183,11 +277,38 @@ func tableForBreakdown(currency string, breakdown Breakdown, fields []string, in
 		var totalCostRow table.Row
 		totalCostRow = append(totalCostRow, ui.BoldString(formatTitleWithCurrency("Project total", currency)))
 		numOfFields := i - 3
+		if showCarbon {
+			numOfFields--
+		}
 		for q := 0; q < numOfFields; q++ {
 			totalCostRow = append(totalCostRow, "")
 		}
 		totalCostRow = append(totalCostRow, FormatCost2DP(currency, breakdown.TotalMonthlyCost))
+		if showCarbon {
+			totalCostRow = append(totalCostRow, formatQuantity(breakdown.TotalMonthlyCarbon))
+		}
 		t.AppendRow(totalCostRow)
+
+		periodTotals := []struct {
+			title string
+			cost  *decimal.Decimal
+		}{
+			{"Project daily total", breakdown.TotalDailyCost},
+			{"Project annual total", breakdown.TotalAnnualCost},
+		}
+
+		for _, pt := range periodTotals {
+			if pt.cost == nil {
+				continue
+			}
+
+			row := table.Row{ui.BoldString(formatTitleWithCurrency(pt.title, currency))}
+			for q := 0; q < numOfFields; q++ {
+				row = append(row, "")
+			}
+			row = append(row, FormatCost2DP(currency, pt.cost))
+			t.AppendRow(row)
+		}
 	}
 
 	return t.Render()
@@ -253,6 +374,14 @@ func buildCostComponentRows(t table.Writer, currency string, costComponents []Co
 					if contains(fields, "monthlyCost") {
 						tableRow = append(tableRow, FormatCost2DP(currency, c.TierData[index].MonthlyCost))
 					}
+					// The carbon isn't split by tier, so it is shown on the first tier
+					if contains(fields, "monthlyCarbon") {
+						carbon := ""
+						if index == 0 {
+							carbon = formatQuantity(c.MonthlyCarbon)
+						}
+						tableRow = append(tableRow, carbon)
+					}
 
 					t.AppendRow(tableRow)
 				}
@@ -289,9 +418,37 @@ func buildCostComponentRows(t table.Writer, currency string, costComponents []Co
 			if contains(fields, "monthlyCost") {
 				tableRow = append(tableRow, FormatCost2DP(currency, c.MonthlyCost))
 			}
+			if contains(fields, "monthlyCarbon") {
+				tableRow = append(tableRow, formatQuantity(c.MonthlyCarbon))
+			}
 
 			t.AppendRow(tableRow)
 		}
+
+		notes := make([]string, 0)
+		if c.PriceOverride != "" {
+			notes = append(notes, fmt.Sprintf("Price overridden by %s", c.PriceOverride))
+		}
+		if c.Commitment != "" && c.OnDemandMonthlyCost != nil {
+			notes = append(notes, fmt.Sprintf("Covered by %s, on-demand cost %s", c.Commitment, FormatCost2DP(currency, c.OnDemandMonthlyCost)))
+		}
+		if c.DiscountRule != "" && c.ListMonthlyCost != nil && c.MonthlyCost != nil {
+			notes = append(notes, fmt.Sprintf(
+				"Discounted by %s, list cost %s, discount %s",
+				c.DiscountRule,
+				FormatCost2DP(currency, c.ListMonthlyCost),
+				FormatCost2DP(currency, decimalPtr(c.ListMonthlyCost.Sub(*c.MonthlyCost))),
+			))
+		}
+
+		notePrefix := prefix + "│ "
+		if !hasSubResources && i == len(costComponents)-1 {
+			notePrefix = prefix + "  "
+		}
+
+		for _, note := range notes {
+			t.AppendRow(table.Row{fmt.Sprintf("%s %s", ui.FaintString(notePrefix), ui.FaintString(note))})
+		}
 	}
 }
 
@@ -0,0 +1,152 @@
+package output
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/jedib0t/go-pretty/v6/text"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/infracost/infracost/internal/ui"
+)
+
+// TagPolicyCheck is the result of checking the tags of the resources against
+// the tag policy of the config file.
+type TagPolicyCheck struct {
+	Violations []TagPolicyViolation `json:"violations"`
+}
+
+// TagPolicyViolation is a resource that is missing required tags or has tag
+// values that aren't allowed.
+type TagPolicyViolation struct {
+	ProjectName string   `json:"projectName"`
+	Address     string   `json:"address"`
+	Filename    string   `json:"filename,omitempty"`
+	StartLine   int      `json:"startLine,omitempty"`
+	Messages    []string `json:"messages"`
+}
+
+// HasFailed returns if any of the resources violate the tag policy.
+func (c *TagPolicyCheck) HasFailed() bool {
+	return c != nil && len(c.Violations) > 0
+}
+
+// Location returns the file and line the resource is defined on, or an empty
+// string if it isn't known, e.g. for resources from plan JSON files.
+func (v TagPolicyViolation) Location() string {
+	if v.Filename == "" {
+		return ""
+	}
+
+	if v.StartLine == 0 {
+		return v.Filename
+	}
+
+	return fmt.Sprintf("%s:%d", v.Filename, v.StartLine)
+}
+
+// CheckTagPolicy checks the tags of the priced resources of the projects
+// against the tag policy. Resources that can't be tagged are skipped.
+func CheckTagPolicy(projects []*schema.Project, policy *config.TagPolicy) *TagPolicyCheck {
+	check := &TagPolicyCheck{Violations: make([]TagPolicyViolation, 0)}
+
+	for _, p := range projects {
+		for _, r := range p.Resources {
+			if r.IsSkipped || r.NoPrice || r.Tags == nil {
+				continue
+			}
+
+			messages := policy.Check(r.Tags)
+			if len(messages) == 0 {
+				continue
+			}
+
+			v := TagPolicyViolation{
+				ProjectName: p.Name,
+				Address:     r.Name,
+				Messages:    messages,
+			}
+
+			v.Filename = r.Metadata["filename"].String()
+			v.StartLine = int(r.Metadata["startLine"].Int())
+
+			check.Violations = append(check.Violations, v)
+		}
+	}
+
+	sort.SliceStable(check.Violations, func(i, j int) bool {
+		if check.Violations[i].ProjectName != check.Violations[j].ProjectName {
+			return check.Violations[i].ProjectName < check.Violations[j].ProjectName
+		}
+
+		return check.Violations[i].Address < check.Violations[j].Address
+	})
+
+	return check
+}
+
+// mergeTagPolicyChecks returns a check with the violations of all the checks,
+// or nil if none of the checks are set.
+func mergeTagPolicyChecks(checks []*TagPolicyCheck) *TagPolicyCheck {
+	var merged *TagPolicyCheck
+
+	for _, c := range checks {
+		if c == nil {
+			continue
+		}
+
+		if merged == nil {
+			merged = &TagPolicyCheck{Violations: make([]TagPolicyViolation, 0)}
+		}
+
+		merged.Violations = append(merged.Violations, c.Violations...)
+	}
+
+	return merged
+}
+
+// tagPolicySummary returns a message with the number of resources that violate
+// the tag policy.
+func tagPolicySummary(check *TagPolicyCheck) string {
+	if len(check.Violations) == 1 {
+		return "1 resource is missing required tags or has tag values that aren't allowed"
+	}
+
+	return fmt.Sprintf("%d resources are missing required tags or have tag values that aren't allowed", len(check.Violations))
+}
+
+// tableForTagPolicy renders a table of the resources that violate the tag
+// policy.
+func tableForTagPolicy(check *TagPolicyCheck) string {
+	t := table.NewWriter()
+	t.Style().Options.DrawBorder = false
+	t.Style().Options.SeparateColumns = false
+	t.Style().Options.SeparateRows = false
+	t.Style().Options.SeparateHeader = false
+	t.Style().Format.Header = text.FormatDefault
+
+	t.AppendHeader(table.Row{
+		ui.UnderlineString("Resource"),
+		ui.UnderlineString("Location"),
+		ui.UnderlineString("Violations"),
+	})
+	t.AppendRow(table.Row{""})
+
+	for _, v := range check.Violations {
+		location := v.Location()
+		if location == "" {
+			location = "-"
+		}
+
+		t.AppendRow(table.Row{
+			ui.BoldString(v.Address),
+			location,
+			strings.Join(v.Messages, "\n"),
+		})
+	}
+
+	return t.Render()
+}
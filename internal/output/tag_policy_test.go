@@ -0,0 +1,91 @@
+package output
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/infracost/infracost/internal/ui"
+)
+
+func TestCheckTagPolicy(t *testing.T) {
+	policy := &config.TagPolicy{RequiredTags: []config.RequiredTag{
+		{Key: "team", AllowedValues: []string{"web", "data"}},
+		{Key: "env"},
+	}}
+	require.NoError(t, policy.Validate())
+
+	newResource := func(name string, tags map[string]string) *schema.Resource {
+		qty := decimal.NewFromInt(730)
+		c := &schema.CostComponent{
+			Name:            "Instance usage",
+			Unit:            "hours",
+			UnitMultiplier:  decimal.NewFromInt(1),
+			MonthlyQuantity: &qty,
+		}
+		c.SetPrice(decimal.NewFromInt(1))
+
+		return &schema.Resource{Name: name, Tags: tags, CostComponents: []*schema.CostComponent{c}}
+	}
+
+	tagged := newResource("aws_instance.tagged", map[string]string{"team": "web", "env": "prod"})
+	invalid := newResource("aws_instance.invalid", map[string]string{"team": "ops"})
+	invalid.Metadata = map[string]gjson.Result{
+		"filename":  gjson.Parse(`"main.tf"`),
+		"startLine": gjson.Parse(`12`),
+	}
+	untaggable := newResource("aws_route53_record.record", nil)
+	free := &schema.Resource{Name: "aws_iam_role.role", NoPrice: true, Tags: map[string]string{}}
+
+	project := &schema.Project{
+		Name:      "app",
+		Metadata:  &schema.ProjectMetadata{},
+		Resources: []*schema.Resource{tagged, invalid, untaggable, free},
+	}
+	schema.CalculateCosts(project)
+
+	check := CheckTagPolicy([]*schema.Project{project}, policy)
+	require.True(t, check.HasFailed())
+	assert.Equal(t, []TagPolicyViolation{
+		{
+			ProjectName: "app",
+			Address:     "aws_instance.invalid",
+			Filename:    "main.tf",
+			StartLine:   12,
+			Messages: []string{
+				"tag team has value 'ops', allowed values are: 'web', 'data'",
+				"missing required tag env",
+			},
+		},
+	}, check.Violations)
+	assert.Equal(t, "main.tf:12", check.Violations[0].Location())
+
+	out, err := ToOutputFormat([]*schema.Project{project})
+	require.NoError(t, err)
+	out.Currency = "USD"
+	out.TagPolicy = check
+
+	b, err := ToTable(out, Options{Fields: []string{"monthlyQuantity", "unit", "monthlyCost"}})
+	require.NoError(t, err)
+	assert.Contains(t, ui.StripColor(string(b)), "Tag policy failed: 1 resource is missing required tags or has tag values that aren't allowed")
+	assert.Regexp(t, `aws_instance\.invalid\s+main\.tf:12\s+tag team has value 'ops'`, ui.StripColor(string(b)))
+
+	combined, err := Combine([]ReportInput{{Root: out}, {Root: out}}, nil)
+	require.NoError(t, err)
+	assert.Len(t, combined.TagPolicy.Violations, 2)
+
+	b, err = ToMarkdown(combined, Options{}, MarkdownOptions{})
+	require.NoError(t, err)
+	assert.Contains(t, string(b), "❌ Tag policy failed")
+	assert.Contains(t, string(b), "> - aws_instance.invalid (main.tf:12): tag team has value 'ops', allowed values are: 'web', 'data', missing required tag env")
+
+	combined.TagPolicy = &TagPolicyCheck{Violations: []TagPolicyViolation{}}
+	b, err = ToMarkdown(combined, Options{}, MarkdownOptions{BasicSyntax: true})
+	require.NoError(t, err)
+	assert.Contains(t, string(b), "**Tag policy passed**")
+}
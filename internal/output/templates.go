@@ -51,7 +51,7 @@ td.name {
   max-width: 32rem;
 }
 
-td.monthly-quantity, td.price, td.hourly-cost, td.monthly-cost {
+td.monthly-quantity, td.price, td.hourly-cost, td.monthly-cost, td.monthly-carbon {
   text-align: right;
 }
 
@@ -136,6 +136,9 @@ iVBORw0KGgoAAAANSUhEUgAAAMAAAADACAMAAABlApw1AAAABGdBTUEAALGPC/xhBQAAAAFzUkdCAK7O
   {{if contains .Fields "monthlyCost"}}
     <td class="monthly-cost"></td>
   {{end}}
+  {{if contains .Fields "monthlyCarbon"}}
+    <td class="monthly-carbon"></td>
+  {{end}}
 {{end}}
 
 {{define "resourceRows"}}
@@ -198,6 +201,9 @@ iVBORw0KGgoAAAANSUhEUgAAAMAAAADACAMAAABlApw1AAAABGdBTUEAALGPC/xhBQAAAAFzUkdCAK7O
       {{if contains .Fields "monthlyCost"}}
         <td class="monthly-cost">{{.CostComponent.MonthlyCost | formatCost2DP}}</td>
       {{end}}
+      {{if contains .Fields "monthlyCarbon"}}
+        <td class="monthly-carbon">{{.CostComponent.MonthlyCarbon | formatQuantity }}</td>
+      {{end}}
     {{else}}
       <td colspan="{{len .Fields}}" class="usage-cost">Cost depends on usage: {{.CostComponent.Price | formatPrice}} per {{.CostComponent.Unit}}</td>
     {{end}}
@@ -221,6 +227,9 @@ iVBORw0KGgoAAAANSUhEUgAAAMAAAADACAMAAABlApw1AAAABGdBTUEAALGPC/xhBQAAAAFzUkdCAK7O
   {{if contains .Fields "monthlyCost"}}
     <td class="monthly-cost">{{ "Monthly Cost" | formatTitleWithCurrency }}</td>
   {{end}}
+  {{if contains .Fields "monthlyCarbon"}}
+    <td class="monthly-carbon">Monthly kgCO2e</td>
+  {{end}}
 {{end}}
 
 {{define "projectBlock"}}
@@ -241,9 +250,24 @@ iVBORw0KGgoAAAANSUhEUgAAAMAAAADACAMAAABlApw1AAAABGdBTUEAALGPC/xhBQAAAAFzUkdCAK7O
         {{template "resourceRows" dict "Resource" . "Fields" $fields "Indent" 0}}
       {{end}}
       <tr class="total">
-        <td class="name" colspan="{{len .Options.Fields}}">Project total</td>
+        <td class="name" colspan="{{totalColspan .Options.Fields}}">Project total</td>
         <td class="monthly-cost">{{.Project.Breakdown.TotalMonthlyCost | formatCost2DP}}</td>
+        {{- if contains .Options.Fields "monthlyCarbon"}}
+        <td class="monthly-carbon">{{.Project.Breakdown.TotalMonthlyCarbon | formatQuantity}}</td>
+        {{- end}}
       </tr>
+      {{- if .Project.Breakdown.TotalDailyCost}}
+      <tr class="total">
+        <td class="name" colspan="{{totalColspan .Options.Fields}}">Project daily total</td>
+        <td class="monthly-cost">{{.Project.Breakdown.TotalDailyCost | formatCost2DP}}</td>
+      </tr>
+      {{- end}}
+      {{- if .Project.Breakdown.TotalAnnualCost}}
+      <tr class="total">
+        <td class="name" colspan="{{totalColspan .Options.Fields}}">Project annual total</td>
+        <td class="monthly-cost">{{.Project.Breakdown.TotalAnnualCost | formatCost2DP}}</td>
+      </tr>
+      {{- end}}
     </tbody>
   </table>
 {{end}}
@@ -282,12 +306,48 @@ iVBORw0KGgoAAAANSUhEUgAAAMAAAADACAMAAABlApw1AAAABGdBTUEAALGPC/xhBQAAAAFzUkdCAK7O
     <table class="overall-total">
       <tbody>
         <tr class="total">
-          <td class="name" colspan="{{len .Options.Fields}}">{{ "Overall total" | formatTitleWithCurrency }}</td>
+          <td class="name" colspan="{{totalColspan .Options.Fields}}">{{ "Overall total" | formatTitleWithCurrency }}</td>
           <td class="monthly-cost">{{.Root.TotalMonthlyCost | formatCost2DP}}</td>
+          {{- if contains .Options.Fields "monthlyCarbon"}}
+          <td class="monthly-carbon">{{.Root.TotalMonthlyCarbon | formatQuantity}}</td>
+          {{- end}}
+        </tr>
+        {{- if .Root.TotalDailyCost}}
+        <tr class="total">
+          <td class="name" colspan="{{totalColspan .Options.Fields}}">{{ "Overall daily total" | formatTitleWithCurrency }}</td>
+          <td class="monthly-cost">{{.Root.TotalDailyCost | formatCost2DP}}</td>
+        </tr>
+        {{- end}}
+        {{- if .Root.TotalAnnualCost}}
+        <tr class="total">
+          <td class="name" colspan="{{totalColspan .Options.Fields}}">{{ "Overall annual total" | formatTitleWithCurrency }}</td>
+          <td class="monthly-cost">{{.Root.TotalAnnualCost | formatCost2DP}}</td>
         </tr>
+        {{- end}}
       </tbody>
     </table>
 
+    {{- range $key := .Root.GroupKeys}}
+
+    <p class="project-name">Group by: {{$key}}</p>
+    <table class="breakdown">
+      <thead>
+        <th class="name">{{$key}}</th>
+        <td class="monthly-quantity">Resources</td>
+        <td class="monthly-cost">{{ "Monthly Cost" | formatTitleWithCurrency }}</td>
+      </thead>
+      <tbody>
+        {{- range $.Root.GroupsWithKey $key}}
+        <tr class="resource">
+          <td class="name">{{.Value}}</td>
+          <td class="monthly-quantity">{{.ResourceCount}}</td>
+          <td class="monthly-cost">{{.MonthlyCost | formatCost2DP}}</td>
+        </tr>
+        {{- end}}
+      </tbody>
+    </table>
+    {{- end}}
+
     <div class="warnings">
       <p>{{.SummaryMessage | stripColor | replaceNewLines}}</p>
     </div>
@@ -302,10 +362,11 @@ var CommentMarkdownWithHTMLTemplate = `
       <td>{{ truncateMiddle . 64 "..." }}</td>
   {{- end }}
       <td align="right">{{ formatCost .PastCost }}</td>
-      <td align="right">{{ formatCost .Cost }}</td>
+      <td align="right">{{ formatCost .Cost }}{{ template "costRange" . }}</td>
       <td>{{ formatCostChange .PastCost .Cost }}</td>
     </tr>
 {{- end}}
+{{- define "costRange"}}{{ if .CostRange }} (range {{ formatCost .CostRange.Low }}–{{ formatCost .CostRange.High }}){{ end }}{{- end}}
 💰 Infracost estimate: **{{ formatCostChangeSentence .Root.Currency .Root.PastTotalMonthlyCost .Root.TotalMonthlyCost true }}**
 <table>
   <thead>
@@ -321,10 +382,10 @@ var CommentMarkdownWithHTMLTemplate = `
   <tbody>
   {{- range .Root.Projects }}
     {{- if showProject . }}
-      {{- template "summaryRow" dict "Name" .Name "MetadataFields" (. | metadataFields) "PastCost" .PastBreakdown.TotalMonthlyCost "Cost" .Breakdown.TotalMonthlyCost  }}
+      {{- template "summaryRow" dict "Name" .Name "MetadataFields" (. | metadataFields) "PastCost" .PastBreakdown.TotalMonthlyCost "Cost" .Breakdown.TotalMonthlyCost "CostRange" .Breakdown.TotalMonthlyCostRange }}
     {{- end }}
   {{- end }}
-  {{- template "summaryRow" dict "Name" "All projects" "MetadataFields" (metadataPlaceholders) "PastCost" .Root.PastTotalMonthlyCost "Cost" .Root.TotalMonthlyCost  }}
+  {{- template "summaryRow" dict "Name" "All projects" "MetadataFields" (metadataPlaceholders) "PastCost" .Root.PastTotalMonthlyCost "Cost" .Root.TotalMonthlyCost "CostRange" .Root.TotalMonthlyCostRange }}
   </tbody>
 </table>
 
@@ -344,11 +405,75 @@ var CommentMarkdownWithHTMLTemplate = `
 {{- else }}
   <tbody>
   {{- range .Root.Projects }}
-    {{- template "summaryRow" dict "Name" .Name "MetadataFields" (. | metadataFields) "PastCost" .PastBreakdown.TotalMonthlyCost "Cost" .Breakdown.TotalMonthlyCost  }}
+    {{- template "summaryRow" dict "Name" .Name "MetadataFields" (. | metadataFields) "PastCost" .PastBreakdown.TotalMonthlyCost "Cost" .Breakdown.TotalMonthlyCost "CostRange" .Breakdown.TotalMonthlyCostRange }}
   {{- end }}
   </tbody>
 </table>
 {{- end }}
+{{- if .Root.TotalDailyCost }}
+
+Daily cost: **{{ formatCost .Root.TotalDailyCost }}**
+{{- end }}
+{{- if .Root.TotalAnnualCost }}
+
+Annual cost: **{{ formatCost .Root.TotalAnnualCost }}**
+{{- end }}
+{{- if .Root.TotalMonthlyCarbon }}
+
+Monthly carbon emissions: **{{ formatCarbon .Root.TotalMonthlyCarbon }}** ({{ formatCarbonChange .Root.PastTotalMonthlyCarbon .Root.TotalMonthlyCarbon }})
+{{- end }}
+{{- if .Replacements }}
+
+🔁 **{{ len .Replacements }} {{ if eq (len .Replacements) 1 }}resource is{{ else }}resources are{{ end }} replaced**{{ if .Root.TotalOverlapCost }}, running both copies while they are replaced is estimated to cost **{{ formatCost .Root.TotalOverlapCost }}** one-off{{ end }}:
+  {{- range .Replacements }}
+- ` + "`" + `{{ .Name }}` + "`" + ` in {{ .Project }} ({{ replacementLabel .Replacement }}){{ if .OverlapCost }}, {{ formatCost .OverlapCost }} overlap cost{{ end }}
+  {{- end }}
+{{- end }}
+{{- if .PriceChanges }}
+
+💲 **{{ len .PriceChanges }} {{ if eq (len .PriceChanges) 1 }}cost component has{{ else }}cost components have{{ end }} a price change**, the diff is split into the part caused by the price change and the part caused by the quantity change:
+<table>
+  <thead>
+    <td>Project</td>
+    <td>Cost component</td>
+    <td>Price change</td>
+    <td>Quantity change</td>
+  </thead>
+  <tbody>
+  {{- range .PriceChanges }}
+    <tr>
+      <td>{{ truncateMiddle .Project 64 "..." }}</td>
+      <td>{{ .Name }}</td>
+      <td align="right">{{ formatCostDiff .PriceChange }}</td>
+      <td align="right">{{ formatCostDiff .QuantityChange }}</td>
+    </tr>
+  {{- end }}
+  </tbody>
+</table>
+{{- end }}
+{{- range $key := .Root.GroupKeys }}
+
+<table>
+  <thead>
+    <td>{{ $key }}</td>
+    <td>Resources</td>
+    <td>Monthly cost</td>
+  </thead>
+  <tbody>
+  {{- range $.Root.GroupsWithKey $key }}
+    <tr>
+      <td>{{ truncateMiddle .Value 64 "..." }}</td>
+      <td align="right">{{ .ResourceCount }}</td>
+      <td align="right">{{ formatCost .MonthlyCost }}</td>
+    </tr>
+  {{- end }}
+  </tbody>
+</table>
+{{- end }}
+{{- if .Root.IsLowerBound }}
+
+⚠️ Prices for some cost components could not be retrieved, so these costs are a lower bound.
+{{- end }}
 
 {{- if not .MarkdownOptions.OmitDetails }}
 
@@ -377,6 +502,18 @@ var CommentMarkdownWithHTMLTemplate = `
 		</details>
 	{{- end }}
 {{- end }}
+{{- if .Root.TagPolicy }}
+	{{- if gt (len .Root.TagPolicy.Violations) 0 }}
+		<details>
+			<summary><strong>❌ Tag policy failed</strong></summary>
+				{{ range $v, $f := .Root.TagPolicy.Violations}}
+> - {{ $f.Address }}{{ if $f.Location }} ({{ $f.Location }}){{ end }}: {{ join ", " $f.Messages }}
+				{{- end}}
+		</details>
+	{{ else }}
+<strong>✅ Tag policy passed</strong>
+	{{- end }}
+{{- end }}
 {{- if .Options.GuardrailCheck.Comment }}
 	{{- if gt (len .Options.GuardrailCheck.CommentableFailures) 0 }}
 		<details>
@@ -408,11 +545,12 @@ This comment will be replaced when the cost estimate changes
 
 var CommentMarkdownTemplate = `
 {{- define "summaryRow"}}
-| {{ truncateMiddle .Name 64 "..." }}{{- range .MetadataFields }} | {{ . }} {{- end }} | {{ formatCost .PastCost }} | {{ formatCost .Cost }} | {{ formatCostChange .PastCost .Cost }} |
+| {{ truncateMiddle .Name 64 "..." }}{{- range .MetadataFields }} | {{ . }} {{- end }} | {{ formatCost .PastCost }} | {{ formatCost .Cost }}{{ template "costRange" . }} | {{ formatCostChange .PastCost .Cost }} |
 {{- end }}
 {{- define "totalRow"}}
-| **{{ truncateMiddle .Name 64 "..." }}**{{- range metadataHeaders }} | {{- end }} | **{{ formatCost .PastCost }}** | **{{ formatCost .Cost }}** | **{{ formatCostChange .PastCost .Cost }}** |
+| **{{ truncateMiddle .Name 64 "..." }}**{{- range metadataHeaders }} | {{- end }} | **{{ formatCost .PastCost }}** | **{{ formatCost .Cost }}**{{ template "costRange" . }} | **{{ formatCostChange .PastCost .Cost }}** |
 {{- end }}
+{{- define "costRange"}}{{ if .CostRange }} (range {{ formatCost .CostRange.Low }}–{{ formatCost .CostRange.High }}){{ end }}{{- end }}
 ## Infracost estimate: **{{ formatCostChangeSentence .Root.Currency .Root.PastTotalMonthlyCost .Root.TotalMonthlyCost false }}**
 
 | **Project**{{- range metadataHeaders }} | **{{ . }}** {{- end }} | **Previous** | **New** | **Diff** |
@@ -421,10 +559,10 @@ var CommentMarkdownTemplate = `
 {{- if gt (len .Root.Projects) 1  }}
   {{- range .Root.Projects }}
     {{- if showProject . }}
-      {{- template "summaryRow" dict "Name" .Name "MetadataFields" (. | metadataFields) "PastCost" .PastBreakdown.TotalMonthlyCost "Cost" .Breakdown.TotalMonthlyCost  }}
+      {{- template "summaryRow" dict "Name" .Name "MetadataFields" (. | metadataFields) "PastCost" .PastBreakdown.TotalMonthlyCost "Cost" .Breakdown.TotalMonthlyCost "CostRange" .Breakdown.TotalMonthlyCostRange }}
     {{- end }}
   {{- end }}
-  {{- template "totalRow" dict "Name" "All projects" "PastCost" .Root.PastTotalMonthlyCost "Cost" .Root.TotalMonthlyCost  }}
+  {{- template "totalRow" dict "Name" "All projects" "PastCost" .Root.PastTotalMonthlyCost "Cost" .Root.TotalMonthlyCost "CostRange" .Root.TotalMonthlyCostRange }}
 
   {{- if eq .SkippedProjectCount 1 }}
 
@@ -435,9 +573,50 @@ var CommentMarkdownTemplate = `
   {{- end }}
 {{- else }}
   {{- range .Root.Projects }}
-    {{- template "summaryRow" dict "Name" .Name "MetadataFields" (. | metadataFields) "PastCost" .PastBreakdown.TotalMonthlyCost "Cost" .Breakdown.TotalMonthlyCost  }}
+    {{- template "summaryRow" dict "Name" .Name "MetadataFields" (. | metadataFields) "PastCost" .PastBreakdown.TotalMonthlyCost "Cost" .Breakdown.TotalMonthlyCost "CostRange" .Breakdown.TotalMonthlyCostRange }}
+  {{- end }}
+{{- end }}
+{{- if .Root.TotalDailyCost }}
+
+Daily cost: **{{ formatCost .Root.TotalDailyCost }}**
+{{- end }}
+{{- if .Root.TotalAnnualCost }}
+
+Annual cost: **{{ formatCost .Root.TotalAnnualCost }}**
+{{- end }}
+{{- if .Root.TotalMonthlyCarbon }}
+
+Monthly carbon emissions: **{{ formatCarbon .Root.TotalMonthlyCarbon }}** ({{ formatCarbonChange .Root.PastTotalMonthlyCarbon .Root.TotalMonthlyCarbon }})
+{{- end }}
+{{- if .Replacements }}
+
+🔁 **{{ len .Replacements }} {{ if eq (len .Replacements) 1 }}resource is{{ else }}resources are{{ end }} replaced**{{ if .Root.TotalOverlapCost }}, running both copies while they are replaced is estimated to cost **{{ formatCost .Root.TotalOverlapCost }}** one-off{{ end }}:
+  {{- range .Replacements }}
+- ` + "`" + `{{ .Name }}` + "`" + ` in {{ .Project }} ({{ replacementLabel .Replacement }}){{ if .OverlapCost }}, {{ formatCost .OverlapCost }} overlap cost{{ end }}
+  {{- end }}
+{{- end }}
+{{- if .PriceChanges }}
+
+💲 **{{ len .PriceChanges }} {{ if eq (len .PriceChanges) 1 }}cost component has{{ else }}cost components have{{ end }} a price change**, the diff is split into the part caused by the price change and the part caused by the quantity change:
+
+| **Project** | **Cost component** | **Price change** | **Quantity change** |
+| ----------- | ------------------ | ---------------: | ------------------: |
+  {{- range .PriceChanges }}
+| {{ truncateMiddle .Project 64 "..." }} | {{ .Name }} | {{ formatCostDiff .PriceChange }} | {{ formatCostDiff .QuantityChange }} |
+  {{- end }}
+{{- end }}
+{{- range $key := .Root.GroupKeys }}
+
+| **{{ $key }}** | **Resources** | **Monthly cost** |
+| -------------- | ------------: | ---------------: |
+  {{- range $.Root.GroupsWithKey $key }}
+| {{ truncateMiddle .Value 64 "..." }} | {{ .ResourceCount }} | {{ formatCost .MonthlyCost }} |
   {{- end }}
 {{- end }}
+{{- if .Root.IsLowerBound }}
+
+⚠️ Prices for some cost components could not be retrieved, so these costs are a lower bound.
+{{- end }}
 
 {{- if not .MarkdownOptions.OmitDetails }}
 
@@ -465,6 +644,18 @@ var CommentMarkdownTemplate = `
 ` + "```" /* can't escape backticks */ + `
 	{{- end }}
 {{- end }}
+{{- if .Root.TagPolicy }}
+	{{- if gt (len .Root.TagPolicy.Violations) 0 }}
+**Tag policy failed:**
+` + "```" /* can't escape backticks */ + `
+				{{ range $v, $f := .Root.TagPolicy.Violations}}
+> {{ $f.Address }}{{ if $f.Location }} ({{ $f.Location }}){{ end }}: {{ join ", " $f.Messages }}
+				{{- end}}
+` + "```" /* can't escape backticks */ + `
+	{{ else }}
+**Tag policy passed**
+	{{- end }}
+{{- end }}
 {{- if .MarkdownOptions.WillUpdate }}
 
 This comment will be updated when the cost estimate changes.
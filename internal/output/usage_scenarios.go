@@ -0,0 +1,158 @@
+package output
+
+import (
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/jedib0t/go-pretty/v6/text"
+	"github.com/shopspring/decimal"
+
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/infracost/infracost/internal/ui"
+)
+
+// CostRange is the lowest and highest monthly cost of the usage scenarios
+// from the usage file.
+type CostRange struct {
+	Low  *decimal.Decimal `json:"low"`
+	High *decimal.Decimal `json:"high"`
+}
+
+// resourceCostRange returns the range of the monthly costs of the resource
+// and its usage scenarios, or nil if the resource has no usage scenarios.
+func resourceCostRange(r *schema.Resource) *CostRange {
+	if len(r.UsageScenarios) == 0 {
+		return nil
+	}
+
+	cost := decimal.Zero
+	if r.MonthlyCost != nil {
+		cost = *r.MonthlyCost
+	}
+
+	low, high := cost, cost
+	for _, s := range r.UsageScenarios {
+		scenarioCost := decimal.Zero
+		if s.MonthlyCost != nil {
+			scenarioCost = *s.MonthlyCost
+		}
+
+		low = decimal.Min(low, scenarioCost)
+		high = decimal.Max(high, scenarioCost)
+	}
+
+	return &CostRange{Low: decimalPtr(low), High: decimalPtr(high)}
+}
+
+// sumCostRanges adds up the ranges. Where a range isn't set the matching cost
+// is added to both the low and the high total. It returns nil if none of the
+// ranges are set.
+func sumCostRanges(ranges []*CostRange, costs []*decimal.Decimal) *CostRange {
+	var total *CostRange
+
+	for _, r := range ranges {
+		if r != nil {
+			total = &CostRange{Low: decimalPtr(decimal.Zero), High: decimalPtr(decimal.Zero)}
+			break
+		}
+	}
+
+	if total == nil {
+		return nil
+	}
+
+	for i, r := range ranges {
+		if r != nil {
+			total.Low = decimalPtr(total.Low.Add(*r.Low))
+			total.High = decimalPtr(total.High.Add(*r.High))
+			continue
+		}
+
+		if costs[i] != nil {
+			total.Low = decimalPtr(total.Low.Add(*costs[i]))
+			total.High = decimalPtr(total.High.Add(*costs[i]))
+		}
+	}
+
+	return total
+}
+
+// calculateTotalCostRange returns the total range of the monthly costs of the
+// resources, or nil if none of the resources have usage scenarios.
+func calculateTotalCostRange(resources []Resource) *CostRange {
+	ranges := make([]*CostRange, len(resources))
+	costs := make([]*decimal.Decimal, len(resources))
+
+	for i, r := range resources {
+		ranges[i] = r.MonthlyCostRange
+		costs[i] = r.MonthlyCost
+	}
+
+	return sumCostRanges(ranges, costs)
+}
+
+func convertCostRange(r *CostRange, convert func(d *decimal.Decimal) *decimal.Decimal) *CostRange {
+	if r == nil {
+		return nil
+	}
+
+	return &CostRange{Low: convert(r.Low), High: convert(r.High)}
+}
+
+// tableForUsageScenarios renders a table of the cost ranges of the resources
+// that have usage scenarios.
+func tableForUsageScenarios(out Root) string {
+	t := table.NewWriter()
+	t.Style().Options.DrawBorder = false
+	t.Style().Options.SeparateColumns = false
+	t.Style().Options.SeparateRows = false
+	t.Style().Options.SeparateHeader = false
+	t.Style().Format.Header = text.FormatDefault
+
+	t.SetColumnConfigs([]table.ColumnConfig{
+		{Number: 1, Align: text.AlignLeft, AlignHeader: text.AlignLeft},
+		{Number: 2, Align: text.AlignRight, AlignHeader: text.AlignRight},
+		{Number: 3, Align: text.AlignRight, AlignHeader: text.AlignRight},
+		{Number: 4, Align: text.AlignRight, AlignHeader: text.AlignRight},
+	})
+
+	t.AppendHeader(table.Row{
+		ui.UnderlineString("Usage scenarios"),
+		ui.UnderlineString(formatTitleWithCurrency("Low", out.Currency)),
+		ui.UnderlineString(formatTitleWithCurrency("Expected", out.Currency)),
+		ui.UnderlineString(formatTitleWithCurrency("High", out.Currency)),
+	})
+	t.AppendRow(table.Row{""})
+
+	rows := 0
+	for _, p := range out.Projects {
+		if p.Breakdown == nil || p.Breakdown.TotalMonthlyCostRange == nil {
+			continue
+		}
+
+		if rows > 0 {
+			t.AppendRow(table.Row{""})
+		}
+		rows++
+
+		for _, r := range p.Breakdown.Resources {
+			if r.MonthlyCostRange == nil {
+				continue
+			}
+
+			t.AppendRow(table.Row{
+				r.Name,
+				FormatCost2DP(out.Currency, r.MonthlyCostRange.Low),
+				FormatCost2DP(out.Currency, r.MonthlyCost),
+				FormatCost2DP(out.Currency, r.MonthlyCostRange.High),
+			})
+		}
+
+		t.AppendRow(table.Row{
+			ui.BoldString(p.Label()),
+			FormatCost2DP(out.Currency, p.Breakdown.TotalMonthlyCostRange.Low),
+			FormatCost2DP(out.Currency, p.Breakdown.TotalMonthlyCost),
+			FormatCost2DP(out.Currency, p.Breakdown.TotalMonthlyCostRange.High),
+		})
+	}
+
+	return t.Render()
+}
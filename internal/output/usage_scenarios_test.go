@@ -0,0 +1,72 @@
+package output
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/infracost/infracost/internal/ui"
+)
+
+func TestUsageScenarioCostRanges(t *testing.T) {
+	newResource := func(name string, requests int64) *schema.Resource {
+		qty := decimal.NewFromInt(requests)
+		c := &schema.CostComponent{
+			Name:            "Requests",
+			Unit:            "requests",
+			UnitMultiplier:  decimal.NewFromInt(1),
+			MonthlyQuantity: &qty,
+		}
+		c.SetPrice(decimal.NewFromInt(1))
+
+		return &schema.Resource{Name: name, CostComponents: []*schema.CostComponent{c}}
+	}
+
+	fn := newResource("aws_lambda_function.fn", 100)
+	fn.UsageScenarios = map[string]*schema.Resource{
+		"low":  newResource("aws_lambda_function.fn", 10),
+		"high": newResource("aws_lambda_function.fn", 1000),
+	}
+
+	project := &schema.Project{
+		Name:      "app",
+		Metadata:  &schema.ProjectMetadata{},
+		Resources: []*schema.Resource{fn, newResource("aws_instance.web", 50)},
+	}
+	schema.CalculateCosts(project)
+
+	out, err := ToOutputFormat([]*schema.Project{project})
+	require.NoError(t, err)
+	out.Currency = "USD"
+
+	assert.Equal(t, "10", out.Projects[0].Breakdown.Resources[1].MonthlyCostRange.Low.String())
+	assert.Equal(t, "1000", out.Projects[0].Breakdown.Resources[1].MonthlyCostRange.High.String())
+	assert.Nil(t, out.Projects[0].Breakdown.Resources[0].MonthlyCostRange)
+	assert.Equal(t, "60", out.TotalMonthlyCostRange.Low.String())
+	assert.Equal(t, "1050", out.TotalMonthlyCostRange.High.String())
+
+	b, err := ToTable(out, Options{Fields: []string{"monthlyQuantity", "unit", "monthlyCost"}})
+	require.NoError(t, err)
+	assert.Regexp(t, `OVERALL TOTAL RANGE\s+\$60\.00 - \$1,050\.00`, ui.StripColor(string(b)))
+	assert.Regexp(t, `aws_lambda_function\.fn\s+\$10\.00\s+\$100\.00\s+\$1,000\.00`, ui.StripColor(string(b)))
+
+	compared, err := CompareTo(out, Root{}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "1050", compared.TotalMonthlyCostRange.High.String())
+
+	combined, err := Combine([]ReportInput{{Root: compared}, {Root: compared}}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "120", combined.TotalMonthlyCostRange.Low.String())
+
+	b, err = ToMarkdown(combined, Options{}, MarkdownOptions{})
+	require.NoError(t, err)
+	assert.Contains(t, string(b), "$150 (range $60.00–$1,050)")
+	assert.Contains(t, string(b), "$300 (range $120–$2,100)")
+
+	b, err = ToMarkdown(combined, Options{}, MarkdownOptions{BasicSyntax: true})
+	require.NoError(t, err)
+	assert.Contains(t, string(b), "**$300** (range $120–$2,100)")
+}
@@ -0,0 +1,476 @@
+package prices
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/shopspring/decimal"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/infracost/infracost/internal/apiclient"
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/resources/aws"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+// commitmentCandidate is an on-demand cost component that can be covered by a
+// Reserved Instance or Savings Plan.
+type commitmentCandidate struct {
+	resource     *schema.Resource
+	component    *schema.CostComponent
+	service      string
+	instanceType string
+	region       string
+	// instances is the number of instances, or for Fargate the number of
+	// vCPUs or GBs, that are used per hour.
+	instances decimal.Decimal
+	// covered is the fraction of the usage that is covered by commitments.
+	covered decimal.Decimal
+	labels  []string
+	// shared is set when the resource is also in the set of current resources,
+	// which its prices are set by. The candidate uses up commitments but its
+	// cost component isn't changed.
+	shared bool
+}
+
+// resourceSet is a set of resources of a project that the commitments of the
+// project are allocated to together, i.e. the resources of a point in time.
+type resourceSet struct {
+	resources []*schema.Resource
+	// shared are the resources that are also in the set of current resources.
+	shared map[*schema.Resource]bool
+	// derived is set for the sets built from the current resources, e.g. for a
+	// month of the forecast or a usage scenario. Unused commitments are only
+	// logged for the past and current resources so they aren't logged once per
+	// month or scenario.
+	derived bool
+}
+
+// reservation is the part of a candidate that is covered by a Reserved
+// Instance. The reserved cost component is priced with the reserved price
+// filter of the commitment.
+type reservation struct {
+	candidate  *commitmentCandidate
+	commitment *schema.ReservedInstanceCommitment
+	fraction   decimal.Decimal
+	reserved   *schema.CostComponent
+}
+
+// applyCommitments applies the Reserved Instances and Savings Plans of each
+// project to its cost components, which must already have their on-demand
+// prices. The past and current resources of a project, and the resources of
+// each month of its forecast and of each of its usage scenarios, are covered
+// separately. The reserved prices are
+// retrieved for all projects together.
+func applyCommitments(ctx *config.RunContext, c *apiclient.PricingAPIClient, projects []*schema.Project) error {
+	type candidateSet struct {
+		commitments *schema.Commitments
+		candidates  []*commitmentCandidate
+		logUnused   bool
+	}
+
+	var sets []candidateSet
+	var reservations []*reservation
+
+	for _, project := range projects {
+		if project.Commitments == nil {
+			continue
+		}
+
+		for _, set := range commitmentResourceSets(project) {
+			candidates := findCommitmentCandidates(set.resources, set.shared)
+			reservations = append(reservations, allocateReservedInstances(project.Commitments.ReservedInstances, candidates, !set.derived)...)
+			sets = append(sets, candidateSet{commitments: project.Commitments, candidates: candidates, logUnused: !set.derived})
+		}
+	}
+
+	if len(reservations) > 0 {
+		reservedResources := make([]*schema.Resource, 0, len(reservations))
+		for _, r := range reservations {
+			reservedResources = append(reservedResources, &schema.Resource{
+				Name:           r.candidate.resource.Name,
+				ResourceType:   r.candidate.resource.ResourceType,
+				CostComponents: []*schema.CostComponent{r.reserved},
+			})
+		}
+
+		err := GetPricesConcurrent(ctx, c, reservedResources)
+		if err != nil {
+			return err
+		}
+
+		for _, r := range reservations {
+			r.apply()
+		}
+	}
+
+	for _, set := range sets {
+		applySavingsPlans(set.commitments.SavingsPlans, set.candidates, set.logUnused)
+	}
+
+	for _, set := range sets {
+		for _, candidate := range set.candidates {
+			if !candidate.shared && len(candidate.labels) > 0 {
+				candidate.component.Commitment = strings.Join(candidate.labels, ", ")
+			}
+		}
+	}
+
+	return nil
+}
+
+// commitmentResourceSets returns the sets of resources of the project that
+// commitments are allocated to: its past resources, its current resources, the
+// resources of each month of its forecast and the resources of each of its
+// usage scenarios. Resources without growth or usage scenarios are used as
+// they are for every month and scenario, so they are shared with the current
+// resources.
+func commitmentResourceSets(project *schema.Project) []resourceSet {
+	sets := []resourceSet{{resources: project.PastResources}, {resources: project.Resources}}
+
+	months := 0
+	for _, r := range project.Resources {
+		if len(r.ForecastMonths) > months {
+			months = len(r.ForecastMonths)
+		}
+	}
+
+	for m := 0; m < months; m++ {
+		set, ok := derivedResourceSet(project.Resources, func(r *schema.Resource) *schema.Resource {
+			if m < len(r.ForecastMonths) {
+				return r.ForecastMonths[m]
+			}
+			return r
+		})
+		if ok {
+			sets = append(sets, set)
+		}
+	}
+
+	for _, name := range schema.UsageScenarios {
+		if name == schema.ExpectedUsageScenario {
+			continue
+		}
+
+		set, ok := derivedResourceSet(project.Resources, func(r *schema.Resource) *schema.Resource {
+			if s, ok := r.UsageScenarios[name]; ok {
+				return s
+			}
+			return r
+		})
+		if ok {
+			sets = append(sets, set)
+		}
+	}
+
+	return sets
+}
+
+// derivedResourceSet returns the set of the resources derived from the current
+// resources. It returns false if none of the resources are different from the
+// current resources, since the set is then already covered.
+func derivedResourceSet(resources []*schema.Resource, derive func(r *schema.Resource) *schema.Resource) (resourceSet, bool) {
+	set := resourceSet{shared: map[*schema.Resource]bool{}, derived: true}
+	changed := false
+
+	for _, r := range resources {
+		d := derive(r)
+		if d == r {
+			set.shared[d] = true
+		} else {
+			changed = true
+		}
+
+		set.resources = append(set.resources, d)
+	}
+
+	return set, changed
+}
+
+// findCommitmentCandidates returns the cost components of the resources that
+// can be covered by commitments, sorted by resource and cost component name
+// so that limited commitments are always allocated in the same order.
+func findCommitmentCandidates(resources []*schema.Resource, shared map[*schema.Resource]bool) []*commitmentCandidate {
+	var candidates []*commitmentCandidate
+
+	var find func(r *schema.Resource, isShared bool)
+	find = func(r *schema.Resource, isShared bool) {
+		for _, c := range r.CostComponents {
+			if candidate := newCommitmentCandidate(r, c); candidate != nil {
+				candidate.shared = isShared
+				candidates = append(candidates, candidate)
+			}
+		}
+
+		for _, s := range r.SubResources {
+			find(s, isShared)
+		}
+	}
+
+	for _, r := range resources {
+		if !r.IsSkipped {
+			find(r, shared[r])
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].resource.Name != candidates[j].resource.Name {
+			return candidates[i].resource.Name < candidates[j].resource.Name
+		}
+		return candidates[i].component.Name < candidates[j].component.Name
+	})
+
+	return candidates
+}
+
+func newCommitmentCandidate(r *schema.Resource, c *schema.CostComponent) *commitmentCandidate {
+	if c.ProductFilter == nil || c.CustomPrice() != nil || c.PriceUnavailable() || len(c.PriceTiers()) > 0 || c.Price().IsZero() {
+		return nil
+	}
+
+	purchaseOption := ""
+	if c.PriceFilter != nil && c.PriceFilter.PurchaseOption != nil {
+		purchaseOption = *c.PriceFilter.PurchaseOption
+	}
+
+	var service string
+
+	switch strVal(c.ProductFilter.Service) + "/" + strVal(c.ProductFilter.ProductFamily) {
+	case "AmazonEC2/Compute Instance":
+		service = "ec2"
+	case "AmazonRDS/Database Instance":
+		service = "rds"
+	case "AmazonElastiCache/Cache Instance":
+		service = "elasticache"
+	case "AmazonECS/Compute", "AmazonEKS/Compute":
+		service = "fargate"
+	default:
+		return nil
+	}
+
+	// Reserved, spot and other purchase options can't be covered, and the EC2
+	// and RDS cost components without a purchase option aren't instance usage,
+	// e.g. EBS-optimized usage.
+	if purchaseOption != "on_demand" && (service != "fargate" || purchaseOption != "") {
+		return nil
+	}
+
+	instanceType := ""
+	for _, f := range c.ProductFilter.AttributeFilters {
+		if f.Key == "instanceType" && f.Value != nil {
+			instanceType = *f.Value
+		}
+	}
+
+	if service != "fargate" && instanceType == "" {
+		return nil
+	}
+
+	var instances decimal.Decimal
+	if c.HourlyQuantity != nil {
+		instances = *c.HourlyQuantity
+	} else if c.MonthlyQuantity != nil {
+		instances = c.MonthlyQuantity.Div(schema.HourToMonthUnitMultiplier)
+	}
+
+	if !instances.IsPositive() {
+		return nil
+	}
+
+	return &commitmentCandidate{
+		resource:     r,
+		component:    c,
+		service:      service,
+		instanceType: instanceType,
+		region:       strVal(c.ProductFilter.Region),
+		instances:    instances,
+		covered:      decimal.Zero,
+	}
+}
+
+// allocateReservedInstances allocates the reserved instances to the
+// candidates in order. A candidate is only covered by a single reservation.
+func allocateReservedInstances(commitments []*schema.ReservedInstanceCommitment, candidates []*commitmentCandidate, logUnused bool) []*reservation {
+	var reservations []*reservation
+
+	for _, ri := range commitments {
+		remaining := decimal.NewFromInt(ri.Count)
+
+		for _, candidate := range candidates {
+			if ri.Count > 0 && !remaining.IsPositive() {
+				break
+			}
+
+			if !candidate.covered.IsZero() || candidate.service != ri.Service || candidate.region != ri.Region || !matchesInstance(candidate.instanceType, ri.InstanceType, ri.InstanceFamily) {
+				continue
+			}
+
+			priceFilter, err := aws.ReservedPriceFilter(ri.Service, candidate.instanceType, ri.Term, ri.PaymentOption, ri.OfferingClass)
+			if err != nil {
+				log.Warnf("Ignoring reserved instance commitment for %s: %s", candidate.resource.Name, err)
+				continue
+			}
+
+			covered := candidate.instances
+			if ri.Count > 0 {
+				covered = decimal.Min(covered, remaining)
+				remaining = remaining.Sub(covered)
+			}
+
+			fraction := covered.Div(candidate.instances)
+			candidate.covered = fraction
+
+			reservations = append(reservations, &reservation{
+				candidate:  candidate,
+				commitment: ri,
+				fraction:   fraction,
+				reserved: &schema.CostComponent{
+					Name:           candidate.component.Name,
+					Unit:           candidate.component.Unit,
+					UnitMultiplier: candidate.component.UnitMultiplier,
+					ProductFilter:  candidate.component.ProductFilter,
+					PriceFilter:    priceFilter,
+				},
+			})
+		}
+
+		if logUnused && ri.Count > 0 && remaining.IsPositive() {
+			log.Warnf("%s of %d reserved instances for %s in %s are not used", remaining, ri.Count, instanceLabel(ri.InstanceType, ri.InstanceFamily), ri.Region)
+		}
+	}
+
+	return reservations
+}
+
+// apply sets the effective price of the covered cost component to the
+// on-demand price of the uncovered usage plus the reserved price of the
+// covered usage. The cost components of shared candidates are left as they are.
+func (r *reservation) apply() {
+	c := r.candidate.component
+	reservedPrice := r.reserved.Price()
+
+	if r.reserved.PriceUnavailable() || reservedPrice.IsZero() {
+		if !r.candidate.shared {
+			log.Warnf("No reserved price found for %s %s, using the on-demand price", r.candidate.resource.Name, c.Name)
+		}
+		r.candidate.covered = decimal.Zero
+		return
+	}
+
+	if r.candidate.shared {
+		return
+	}
+
+	onDemandPrice := c.Price()
+	c.SetOnDemandPrice(onDemandPrice)
+	c.SetPrice(onDemandPrice.Mul(decimal.NewFromInt(1).Sub(r.fraction)).Add(reservedPrice.Mul(r.fraction)))
+
+	r.candidate.labels = append(r.candidate.labels, coverageLabel(r.commitment.Label(), r.fraction))
+}
+
+// applySavingsPlans covers the usage that isn't covered by reserved instances
+// with the Savings Plans in order, until their hourly commitment is used up.
+func applySavingsPlans(commitments []*schema.SavingsPlanCommitment, candidates []*commitmentCandidate, logUnused bool) {
+	one := decimal.NewFromInt(1)
+
+	for _, sp := range commitments {
+		remaining := decimal.NewFromFloat(sp.HourlyCommitment)
+		discount := decimal.NewFromFloat(sp.DiscountPerc)
+
+		for _, candidate := range candidates {
+			if !remaining.IsPositive() {
+				break
+			}
+
+			if !savingsPlanCovers(sp, candidate) {
+				continue
+			}
+
+			uncovered := one.Sub(candidate.covered)
+			if !uncovered.IsPositive() {
+				continue
+			}
+
+			c := candidate.component
+			onDemandPrice := c.Price()
+			if c.OnDemandPrice() != nil {
+				onDemandPrice = *c.OnDemandPrice()
+			}
+
+			// The cost of the uncovered usage per hour at Savings Plan rates
+			spCost := onDemandPrice.Mul(candidate.instances).Mul(uncovered).Mul(one.Sub(discount))
+
+			fraction := uncovered
+			if spCost.GreaterThan(remaining) {
+				fraction = uncovered.Mul(remaining).Div(spCost)
+				remaining = decimal.Zero
+			} else {
+				remaining = remaining.Sub(spCost)
+			}
+
+			candidate.covered = candidate.covered.Add(fraction)
+			if candidate.shared {
+				continue
+			}
+
+			if c.OnDemandPrice() == nil {
+				c.SetOnDemandPrice(onDemandPrice)
+			}
+			c.SetPrice(c.Price().Sub(onDemandPrice.Mul(fraction).Mul(discount)))
+
+			candidate.labels = append(candidate.labels, coverageLabel(sp.Label(), fraction))
+		}
+
+		if logUnused && remaining.IsPositive() {
+			log.Warnf("%s/hour of the %s hourly commitment is not used", remaining.StringFixed(2), sp.Label())
+		}
+	}
+}
+
+func savingsPlanCovers(sp *schema.SavingsPlanCommitment, candidate *commitmentCandidate) bool {
+	switch sp.Type {
+	case "compute":
+		return candidate.service == "ec2" || candidate.service == "fargate"
+	case "ec2_instance":
+		return candidate.service == "ec2" && candidate.region == sp.Region && matchesInstance(candidate.instanceType, "", sp.InstanceFamily)
+	}
+
+	return false
+}
+
+// matchesInstance returns true if the instance type is the given type, or
+// belongs to the given family, e.g. m5.large belongs to m5 and db.r5.large
+// to db.r5.
+func matchesInstance(instanceType, commitmentType, commitmentFamily string) bool {
+	if commitmentType != "" {
+		return instanceType == commitmentType
+	}
+
+	i := strings.LastIndex(instanceType, ".")
+	return i > 0 && instanceType[:i] == commitmentFamily
+}
+
+func instanceLabel(instanceType, instanceFamily string) string {
+	if instanceType != "" {
+		return instanceType
+	}
+
+	return instanceFamily + " family"
+}
+
+func coverageLabel(label string, fraction decimal.Decimal) string {
+	if fraction.Equal(decimal.NewFromInt(1)) {
+		return label
+	}
+
+	return fmt.Sprintf("%s, %s%% coverage", label, fraction.Mul(decimal.NewFromInt(100)).Round(0))
+}
+
+func strVal(s *string) string {
+	if s == nil {
+		return ""
+	}
+
+	return *s
+}
@@ -0,0 +1,284 @@
+package prices
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infracost/infracost/internal/apiclient"
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func fargateResource(name string, vcpus int64) *schema.Resource {
+	return &schema.Resource{
+		Name: name,
+		CostComponents: []*schema.CostComponent{
+			{
+				Name:           "Per vCPU per hour",
+				Unit:           "CPU",
+				UnitMultiplier: schema.HourToMonthUnitMultiplier,
+				HourlyQuantity: decimalPtr(decimal.NewFromInt(vcpus)),
+				ProductFilter: &schema.ProductFilter{
+					VendorName:    strPtr("aws"),
+					Region:        strPtr("us-east-1"),
+					Service:       strPtr("AmazonECS"),
+					ProductFamily: strPtr("Compute"),
+					AttributeFilters: []*schema.AttributeFilter{
+						{Key: "usagetype", ValueRegex: strPtr("/Fargate-vCPU-Hours:perCPU/")},
+					},
+				},
+			},
+		},
+	}
+}
+
+func ec2Resource(name string) *schema.Resource {
+	r := instanceResource(name, "m5.large")
+	c := r.CostComponents[0]
+	c.MonthlyQuantity = decimalPtr(decimal.NewFromInt(730))
+	c.ProductFilter.Region = strPtr("us-east-1")
+	c.ProductFilter.Service = strPtr("AmazonEC2")
+	c.ProductFilter.ProductFamily = strPtr("Compute Instance")
+
+	return r
+}
+
+func decimalPtr(d decimal.Decimal) *decimal.Decimal {
+	return &d
+}
+
+func commitmentsTestServer(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body []apiclient.GraphQLQuery
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+
+		results := make([]string, 0, len(body))
+		for _, q := range body {
+			productFilter := q.Variables["productFilter"].(map[string]interface{})
+			priceFilter, _ := q.Variables["priceFilter"].(map[string]interface{})
+
+			price := "0.04"
+			if productFilter["service"] == "AmazonEC2" {
+				price = "0.10"
+				if priceFilter["termLength"] == "1yr" {
+					price = "0.06"
+				}
+			}
+
+			results = append(results, fmt.Sprintf(`{"data":{"products":[{"prices":[{"priceHash":"h","USD":"%s"}]}]}}`, price))
+		}
+
+		_, _ = fmt.Fprintf(w, "[%s]", strings.Join(results, ","))
+	}))
+}
+
+func TestPopulatePricesCommitments(t *testing.T) {
+	server := commitmentsTestServer(t)
+	defer server.Close()
+
+	ctx := config.EmptyRunContext()
+	ctx.Config.PricingAPIEndpoint = server.URL
+	ctx.Config.NoCache = true
+	ctx.Config.EventsDisabled = true
+
+	project := &schema.Project{
+		Name:     "test",
+		Metadata: &schema.ProjectMetadata{},
+		Resources: []*schema.Resource{
+			ec2Resource("aws_instance.a"),
+			ec2Resource("aws_instance.b"),
+			ec2Resource("aws_instance.c"),
+			fargateResource("aws_ecs_service.web", 4),
+		},
+		Commitments: &schema.Commitments{
+			ReservedInstances: []*schema.ReservedInstanceCommitment{
+				{Service: "ec2", InstanceFamily: "m5", Region: "us-east-1", Term: "1_year", PaymentOption: "no_upfront", Count: 2},
+			},
+			SavingsPlans: []*schema.SavingsPlanCommitment{
+				// Covers the Fargate usage (4 * 0.04 * 0.8 = 0.128/hr) and half of
+				// instance c (0.10 * 0.8 / 2 = 0.04/hr), resources are covered in
+				// name order
+				{Type: "compute", HourlyCommitment: 0.168, DiscountPerc: 0.2},
+			},
+		},
+	}
+
+	require.NoError(t, PopulatePrices(ctx, project))
+	schema.CalculateCosts(project)
+
+	a := project.Resources[0].CostComponents[0]
+	assert.Equal(t, "0.06", a.Price().String())
+	assert.Equal(t, "0.1", a.OnDemandPrice().String())
+	assert.Equal(t, "reserved instance, 1 year, no upfront", a.Commitment)
+	assert.Equal(t, "73", a.OnDemandMonthlyCost.String())
+	assert.Equal(t, "43.8", a.MonthlyCost.String())
+
+	b := project.Resources[1].CostComponents[0]
+	assert.Equal(t, "0.06", b.Price().String())
+
+	c := project.Resources[2].CostComponents[0]
+	assert.Equal(t, "0.09", c.Price().String())
+	assert.Equal(t, "compute savings plan, 50% coverage", c.Commitment)
+
+	fargate := project.Resources[3].CostComponents[0]
+	assert.Equal(t, "0.032", fargate.Price().String())
+	assert.Equal(t, "0.04", fargate.OnDemandPrice().String())
+	assert.Equal(t, "compute savings plan", fargate.Commitment)
+}
+
+func TestPopulatePricesCommitmentsSkipsOtherCostComponents(t *testing.T) {
+	server := commitmentsTestServer(t)
+	defer server.Close()
+
+	ctx := config.EmptyRunContext()
+	ctx.Config.PricingAPIEndpoint = server.URL
+	ctx.Config.NoCache = true
+	ctx.Config.EventsDisabled = true
+
+	other := ec2Resource("aws_instance.other_region")
+	other.CostComponents[0].ProductFilter.Region = strPtr("eu-west-1")
+
+	spot := ec2Resource("aws_instance.spot")
+	spot.CostComponents[0].PriceFilter.PurchaseOption = strPtr("spot")
+
+	project := &schema.Project{
+		Name:      "test",
+		Metadata:  &schema.ProjectMetadata{},
+		Resources: []*schema.Resource{other, spot},
+		Commitments: &schema.Commitments{
+			ReservedInstances: []*schema.ReservedInstanceCommitment{
+				{Service: "ec2", InstanceType: "m5.large", Region: "us-east-1", Term: "1_year", PaymentOption: "no_upfront"},
+			},
+		},
+	}
+
+	require.NoError(t, PopulatePrices(ctx, project))
+
+	for _, r := range project.Resources {
+		assert.Equal(t, "0.1", r.CostComponents[0].Price().String())
+		assert.Nil(t, r.CostComponents[0].OnDemandPrice())
+		assert.Empty(t, r.CostComponents[0].Commitment)
+	}
+}
+
+func TestPopulatePricesCommitmentsForecast(t *testing.T) {
+	server := commitmentsTestServer(t)
+	defer server.Close()
+
+	ctx := config.EmptyRunContext()
+	ctx.Config.PricingAPIEndpoint = server.URL
+	ctx.Config.NoCache = true
+	ctx.Config.EventsDisabled = true
+
+	// Only the storage of the instance grows, so each month is rebuilt with
+	// the same instance usage.
+	growing := func(storageGB int64) *schema.Resource {
+		r := ec2Resource("aws_instance.a")
+		r.CostComponents = append(r.CostComponents, &schema.CostComponent{
+			Name:            "Storage (general purpose SSD, gp3)",
+			Unit:            "GB",
+			UnitMultiplier:  decimal.NewFromInt(1),
+			MonthlyQuantity: decimalPtr(decimal.NewFromInt(storageGB)),
+			ProductFilter: &schema.ProductFilter{
+				VendorName:    strPtr("aws"),
+				Region:        strPtr("us-east-1"),
+				Service:       strPtr("AmazonEC2"),
+				ProductFamily: strPtr("Storage"),
+			},
+		})
+
+		return r
+	}
+
+	a := growing(100)
+	a.ForecastMonths = []*schema.Resource{growing(100), growing(200)}
+
+	b := ec2Resource("aws_instance.b")
+	b.ForecastMonths = []*schema.Resource{b, b}
+
+	project := &schema.Project{
+		Name:      "test",
+		Metadata:  &schema.ProjectMetadata{},
+		Resources: []*schema.Resource{a, b},
+		Commitments: &schema.Commitments{
+			ReservedInstances: []*schema.ReservedInstanceCommitment{
+				{Service: "ec2", InstanceFamily: "m5", Region: "us-east-1", Term: "1_year", PaymentOption: "no_upfront", Count: 2},
+			},
+		},
+	}
+
+	require.NoError(t, PopulatePrices(ctx, project))
+
+	for m, r := range append([]*schema.Resource{a}, a.ForecastMonths...) {
+		c := r.CostComponents[0]
+		assert.Equal(t, "0.06", c.Price().String(), "month %d", m)
+		assert.Equal(t, "0.1", c.OnDemandPrice().String(), "month %d", m)
+		assert.Equal(t, "reserved instance, 1 year, no upfront", c.Commitment, "month %d", m)
+		assert.Nil(t, r.CostComponents[1].OnDemandPrice(), "month %d", m)
+	}
+
+	// The resource without growth is only covered once for all the months.
+	c := b.CostComponents[0]
+	assert.Equal(t, "0.06", c.Price().String())
+	assert.Equal(t, "0.1", c.OnDemandPrice().String())
+	assert.Equal(t, "reserved instance, 1 year, no upfront", c.Commitment)
+}
+
+func TestPopulatePricesCommitmentsUsageScenarios(t *testing.T) {
+	server := commitmentsTestServer(t)
+	defer server.Close()
+
+	ctx := config.EmptyRunContext()
+	ctx.Config.PricingAPIEndpoint = server.URL
+	ctx.Config.NoCache = true
+	ctx.Config.EventsDisabled = true
+
+	withHours := func(hours int64) *schema.Resource {
+		r := ec2Resource("aws_instance.a")
+		r.CostComponents[0].MonthlyQuantity = decimalPtr(decimal.NewFromInt(hours))
+		return r
+	}
+
+	a := withHours(730)
+	a.UsageScenarios = map[string]*schema.Resource{
+		"low":  withHours(600),
+		"high": withHours(1460),
+	}
+
+	project := &schema.Project{
+		Name:      "test",
+		Metadata:  &schema.ProjectMetadata{},
+		Resources: []*schema.Resource{a},
+		Commitments: &schema.Commitments{
+			ReservedInstances: []*schema.ReservedInstanceCommitment{
+				{Service: "ec2", InstanceFamily: "m5", Region: "us-east-1", Term: "1_year", PaymentOption: "no_upfront", Count: 1},
+			},
+		},
+	}
+
+	require.NoError(t, PopulatePrices(ctx, project))
+	schema.CalculateCosts(project)
+
+	assert.Equal(t, "43.8", a.MonthlyCost.String())
+
+	// The low scenario is covered by the reserved instance as well, so it
+	// doesn't cost more than the expected usage.
+	low := a.UsageScenarios["low"]
+	assert.Equal(t, "0.06", low.CostComponents[0].Price().String())
+	assert.Equal(t, "reserved instance, 1 year, no upfront", low.CostComponents[0].Commitment)
+	assert.Equal(t, "36", low.MonthlyCost.String())
+
+	// Only one of the two instances of the high scenario is covered.
+	high := a.UsageScenarios["high"]
+	assert.Equal(t, "0.08", high.CostComponents[0].Price().String())
+	assert.Equal(t, "reserved instance, 1 year, no upfront, 50% coverage", high.CostComponents[0].Commitment)
+	assert.Equal(t, "116.8", high.MonthlyCost.String())
+}
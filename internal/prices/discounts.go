@@ -0,0 +1,169 @@
+package prices
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+	yamlv3 "gopkg.in/yaml.v3"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+const discountRulesVersion = "0.1"
+
+// DiscountRules are negotiated discounts, such as an EDP or a discount for a
+// service, that are applied to the cost components after they are priced.
+//
+// The rules are evaluated in the order they are listed in the file. The first
+// rule that matches a cost component is applied and evaluation stops, unless
+// the rule has stack set, in which case evaluation continues and the discounts
+// of the later matching rules are compounded with it. For example a stacked 5%
+// EDP rule followed by a 12% EC2 rule gives EC2 instances a discount of
+// 1 - (0.95 * 0.88) = 16.4%. Discounts that the cost component already has,
+// such as Google sustained use discounts, are compounded in the same way.
+type DiscountRules struct {
+	Version string          `yaml:"version"`
+	Rules   []*DiscountRule `yaml:"rules"`
+}
+
+// DiscountRule is a discount for the cost components that match all of its
+// patterns. Patterns match the whole value and can contain * and ? wildcards,
+// empty patterns match everything.
+type DiscountRule struct {
+	Name          string  `yaml:"name"`
+	Vendor        string  `yaml:"vendor,omitempty"`
+	Service       string  `yaml:"service,omitempty"`
+	ProductFamily string  `yaml:"product_family,omitempty"`
+	ResourceType  string  `yaml:"resource_type,omitempty"`
+	CostComponent string  `yaml:"cost_component,omitempty"`
+	DiscountPerc  float64 `yaml:"discount_perc"`
+	Stack         bool    `yaml:"stack,omitempty"`
+
+	patterns []*regexp.Regexp
+}
+
+// LoadDiscountRules reads and validates the discount rules file at path.
+func LoadDiscountRules(path string) (*DiscountRules, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Error reading discount rules file %s", path)
+	}
+
+	return parseDiscountRules(data)
+}
+
+func parseDiscountRules(data []byte) (*DiscountRules, error) {
+	var rules DiscountRules
+
+	err := yamlv3.Unmarshal(data, &rules)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error parsing discount rules file")
+	}
+
+	if rules.Version != discountRulesVersion {
+		return nil, fmt.Errorf("Invalid discount rules file version %q, expected %s", rules.Version, discountRulesVersion)
+	}
+
+	for i, rule := range rules.Rules {
+		if rule.Name == "" {
+			return nil, fmt.Errorf("Invalid discount rule rules[%d]: name is required", i)
+		}
+
+		if rule.DiscountPerc <= 0 || rule.DiscountPerc > 1 {
+			return nil, fmt.Errorf("Invalid discount rule %s: discount_perc must be greater than 0 and at most 1", rule.Name)
+		}
+
+		for _, p := range []string{rule.Vendor, rule.Service, rule.ProductFamily, rule.ResourceType, rule.CostComponent} {
+			rule.patterns = append(rule.patterns, globToRegexp(p))
+		}
+	}
+
+	return &rules, nil
+}
+
+// matches returns true if all the patterns of the rule match the cost component.
+func (r *DiscountRule) matches(resourceType string, c *schema.CostComponent) bool {
+	var vendor, service, productFamily string
+	if c.ProductFilter != nil {
+		vendor = strVal(c.ProductFilter.VendorName)
+		service = strVal(c.ProductFilter.Service)
+		productFamily = strVal(c.ProductFilter.ProductFamily)
+	}
+
+	for i, v := range []string{vendor, service, productFamily, resourceType, c.Name} {
+		if r.patterns[i] != nil && !r.patterns[i].MatchString(v) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Apply sets the discount of every cost component of the projects that is
+// matched by the rules.
+func (d *DiscountRules) Apply(projects []*schema.Project) {
+	for _, project := range projects {
+		resources := append(project.AllResources(), project.UsageScenarioResources()...)
+		resources = append(resources, project.ForecastResources()...)
+		for _, r := range resources {
+			if !r.IsSkipped {
+				d.applyToResource(r.ResourceType, r)
+			}
+		}
+	}
+}
+
+// applyToResource applies the rules to the cost components of the resource
+// and its sub resources. Sub resources are matched with the resource type of
+// their parent.
+func (d *DiscountRules) applyToResource(resourceType string, r *schema.Resource) {
+	for _, c := range r.CostComponents {
+		d.applyToCostComponent(resourceType, c)
+	}
+
+	for _, s := range r.SubResources {
+		d.applyToResource(resourceType, s)
+	}
+}
+
+func (d *DiscountRules) applyToCostComponent(resourceType string, c *schema.CostComponent) {
+	names := make([]string, 0)
+	remaining := 1.0
+
+	for _, rule := range d.Rules {
+		if !rule.matches(resourceType, c) {
+			continue
+		}
+
+		names = append(names, rule.Name)
+		remaining *= 1 - rule.DiscountPerc
+
+		if !rule.Stack {
+			break
+		}
+	}
+
+	if len(names) == 0 {
+		return
+	}
+
+	c.ApplyDiscountRule(strings.Join(names, ", "), 1-remaining)
+}
+
+// globToRegexp converts a pattern with * and ? wildcards to a regular
+// expression that matches the whole value. It returns nil for an empty
+// pattern.
+func globToRegexp(pattern string) *regexp.Regexp {
+	if pattern == "" {
+		return nil
+	}
+
+	expr := regexp.QuoteMeta(pattern)
+	expr = strings.ReplaceAll(expr, `\*`, ".*")
+	expr = strings.ReplaceAll(expr, `\?`, ".")
+
+	return regexp.MustCompile("^" + expr + "$")
+}
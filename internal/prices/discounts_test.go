@@ -0,0 +1,108 @@
+package prices
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func TestParseDiscountRulesInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		err  string
+	}{
+		{name: "version", data: "version: 0.2\nrules: []", err: "Invalid discount rules file version"},
+		{name: "missing name", data: "version: 0.1\nrules:\n  - discount_perc: 0.1", err: "name is required"},
+		{name: "discount", data: "version: 0.1\nrules:\n  - name: edp\n    discount_perc: 1.5", err: "discount_perc must be greater than 0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseDiscountRules([]byte(tt.data))
+			assert.ErrorContains(t, err, tt.err)
+		})
+	}
+}
+
+func TestDiscountRulesApply(t *testing.T) {
+	rules, err := parseDiscountRules([]byte(`
+version: 0.1
+rules:
+  - name: edp
+    vendor: aws
+    discount_perc: 0.05
+    stack: true
+  - name: ec2
+    service: AmazonEC2
+    product_family: Compute Instance
+    cost_component: "Instance usage (*"
+    discount_perc: 0.12
+  - name: ec2-fallback
+    service: AmazonEC2
+    discount_perc: 0.5
+  - name: google
+    vendor: gcp
+    resource_type: google_compute_instance
+    discount_perc: 0.1
+`))
+	require.NoError(t, err)
+
+	instance := ec2Resource("aws_instance.web")
+	instance.ResourceType = "aws_instance"
+
+	fargate := fargateResource("aws_ecs_service.web", 1)
+	fargate.ResourceType = "aws_ecs_service"
+
+	google := &schema.Resource{
+		Name:         "google_compute_instance.web",
+		ResourceType: "google_compute_instance",
+		CostComponents: []*schema.CostComponent{
+			{
+				Name:                "Instance usage",
+				MonthlyQuantity:     decimalPtr(decimal.NewFromInt(730)),
+				MonthlyDiscountPerc: 0.3,
+				ProductFilter:       &schema.ProductFilter{VendorName: strPtr("gcp")},
+			},
+		},
+	}
+
+	project := &schema.Project{
+		Name:      "test",
+		Resources: []*schema.Resource{instance, fargate, google},
+	}
+
+	rules.Apply([]*schema.Project{project})
+
+	// The stacked EDP rule is compounded with the first matching EC2 rule and the
+	// fallback rule is not applied.
+	c := instance.CostComponents[0]
+	assert.Equal(t, "edp, ec2", c.DiscountRule)
+	assert.InDelta(t, 0.164, c.MonthlyDiscountPerc, 0.00001)
+
+	c.SetPrice(decimal.NewFromFloat(0.1))
+	c.CalculateCosts()
+	assert.Equal(t, "73", c.ListMonthlyCost.String())
+	assert.Equal(t, "61.028", c.MonthlyCost.String())
+
+	// A stacked rule still applies when no later rule matches.
+	assert.Equal(t, "edp", fargate.CostComponents[0].DiscountRule)
+	assert.InDelta(t, 0.05, fargate.CostComponents[0].MonthlyDiscountPerc, 0.00001)
+
+	// Existing discounts, such as sustained use discounts, are compounded with
+	// the rules.
+	g := google.CostComponents[0]
+	assert.Equal(t, "google", g.DiscountRule)
+	assert.InDelta(t, 0.37, g.MonthlyDiscountPerc, 0.00001)
+
+	// The list cost keeps the existing discount, so only the rule's discount
+	// is credited to it.
+	g.SetPrice(decimal.NewFromFloat(0.1))
+	g.CalculateCosts()
+	assert.Equal(t, "51.1", g.ListMonthlyCost.String())
+	assert.Equal(t, "45.99", g.MonthlyCost.String())
+}
@@ -0,0 +1,202 @@
+package prices
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	log "github.com/sirupsen/logrus"
+	yamlv3 "gopkg.in/yaml.v3"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+const priceOverridesVersion = "0.1"
+
+// PriceOverrides set the prices of cost components that the Cloud Pricing API
+// doesn't know about or has the wrong price for, such as marketplace AMIs,
+// private offers or internal chargeback rates. An override either sets a fixed
+// price per currency or multiplies the price from the Cloud Pricing API. The
+// overrides are evaluated in the order they are listed in the file and the
+// first one that matches a cost component is applied.
+type PriceOverrides struct {
+	Version   string           `yaml:"version"`
+	Overrides []*PriceOverride `yaml:"overrides"`
+}
+
+// PriceOverride matches cost components either by resource type and cost
+// component name, or by the product filter that is used to price them.
+// Patterns match the whole value and can contain * and ? wildcards, empty
+// patterns match everything.
+type PriceOverride struct {
+	Name          string                `yaml:"name"`
+	ResourceType  string                `yaml:"resource_type,omitempty"`
+	CostComponent string                `yaml:"cost_component,omitempty"`
+	ProductFilter *PriceOverrideProduct `yaml:"product_filter,omitempty"`
+	// Price is the price per currency, e.g. USD: 0.25. Prices are kept as
+	// strings so they don't lose precision.
+	Price      map[string]string `yaml:"price,omitempty"`
+	Multiplier *float64          `yaml:"multiplier,omitempty"`
+
+	patterns []*regexp.Regexp
+}
+
+// PriceOverrideProduct matches the product filter of a cost component. All of
+// the attributes must be in the product filter with a matching value.
+type PriceOverrideProduct struct {
+	Vendor        string            `yaml:"vendor,omitempty"`
+	Service       string            `yaml:"service,omitempty"`
+	ProductFamily string            `yaml:"product_family,omitempty"`
+	Region        string            `yaml:"region,omitempty"`
+	Attributes    map[string]string `yaml:"attributes,omitempty"`
+}
+
+// LoadPriceOverrides reads and validates the price overrides file at path.
+func LoadPriceOverrides(path string) (*PriceOverrides, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Error reading price overrides file %s", path)
+	}
+
+	return parsePriceOverrides(data)
+}
+
+func parsePriceOverrides(data []byte) (*PriceOverrides, error) {
+	var overrides PriceOverrides
+
+	err := yamlv3.Unmarshal(data, &overrides)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error parsing price overrides file")
+	}
+
+	if overrides.Version != priceOverridesVersion {
+		return nil, fmt.Errorf("Invalid price overrides file version %q, expected %s", overrides.Version, priceOverridesVersion)
+	}
+
+	for i, o := range overrides.Overrides {
+		if o.Name == "" {
+			return nil, fmt.Errorf("Invalid price override overrides[%d]: name is required", i)
+		}
+
+		if (len(o.Price) == 0) == (o.Multiplier == nil) {
+			return nil, fmt.Errorf("Invalid price override %s: one of price or multiplier is required", o.Name)
+		}
+
+		if o.Multiplier != nil && *o.Multiplier < 0 {
+			return nil, fmt.Errorf("Invalid price override %s: multiplier can't be negative", o.Name)
+		}
+
+		for currency, price := range o.Price {
+			if _, err := decimal.NewFromString(price); err != nil {
+				return nil, fmt.Errorf("Invalid price override %s: invalid %s price %q", o.Name, currency, price)
+			}
+		}
+
+		patterns := []string{o.ResourceType, o.CostComponent, "", "", "", ""}
+		if o.ProductFilter != nil {
+			patterns[2] = o.ProductFilter.Vendor
+			patterns[3] = o.ProductFilter.Service
+			patterns[4] = o.ProductFilter.ProductFamily
+			patterns[5] = o.ProductFilter.Region
+		}
+
+		for _, p := range patterns {
+			o.patterns = append(o.patterns, globToRegexp(p))
+		}
+	}
+
+	return &overrides, nil
+}
+
+// Apply sets the custom price or custom price multiplier of every cost
+// component of the resources that is matched by an override, so that it is
+// used by setCostComponentPrice. Overrides that don't have a price in the
+// currency are ignored.
+func (o *PriceOverrides) Apply(currency string, resources []*schema.Resource) {
+	for _, r := range resources {
+		if !r.IsSkipped {
+			o.applyToResource(currency, r.ResourceType, r)
+		}
+	}
+}
+
+// applyToResource applies the overrides to the cost components of the
+// resource and its sub resources. Sub resources are matched with the resource
+// type of their parent.
+func (o *PriceOverrides) applyToResource(currency, resourceType string, r *schema.Resource) {
+	for _, c := range r.CostComponents {
+		override := o.match(resourceType, c)
+		if override == nil {
+			continue
+		}
+
+		if override.Multiplier != nil {
+			m := decimal.NewFromFloat(*override.Multiplier)
+			c.SetCustomPriceMultiplier(&m)
+		} else {
+			price, ok := override.Price[currency]
+			if !ok {
+				log.Warnf("Price override %s has no %s price, ignoring it for %s %s", override.Name, currency, r.Name, c.Name)
+				continue
+			}
+
+			p, _ := decimal.NewFromString(price)
+			c.SetCustomPrice(&p)
+		}
+
+		c.PriceOverride = override.Name
+	}
+
+	for _, s := range r.SubResources {
+		o.applyToResource(currency, resourceType, s)
+	}
+}
+
+func (o *PriceOverrides) match(resourceType string, c *schema.CostComponent) *PriceOverride {
+	for _, override := range o.Overrides {
+		if override.matches(resourceType, c) {
+			return override
+		}
+	}
+
+	return nil
+}
+
+func (o *PriceOverride) matches(resourceType string, c *schema.CostComponent) bool {
+	var vendor, service, productFamily, region string
+	attributes := make(map[string]string)
+
+	if c.ProductFilter != nil {
+		vendor = strVal(c.ProductFilter.VendorName)
+		service = strVal(c.ProductFilter.Service)
+		productFamily = strVal(c.ProductFilter.ProductFamily)
+		region = strVal(c.ProductFilter.Region)
+
+		for _, f := range c.ProductFilter.AttributeFilters {
+			if f.Value != nil {
+				attributes[f.Key] = *f.Value
+			}
+		}
+	} else if o.ProductFilter != nil {
+		return false
+	}
+
+	for i, v := range []string{resourceType, c.Name, vendor, service, productFamily, region} {
+		if o.patterns[i] != nil && !o.patterns[i].MatchString(v) {
+			return false
+		}
+	}
+
+	if o.ProductFilter != nil {
+		for k, pattern := range o.ProductFilter.Attributes {
+			v, ok := attributes[k]
+			if !ok || !globToRegexp(pattern).MatchString(v) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
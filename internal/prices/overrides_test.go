@@ -0,0 +1,106 @@
+package prices
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func TestParsePriceOverridesInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		err  string
+	}{
+		{name: "version", data: "version: 0.2\noverrides: []", err: "Invalid price overrides file version"},
+		{name: "missing name", data: "version: 0.1\noverrides:\n  - multiplier: 0.5", err: "name is required"},
+		{name: "price and multiplier", data: "version: 0.1\noverrides:\n  - name: ami\n    multiplier: 0.5\n    price:\n      USD: 1", err: "one of price or multiplier is required"},
+		{name: "price", data: "version: 0.1\noverrides:\n  - name: ami\n    price:\n      USD: abc", err: "invalid USD price"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parsePriceOverrides([]byte(tt.data))
+			assert.ErrorContains(t, err, tt.err)
+		})
+	}
+}
+
+func TestPopulatePricesPriceOverrides(t *testing.T) {
+	server := commitmentsTestServer(t)
+	defer server.Close()
+
+	overrides, err := parsePriceOverrides([]byte(`
+version: 0.1
+overrides:
+  - name: marketplace-ami
+    resource_type: aws_instance
+    cost_component: "Instance usage*"
+    price:
+      USD: 0.25
+      EUR: 0.23
+  - name: fargate-chargeback
+    product_filter:
+      vendor: aws
+      service: AmazonECS
+      product_family: Compute
+      region: us-east-1
+    multiplier: 1.5
+  - name: eur-only
+    resource_type: aws_db_instance
+    price:
+      EUR: 1
+  - name: m5-private-offer
+    product_filter:
+      service: AmazonEC2
+      attributes:
+        instanceType: m5.*
+    multiplier: 0.5
+`))
+	require.NoError(t, err)
+
+	instance := ec2Resource("aws_instance.web")
+	instance.ResourceType = "aws_instance"
+
+	fargate := fargateResource("aws_ecs_service.web", 1)
+	fargate.ResourceType = "aws_ecs_service"
+
+	db := ec2Resource("aws_db_instance.db")
+	db.ResourceType = "aws_db_instance"
+
+	other := ec2Resource("aws_launch_template.web")
+	other.ResourceType = "aws_launch_template"
+
+	resources := []*schema.Resource{instance, fargate, db, other}
+	overrides.Apply("USD", resources)
+
+	ctx := config.EmptyRunContext()
+	ctx.Config.PricingAPIEndpoint = server.URL
+	ctx.Config.NoCache = true
+	ctx.Config.EventsDisabled = true
+
+	project := &schema.Project{
+		Name:      "test",
+		Metadata:  &schema.ProjectMetadata{},
+		Resources: resources,
+	}
+	require.NoError(t, PopulatePrices(ctx, project))
+
+	assert.Equal(t, "0.25", instance.CostComponents[0].Price().String())
+	assert.Equal(t, "marketplace-ami", instance.CostComponents[0].PriceOverride)
+
+	assert.Equal(t, "0.06", fargate.CostComponents[0].Price().String())
+	assert.Equal(t, "fargate-chargeback", fargate.CostComponents[0].PriceOverride)
+
+	// Only the first matching override is used, and it is ignored if it doesn't
+	// have a price in the currency
+	assert.Equal(t, "0.1", db.CostComponents[0].Price().String())
+	assert.Empty(t, db.CostComponents[0].PriceOverride)
+
+	assert.Equal(t, "0.05", other.CostComponents[0].Price().String())
+	assert.Equal(t, "m5-private-offer", other.CostComponents[0].PriceOverride)
+}
@@ -1,12 +1,16 @@
 package prices
 
 import (
+	"errors"
 	"fmt"
 	"math"
 	"runtime"
 	"sort"
+	"strings"
+	"time"
 
 	"github.com/infracost/infracost/internal/apiclient"
+	"github.com/infracost/infracost/internal/carbon"
 	"github.com/infracost/infracost/internal/config"
 	"github.com/infracost/infracost/internal/schema"
 
@@ -15,22 +19,120 @@ import (
 	"github.com/tidwall/gjson"
 )
 
-func PopulatePrices(ctx *config.RunContext, project *schema.Project) error {
-	resources := project.AllResources()
+var (
+	// maxBatchAttempts is the number of times a batch of price queries is sent
+	// before it is treated as failed.
+	maxBatchAttempts = 3
+	// batchRetryBackoff is the delay before a failed batch is first retried. It
+	// doubles for each further retry.
+	batchRetryBackoff = time.Second
+)
+
+// PopulatePrices sets the prices of all the cost components of the projects.
+// The price overrides file, if one is configured, is applied first so that the
+// overridden prices are used when the cost components are priced. The price
+// queries of all the projects are gathered so that identical queries are only
+// sent once. Any Reserved Instance and Savings Plan commitments
+// of the projects are then applied, followed by the discount rules file if one is
+// configured. If the monthlyCarbon output field is requested the carbon
+// emissions of the compute, storage and network cost components are estimated
+// too. If partial prices are allowed, a warning is added to the project
+// metadata for every cost component whose price could not be retrieved.
+func PopulatePrices(ctx *config.RunContext, projects ...*schema.Project) error {
+	var priceOverrides *PriceOverrides
+	if ctx.Config.PriceOverridesFile != "" {
+		var err error
+		priceOverrides, err = LoadPriceOverrides(ctx.Config.PriceOverridesFile)
+		if err != nil {
+			return err
+		}
+	}
+
+	var discountRules *DiscountRules
+	if ctx.Config.DiscountsFile != "" {
+		var err error
+		discountRules, err = LoadDiscountRules(ctx.Config.DiscountsFile)
+		if err != nil {
+			return err
+		}
+	}
+
+	resources := make([]*schema.Resource, 0)
+	for _, project := range projects {
+		resources = append(resources, project.AllResources()...)
+		resources = append(resources, project.UsageScenarioResources()...)
+		resources = append(resources, project.ForecastResources()...)
+	}
 
 	c := apiclient.NewPricingAPIClient(ctx)
 
+	if priceOverrides != nil {
+		currency := ctx.Config.Currency
+		if currency == "" {
+			currency = "USD"
+		}
+
+		priceOverrides.Apply(currency, resources)
+	}
+
 	err := GetPricesConcurrent(ctx, c, resources)
 	if err != nil {
 		return err
 	}
+
+	err = applyCommitments(ctx, c, projects)
+	if err != nil {
+		return err
+	}
+
+	if discountRules != nil {
+		discountRules.Apply(projects)
+	}
+
+	if ctx.Config.EstimateCarbon() {
+		err = carbon.Estimate(resources)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, project := range projects {
+		addPriceUnavailableWarnings(project)
+	}
+
 	return nil
 }
 
-// GetPricesConcurrent gets the prices of all resources concurrently.
+// GetPricesConcurrent gets the prices of all resources. The queries for all
+// cost components are deduplicated and sent in batches of at most
+// apiclient.MaxQueriesPerBatch, so the number of requests scales with the number
+// of distinct products rather than the number of resources. Batches are sent
+// concurrently and retried with an exponential backoff if they fail.
 // Concurrency level is calculated using the following formula:
 // max(min(4, numCPU * 4), 16)
+//
+// If a batch still fails after it has been retried, an error is returned unless
+// ctx.Config.AllowPartialPrices is set, in which case the cost components of the
+// batch are priced at zero and marked as unavailable.
+//
+// If an exchange rates file is set, the prices are converted to the currency
+// with its rates.
 func GetPricesConcurrent(ctx *config.RunContext, c *apiclient.PricingAPIClient, resources []*schema.Resource) error {
+	keys, queries := c.BatchQueries(resources)
+	if len(queries) == 0 {
+		return nil
+	}
+
+	fxFactor, err := fxConversionFactor(ctx, c)
+	if err != nil {
+		return err
+	}
+
+	unique, indexes := apiclient.DedupeQueries(queries)
+	batches := apiclient.SplitQueries(unique, apiclient.MaxQueriesPerBatch)
+
+	log.Debugf("Getting prices for %d cost components using %d unique queries in %d batches", len(queries), len(unique), len(batches))
+
 	// Set the number of workers
 	numWorkers := 4
 	numCPU := runtime.NumCPU()
@@ -40,40 +142,120 @@ func GetPricesConcurrent(ctx *config.RunContext, c *apiclient.PricingAPIClient,
 	if numWorkers > 16 {
 		numWorkers = 16
 	}
-	numJobs := len(resources)
-	jobs := make(chan *schema.Resource, numJobs)
+
+	type batchJob struct {
+		offset  int
+		queries []apiclient.GraphQLQuery
+	}
+
+	numJobs := len(batches)
+	jobs := make(chan batchJob, numJobs)
 	resultErrors := make(chan error, numJobs)
+	uniqueResults := make([]gjson.Result, len(unique))
+	uniqueErrors := make([]error, len(unique))
 
 	// Fire up the workers
 	for i := 0; i < numWorkers; i++ {
-		go func(jobs <-chan *schema.Resource, resultErrors chan<- error) {
-			for r := range jobs {
-				err := GetPrices(ctx, c, r)
+		go func(jobs <-chan batchJob, resultErrors chan<- error) {
+			for job := range jobs {
+				// Each job writes to its own section of the slices.
+				results, err := performQueriesWithRetry(c, job.queries)
+				if err == nil {
+					copy(uniqueResults[job.offset:], results)
+				} else {
+					for i := range job.queries {
+						uniqueErrors[job.offset+i] = err
+					}
+				}
 				resultErrors <- err
 			}
 		}(jobs, resultErrors)
 	}
 
 	// Feed the workers the jobs of getting prices
-	for _, r := range resources {
-		jobs <- r
+	offset := 0
+	for _, batch := range batches {
+		jobs <- batchJob{offset: offset, queries: batch}
+		offset += len(batch)
 	}
+	close(jobs)
 
 	// Get the result of the jobs
+	failedBatches := 0
 	for i := 0; i < numJobs; i++ {
 		err := <-resultErrors
-		if err != nil {
+		if err == nil {
+			continue
+		}
+
+		if !ctx.Config.AllowPartialPrices || errors.Is(err, apiclient.ErrInvalidAPIKey) {
 			return err
 		}
+
+		failedBatches++
+	}
+
+	if failedBatches > 0 {
+		log.Warnf("%d of %d batches of price queries failed, costs will be a lower bound", failedBatches, numJobs)
+	}
+
+	// Send the results back to every cost component that asked for them.
+	results := make([]gjson.Result, len(queries))
+	for i, idx := range indexes {
+		results[i] = uniqueResults[idx]
+	}
+
+	for i, r := range c.ZipQueryResults(keys, results) {
+		if err := uniqueErrors[indexes[i]]; err != nil {
+			setCostComponentPriceUnavailable(ctx, r.Resource, r.CostComponent, err)
+			continue
+		}
+
+		setCostComponentPrice(ctx, c.Currency, r.Resource, r.CostComponent, r.Result)
+		if fxFactor != nil {
+			convertCostComponentPrice(r.CostComponent, *fxFactor)
+		}
 	}
+
 	return nil
 }
 
+// performQueriesWithRetry sends the queries, retrying with an exponential
+// backoff if the request fails. Invalid API key errors are not retried since
+// they won't succeed on a later attempt.
+func performQueriesWithRetry(c *apiclient.PricingAPIClient, queries []apiclient.GraphQLQuery) ([]gjson.Result, error) {
+	backoff := batchRetryBackoff
+
+	for attempt := 1; ; attempt++ {
+		results, err := c.PerformQueries(queries)
+		if err == nil && len(results) != len(queries) {
+			err = fmt.Errorf("expected %d price query results, got %d", len(queries), len(results))
+		}
+
+		if err == nil {
+			return results, nil
+		}
+
+		if attempt >= maxBatchAttempts || errors.Is(err, apiclient.ErrInvalidAPIKey) {
+			return nil, err
+		}
+
+		log.Debugf("Failed to get prices for batch of %d queries (attempt %d of %d), retrying in %s: %s", len(queries), attempt, maxBatchAttempts, backoff, err)
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+}
+
 func GetPrices(ctx *config.RunContext, c *apiclient.PricingAPIClient, r *schema.Resource) error {
 	if r.IsSkipped {
 		return nil
 	}
 
+	fxFactor, err := fxConversionFactor(ctx, c)
+	if err != nil {
+		return err
+	}
+
 	results, err := c.RunQueries(r)
 	if err != nil {
 		return err
@@ -81,11 +263,51 @@ func GetPrices(ctx *config.RunContext, c *apiclient.PricingAPIClient, r *schema.
 
 	for _, r := range results {
 		setCostComponentPrice(ctx, c.Currency, r.Resource, r.CostComponent, r.Result)
+		if fxFactor != nil {
+			convertCostComponentPrice(r.CostComponent, *fxFactor)
+		}
 	}
 
 	return nil
 }
 
+// fxConversionFactor returns the factor that the prices retrieved by the client
+// are multiplied by to convert them to the currency with the exchange rates
+// file. It returns nil if no conversion is needed.
+func fxConversionFactor(ctx *config.RunContext, c *apiclient.PricingAPIClient) (*decimal.Decimal, error) {
+	if ctx.Config.Currency == "" || strings.EqualFold(ctx.Config.Currency, c.Currency) {
+		return nil, nil
+	}
+
+	rates, err := ctx.Config.LoadFXRates()
+	if err != nil || rates == nil {
+		return nil, err
+	}
+
+	factor, err := rates.Factor(c.Currency, ctx.Config.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	return &factor, nil
+}
+
+// convertCostComponentPrice multiplies the price and price tiers of the cost
+// component by factor. Custom prices are already in the currency of the run
+// so they are not converted.
+func convertCostComponentPrice(c *schema.CostComponent, factor decimal.Decimal) {
+	if c.CustomPrice() != nil || c.PriceUnavailable() {
+		return
+	}
+
+	c.SetPrice(c.Price().Mul(factor))
+
+	tiers := c.PriceTiers()
+	for i := range tiers {
+		tiers[i].Price = tiers[i].Price.Mul(factor)
+	}
+}
+
 func setCostComponentPrice(ctx *config.RunContext, currency string, r *schema.Resource, c *schema.CostComponent, res gjson.Result) {
 	var p decimal.Decimal
 
@@ -105,6 +327,7 @@ func setCostComponentPrice(ctx *config.RunContext, currency string, r *schema.Re
 
 		log.Warnf("No products found for %s %s, using 0.00", r.Name, c.Name)
 		setResourceWarningEvent(ctx, r, "No products found")
+		c.PriceNotFound = true
 		c.SetPrice(decimal.Zero)
 		return
 	}
@@ -134,6 +357,7 @@ func setCostComponentPrice(ctx *config.RunContext, currency string, r *schema.Re
 
 		log.Warnf("No prices found for %s %s, using 0.00", r.Name, c.Name)
 		setResourceWarningEvent(ctx, r, "No prices found")
+		c.PriceNotFound = true
 		c.SetPrice(decimal.Zero)
 		return
 	}
@@ -212,6 +436,59 @@ func setCostComponentPrice(ctx *config.RunContext, currency string, r *schema.Re
 	c.SetPriceHash(prices[0].Get("priceHash").String())
 }
 
+func setCostComponentPriceUnavailable(ctx *config.RunContext, r *schema.Resource, c *schema.CostComponent, err error) {
+	if c.CustomPrice() != nil {
+		log.Debugf("Using user-defined custom price %v for %s %s.", *c.CustomPrice(), r.Name, c.Name)
+		c.SetPrice(*c.CustomPrice())
+		return
+	}
+
+	log.Warnf("Price unavailable for %s %s, using 0.00: %s", r.Name, c.Name, err)
+	setResourceWarningEvent(ctx, r, "Price unavailable")
+	c.PriceUnavailableReason = err.Error()
+	c.SetPrice(decimal.Zero)
+}
+
+// addPriceUnavailableWarnings adds a warning to the project metadata for each
+// cost component of the project whose price could not be retrieved. Past and
+// current resources with the same cost component only get one warning.
+func addPriceUnavailableWarnings(project *schema.Project) {
+	seen := make(map[string]bool)
+
+	var addWarnings func(r *schema.Resource)
+	addWarnings = func(r *schema.Resource) {
+		for _, c := range r.CostComponents {
+			key := r.Name + "\n" + c.Name
+			if !c.PriceUnavailable() || seen[key] {
+				continue
+			}
+			seen[key] = true
+
+			if project.Metadata == nil {
+				project.Metadata = &schema.ProjectMetadata{}
+			}
+
+			project.Metadata.Warnings = append(project.Metadata.Warnings, schema.Warning{
+				Code:    schema.WarningCodePriceUnavailable,
+				Message: "Price unavailable",
+				Data: schema.PriceUnavailableWarningData{
+					ResourceName:      r.Name,
+					CostComponentName: c.Name,
+					Error:             c.PriceUnavailableReason,
+				},
+			})
+		}
+
+		for _, s := range r.SubResources {
+			addWarnings(s)
+		}
+	}
+
+	for _, r := range project.AllResources() {
+		addWarnings(r)
+	}
+}
+
 func setResourceWarningEvent(ctx *config.RunContext, r *schema.Resource, msg string) {
 	warnings := ctx.GetResourceWarnings()
 	if warnings == nil {
@@ -0,0 +1,211 @@
+package prices
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infracost/infracost/internal/apiclient"
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func strPtr(s string) *string {
+	return &s
+}
+
+func instanceResource(name, instanceType string) *schema.Resource {
+	return &schema.Resource{
+		Name: name,
+		CostComponents: []*schema.CostComponent{
+			{
+				Name:           fmt.Sprintf("Instance usage (Linux/UNIX, on-demand, %s)", instanceType),
+				Unit:           "hours",
+				UnitMultiplier: decimal.NewFromInt(1),
+				ProductFilter: &schema.ProductFilter{
+					VendorName: strPtr("aws"),
+					AttributeFilters: []*schema.AttributeFilter{
+						{Key: "instanceType", Value: strPtr(instanceType)},
+					},
+				},
+				PriceFilter: &schema.PriceFilter{PurchaseOption: strPtr("on_demand")},
+			},
+		},
+	}
+}
+
+func TestGetPricesConcurrentDedupesQueries(t *testing.T) {
+	var requests, queries int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+
+		var body []apiclient.GraphQLQuery
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		atomic.AddInt32(&queries, int32(len(body)))
+
+		results := make([]string, 0, len(body))
+		for _, q := range body {
+			filter := q.Variables["productFilter"].(map[string]interface{})
+			attr := filter["attributeFilters"].([]interface{})[0].(map[string]interface{})
+
+			price := "0.0416"
+			if attr["value"] == "t3.large" {
+				price = "0.0832"
+			}
+
+			results = append(results, fmt.Sprintf(`{"data":{"products":[{"prices":[{"priceHash":"%s","USD":"%s"}]}]}}`, attr["value"], price))
+		}
+
+		_, _ = fmt.Fprintf(w, "[%s]", strings.Join(results, ","))
+	}))
+	defer server.Close()
+
+	ctx := config.EmptyRunContext()
+	ctx.Config.PricingAPIEndpoint = server.URL
+	ctx.Config.NoCache = true
+	ctx.Config.EventsDisabled = true
+
+	resources := make([]*schema.Resource, 0)
+	for i := 0; i < 2000; i++ {
+		resources = append(resources, instanceResource(fmt.Sprintf("aws_instance.medium[%d]", i), "t3.medium"))
+	}
+	resources = append(resources, instanceResource("aws_instance.large", "t3.large"))
+
+	skipped := instanceResource("aws_instance.skipped", "t3.xlarge")
+	skipped.IsSkipped = true
+	resources = append(resources, skipped)
+
+	c := apiclient.NewPricingAPIClient(ctx)
+	require.NoError(t, GetPricesConcurrent(ctx, c, resources))
+
+	assert.Equal(t, int32(1), requests)
+	assert.Equal(t, int32(2), queries)
+
+	assert.Equal(t, "0.0416", resources[0].CostComponents[0].Price().String())
+	assert.Equal(t, "0.0416", resources[1999].CostComponents[0].Price().String())
+	assert.Equal(t, "t3.medium", resources[1999].CostComponents[0].PriceHash())
+	assert.Equal(t, "0.0832", resources[2000].CostComponents[0].Price().String())
+	assert.Equal(t, "", resources[2001].CostComponents[0].PriceHash())
+}
+
+func TestGetPricesConcurrentRetriesFailedBatches(t *testing.T) {
+	defer func(d time.Duration) { batchRetryBackoff = d }(batchRetryBackoff)
+	batchRetryBackoff = time.Millisecond
+
+	var requests int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+			_, _ = fmt.Fprint(w, `{"error":"bad gateway"}`)
+			return
+		}
+
+		_, _ = fmt.Fprint(w, `[{"data":{"products":[{"prices":[{"priceHash":"t3.medium","USD":"0.0416"}]}]}}]`)
+	}))
+	defer server.Close()
+
+	ctx := config.EmptyRunContext()
+	ctx.Config.PricingAPIEndpoint = server.URL
+	ctx.Config.NoCache = true
+	ctx.Config.EventsDisabled = true
+
+	resources := []*schema.Resource{instanceResource("aws_instance.medium", "t3.medium")}
+
+	c := apiclient.NewPricingAPIClient(ctx)
+	require.NoError(t, GetPricesConcurrent(ctx, c, resources))
+
+	assert.Equal(t, int32(3), requests)
+	assert.Equal(t, "0.0416", resources[0].CostComponents[0].Price().String())
+	assert.False(t, resources[0].CostComponents[0].PriceUnavailable())
+}
+
+func TestPopulatePricesPartialResults(t *testing.T) {
+	defer func(d time.Duration) { batchRetryBackoff = d }(batchRetryBackoff)
+	batchRetryBackoff = time.Millisecond
+
+	var requests int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_, _ = fmt.Fprint(w, `{"error":"unavailable"}`)
+	}))
+	defer server.Close()
+
+	newProject := func() *schema.Project {
+		return &schema.Project{
+			Name:      "test",
+			Metadata:  &schema.ProjectMetadata{},
+			Resources: []*schema.Resource{instanceResource("aws_instance.medium", "t3.medium")},
+		}
+	}
+
+	ctx := config.EmptyRunContext()
+	ctx.Config.PricingAPIEndpoint = server.URL
+	ctx.Config.NoCache = true
+	ctx.Config.EventsDisabled = true
+
+	require.Error(t, PopulatePrices(ctx, newProject()))
+	assert.Equal(t, int32(maxBatchAttempts), requests)
+
+	ctx.Config.AllowPartialPrices = true
+	project := newProject()
+	require.NoError(t, PopulatePrices(ctx, project))
+
+	cc := project.Resources[0].CostComponents[0]
+	assert.True(t, cc.PriceUnavailable())
+	assert.True(t, cc.Price().IsZero())
+
+	require.Len(t, project.Metadata.Warnings, 1)
+	warning := project.Metadata.Warnings[0]
+	assert.Equal(t, schema.WarningCodePriceUnavailable, warning.Code)
+
+	data := warning.Data.(schema.PriceUnavailableWarningData)
+	assert.Equal(t, "aws_instance.medium", data.ResourceName)
+	assert.Equal(t, cc.Name, data.CostComponentName)
+	assert.Contains(t, data.Error, "503")
+}
+
+func TestPopulatePricesConvertsWithFXRates(t *testing.T) {
+	server := commitmentsTestServer(t)
+	defer server.Close()
+
+	ratesFile := filepath.Join(t.TempDir(), "rates.csv")
+	require.NoError(t, os.WriteFile(ratesFile, []byte("currency,rate,effective_date\nEUR,0.9,2023-06-30\n"), 0600))
+
+	ctx := config.EmptyRunContext()
+	ctx.Config.PricingAPIEndpoint = server.URL
+	ctx.Config.NoCache = true
+	ctx.Config.EventsDisabled = true
+	ctx.Config.Currency = "EUR"
+	ctx.Config.FXRatesFile = ratesFile
+
+	custom := ec2Resource("aws_instance.custom")
+	custom.CostComponents[0].SetCustomPrice(decimalPtr(decimal.NewFromFloat(0.5)))
+
+	project := &schema.Project{
+		Name:      "test",
+		Metadata:  &schema.ProjectMetadata{},
+		Resources: []*schema.Resource{ec2Resource("aws_instance.web"), custom},
+	}
+	require.NoError(t, PopulatePrices(ctx, project))
+
+	assert.Equal(t, "0.09", project.Resources[0].CostComponents[0].Price().String())
+	assert.Equal(t, "0.5", project.Resources[1].CostComponents[0].Price().String())
+
+	ctx.Config.Currency = "JPY"
+	assert.ErrorContains(t, PopulatePrices(ctx, project), "has no rate for JPY")
+}
@@ -96,10 +96,129 @@ func GetResourceRegion(resourceType string, v gjson.Result) string {
 	return p[3]
 }
 
+// taggableResources are the priced resource types that have a tags attribute,
+// or tag blocks, in the AWS provider schema. Resource types that aren't in the
+// set are treated as untaggable so they are never reported by the tag policy,
+// so new resource types need to be added here once they are priced.
+var taggableResources = map[string]bool{
+	"aws_acm_certificate":                        true,
+	"aws_acmpca_certificate_authority":           true,
+	"aws_alb":                                    true,
+	"aws_api_gateway_rest_api":                   true,
+	"aws_api_gateway_stage":                      true,
+	"aws_apigatewayv2_api":                       true,
+	"aws_appautoscaling_target":                  true,
+	"aws_autoscaling_group":                      true,
+	"aws_backup_vault":                           true,
+	"aws_cloudformation_stack":                   true,
+	"aws_cloudformation_stack_set":               true,
+	"aws_cloudfront_distribution":                true,
+	"aws_cloudtrail":                             true,
+	"aws_cloudwatch_event_bus":                   true,
+	"aws_cloudwatch_log_group":                   true,
+	"aws_cloudwatch_metric_alarm":                true,
+	"aws_codebuild_project":                      true,
+	"aws_config_config_rule":                     true,
+	"aws_db_instance":                            true,
+	"aws_directory_service_directory":            true,
+	"aws_dms_replication_instance":               true,
+	"aws_docdb_cluster":                          true,
+	"aws_docdb_cluster_instance":                 true,
+	"aws_dx_connection":                          true,
+	"aws_dynamodb_table":                         true,
+	"aws_ebs_snapshot":                           true,
+	"aws_ebs_snapshot_copy":                      true,
+	"aws_ebs_volume":                             true,
+	"aws_ec2_client_vpn_endpoint":                true,
+	"aws_ec2_host":                               true,
+	"aws_ec2_traffic_mirror_session":             true,
+	"aws_ec2_transit_gateway_peering_attachment": true,
+	"aws_ec2_transit_gateway_vpc_attachment":     true,
+	"aws_ecr_repository":                         true,
+	"aws_ecs_cluster":                            true,
+	"aws_ecs_service":                            true,
+	"aws_ecs_task_definition":                    true,
+	"aws_efs_file_system":                        true,
+	"aws_eip":                                    true,
+	"aws_eks_cluster":                            true,
+	"aws_eks_fargate_profile":                    true,
+	"aws_eks_node_group":                         true,
+	"aws_elastic_beanstalk_environment":          true,
+	"aws_elasticache_cluster":                    true,
+	"aws_elasticache_replication_group":          true,
+	"aws_elasticsearch_domain":                   true,
+	"aws_elb":                                    true,
+	"aws_fsx_openzfs_file_system":                true,
+	"aws_fsx_windows_file_system":                true,
+	"aws_globalaccelerator_accelerator":          true,
+	"aws_glue_catalog_database":                  true,
+	"aws_glue_crawler":                           true,
+	"aws_glue_job":                               true,
+	"aws_instance":                               true,
+	"aws_kinesis_analytics_application":          true,
+	"aws_kinesis_firehose_delivery_stream":       true,
+	"aws_kinesisanalyticsv2_application":         true,
+	"aws_kms_external_key":                       true,
+	"aws_kms_key":                                true,
+	"aws_lambda_function":                        true,
+	"aws_lb":                                     true,
+	"aws_lightsail_instance":                     true,
+	"aws_mq_broker":                              true,
+	"aws_msk_cluster":                            true,
+	"aws_mwaa_environment":                       true,
+	"aws_nat_gateway":                            true,
+	"aws_neptune_cluster":                        true,
+	"aws_neptune_cluster_instance":               true,
+	"aws_networkfirewall_firewall":               true,
+	"aws_rds_cluster":                            true,
+	"aws_rds_cluster_instance":                   true,
+	"aws_redshift_cluster":                       true,
+	"aws_route53_health_check":                   true,
+	"aws_route53_resolver_endpoint":              true,
+	"aws_route53_zone":                           true,
+	"aws_s3_bucket":                              true,
+	"aws_secretsmanager_secret":                  true,
+	"aws_sfn_state_machine":                      true,
+	"aws_sns_topic":                              true,
+	"aws_sqs_queue":                              true,
+	"aws_ssm_activation":                         true,
+	"aws_ssm_parameter":                          true,
+	"aws_transfer_server":                        true,
+	"aws_vpc_endpoint":                           true,
+	"aws_vpn_connection":                         true,
+	"aws_waf_web_acl":                            true,
+	"aws_wafv2_web_acl":                          true,
+}
+
+// ParseTags returns the tags of the resource, or nil if the resource type
+// doesn't support tags. tags_all also has the default tags of the provider so
+// it is used when it's known, i.e. in plans once the tags are known.
+//
+// aws_autoscaling_group sets its tags with repeated tag blocks, or with a tags
+// list of maps on older provider versions, which are merged into the tags.
 func ParseTags(resourceType string, v gjson.Result) map[string]string {
+	if !taggableResources[resourceType] {
+		return nil
+	}
+
 	tags := make(map[string]string)
-	for k, v := range v.Get("tags").Map() {
+	for k, v := range v.Get("tags_all").Map() {
 		tags[k] = v.String()
 	}
+
+	if v.Get("tags").IsArray() {
+		for _, t := range v.Get("tags").Array() {
+			tags[t.Get("key").String()] = t.Get("value").String()
+		}
+	} else {
+		for k, v := range v.Get("tags").Map() {
+			tags[k] = v.String()
+		}
+	}
+
+	for _, t := range v.Get("tag").Array() {
+		tags[t.Get("key").String()] = t.Get("value").String()
+	}
+
 	return tags
 }
@@ -0,0 +1,73 @@
+package aws
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestParseTags(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		resourceType string
+		values       string
+		expected     map[string]string
+	}{
+		{
+			name:         "tags and tags_all",
+			resourceType: "aws_instance",
+			values:       `{"tags": {"team": "web"}, "tags_all": {"env": "prod", "team": "platform"}}`,
+			expected:     map[string]string{"env": "prod", "team": "web"},
+		},
+		{
+			name:         "autoscaling group tag blocks",
+			resourceType: "aws_autoscaling_group",
+			values: `{"tag": [
+				{"key": "env", "value": "prod", "propagate_at_launch": true},
+				{"key": "team", "value": "web", "propagate_at_launch": false}
+			]}`,
+			expected: map[string]string{"env": "prod", "team": "web"},
+		},
+		{
+			name:         "autoscaling group tags list",
+			resourceType: "aws_autoscaling_group",
+			values: `{"tags": [
+				{"key": "env", "value": "prod", "propagate_at_launch": "true"}
+			]}`,
+			expected: map[string]string{"env": "prod"},
+		},
+		{
+			name:         "untaggable resource",
+			resourceType: "aws_route53_record",
+			values:       `{"tags": {"team": "web"}}`,
+			expected:     nil,
+		},
+		{
+			name:         "unknown resource",
+			resourceType: "aws_new_resource",
+			values:       `{"tags": {"team": "web"}}`,
+			expected:     nil,
+		},
+	}
+
+	for _, tt := range tests {
+		actual := ParseTags(tt.resourceType, gjson.Parse(tt.values))
+		assert.Equal(t, tt.expected, actual, tt.name)
+	}
+}
+
+func TestTaggableResourcesAreRegistered(t *testing.T) {
+	t.Parallel()
+
+	registered := make(map[string]bool, len(ResourceRegistry))
+	for _, r := range ResourceRegistry {
+		registered[r.Name] = true
+	}
+
+	for resourceType := range taggableResources {
+		assert.True(t, registered[resourceType], "taggable resource type %s is not registered", resourceType)
+	}
+}
@@ -2,6 +2,7 @@ package aws
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/infracost/infracost/internal/resources/aws"
 	"github.com/infracost/infracost/internal/schema"
@@ -32,7 +33,7 @@ func NewInstance(d *schema.ResourceData) schema.CoreResource {
 	region := d.Get("region").String()
 
 	purchaseOption := "on_demand"
-	if d.Get("spot_price").String() != "" {
+	if d.Get("spot_price").String() != "" || strings.ToLower(d.Get("instance_market_options.0.market_type").String()) == "spot" {
 		purchaseOption = "spot"
 	}
 
@@ -55,6 +56,10 @@ func NewInstance(d *schema.ResourceData) schema.CoreResource {
 		cpuCredits = ref.Get("credit_specification.0.cpu_credits").String()
 		tenancy = ref.Get("placement.0.tenancy").String()
 
+		if strings.ToLower(ref.Get("instance_market_options.0.market_type").String()) == "spot" {
+			purchaseOption = "spot"
+		}
+
 		for _, data := range ref.Get("block_device_mappings").Array() {
 			deviceName := data.Get("device_name").String()
 			ebsBlockDevice := &aws.EBSVolume{
@@ -23,7 +23,49 @@ func GetResourceRegion(resourceType string, v gjson.Result) string {
 	return ""
 }
 
+// untaggableResources are the priced resource types that have no tags attribute
+// in the AzureRM provider schema.
+var untaggableResources = map[string]bool{
+	"azurerm_active_directory_domain_service_replica_set":                           true,
+	"azurerm_app_service_certificate_binding":                                       true,
+	"azurerm_app_service_custom_hostname_binding":                                   true,
+	"azurerm_automation_dsc_nodeconfiguration":                                      true,
+	"azurerm_automation_job_schedule":                                               true,
+	"azurerm_cosmosdb_cassandra_keyspace":                                           true,
+	"azurerm_cosmosdb_cassandra_table":                                              true,
+	"azurerm_cosmosdb_gremlin_database":                                             true,
+	"azurerm_cosmosdb_gremlin_graph":                                                true,
+	"azurerm_cosmosdb_mongo_collection":                                             true,
+	"azurerm_cosmosdb_mongo_database":                                               true,
+	"azurerm_cosmosdb_sql_container":                                                true,
+	"azurerm_cosmosdb_sql_database":                                                 true,
+	"azurerm_cosmosdb_table":                                                        true,
+	"azurerm_data_factory_integration_runtime_azure":                                true,
+	"azurerm_data_factory_integration_runtime_azure_ssis":                           true,
+	"azurerm_data_factory_integration_runtime_managed":                              true,
+	"azurerm_data_factory_integration_runtime_self_hosted":                          true,
+	"azurerm_express_route_connection":                                              true,
+	"azurerm_lb_outbound_rule":                                                      true,
+	"azurerm_lb_rule":                                                               true,
+	"azurerm_sentinel_data_connector_aws_cloud_trail":                               true,
+	"azurerm_sentinel_data_connector_azure_active_directory":                        true,
+	"azurerm_sentinel_data_connector_azure_advanced_threat_protection":              true,
+	"azurerm_sentinel_data_connector_azure_security_center":                         true,
+	"azurerm_sentinel_data_connector_microsoft_cloud_app_security":                  true,
+	"azurerm_sentinel_data_connector_microsoft_defender_advanced_threat_protection": true,
+	"azurerm_sentinel_data_connector_office_365":                                    true,
+	"azurerm_sentinel_data_connector_threat_intelligence":                           true,
+	"azurerm_virtual_network_peering":                                               true,
+	"azurerm_vpn_gateway_connection":                                                true,
+}
+
+// ParseTags returns the tags of the resource, or nil if the resource type
+// doesn't support tags.
 func ParseTags(resourceType string, v gjson.Result) map[string]string {
+	if untaggableResources[resourceType] {
+		return nil
+	}
+
 	tags := make(map[string]string)
 	for k, v := range v.Get("tags").Map() {
 		tags[k] = v.String()
@@ -48,7 +48,7 @@ func aksClusterNodePool(name, region string, n gjson.Result, nodeCount decimal.D
 		Name: name,
 	}
 	instanceType := n.Get("vm_size").String()
-	costComponents = append(costComponents, linuxVirtualMachineCostComponent(region, instanceType, nil))
+	costComponents = append(costComponents, linuxVirtualMachineCostComponent(region, instanceType, n.Get("priority").String(), nil, virtualMachineSpotDiscount(u)))
 	mainResource.CostComponents = costComponents
 	schema.MultiplyQuantities(mainResource, nodeCount)
 
@@ -7,6 +7,7 @@ import (
 	"github.com/infracost/infracost/internal/schema"
 
 	"github.com/shopspring/decimal"
+	log "github.com/sirupsen/logrus"
 )
 
 func GetAzureRMLinuxVirtualMachineRegistryItem() *schema.RegistryItem {
@@ -15,7 +16,8 @@ func GetAzureRMLinuxVirtualMachineRegistryItem() *schema.RegistryItem {
 		RFunc: NewAzureRMLinuxVirtualMachine,
 		Notes: []string{
 			"Non-standard images such as RHEL are not supported.",
-			"Low priority, Spot and Reserved instances are not supported.",
+			"Low priority and Reserved instances are not supported.",
+			"Spot instances are priced with their Spot prices, or with the spot_discount usage value if it's set.",
 		},
 	}
 }
@@ -30,7 +32,7 @@ func NewAzureRMLinuxVirtualMachine(d *schema.ResourceData, u *schema.UsageData)
 		monthlyHours = u.GetFloat("monthly_hrs")
 	}
 
-	costComponents := []*schema.CostComponent{linuxVirtualMachineCostComponent(region, instanceType, monthlyHours)}
+	costComponents := []*schema.CostComponent{linuxVirtualMachineCostComponent(region, instanceType, d.Get("priority").String(), monthlyHours, virtualMachineSpotDiscount(u))}
 
 	if d.Get("additional_capabilities.0.ultra_ssd_enabled").Bool() {
 		costComponents = append(costComponents, ultraSSDReservationCostComponent(region))
@@ -50,7 +52,7 @@ func NewAzureRMLinuxVirtualMachine(d *schema.ResourceData, u *schema.UsageData)
 	}
 }
 
-func linuxVirtualMachineCostComponent(region string, instanceType string, monthlyHours *float64) *schema.CostComponent {
+func linuxVirtualMachineCostComponent(region string, instanceType string, priority string, monthlyHours *float64, spotDiscount *float64) *schema.CostComponent {
 	purchaseOption := "Consumption"
 	purchaseOptionLabel := "pay as you go"
 
@@ -61,12 +63,17 @@ func linuxVirtualMachineCostComponent(region string, instanceType string, monthl
 		instanceType = fmt.Sprintf("Standard_%s", instanceType)
 	}
 
-	qty := decimal.NewFromFloat(730)
+	skuNameRe, spotLabel, multiplier := virtualMachineSpotPricing(priority, spotDiscount, purchaseOptionLabel)
+	if spotLabel != "" {
+		purchaseOptionLabel = spotLabel
+	}
+
+	qty := schema.HourToMonthUnitMultiplier
 	if monthlyHours != nil {
 		qty = decimal.NewFromFloat(*monthlyHours)
 	}
 
-	return &schema.CostComponent{
+	c := &schema.CostComponent{
 		Name:            fmt.Sprintf("Instance usage (%s, %s)", purchaseOptionLabel, instanceType),
 		Unit:            "hours",
 		UnitMultiplier:  decimal.NewFromInt(1),
@@ -78,7 +85,7 @@ func linuxVirtualMachineCostComponent(region string, instanceType string, monthl
 			ProductFamily: strPtr("Compute"),
 			AttributeFilters: []*schema.AttributeFilter{
 				{Key: "meterName", ValueRegex: strPtr("/^(?!.*(Expired|Free)$).*$/i")},
-				{Key: "skuName", ValueRegex: strPtr("/^(?!.*(Low Priority|Spot)$).*$/i")},
+				{Key: "skuName", ValueRegex: strPtr(skuNameRe)},
 				{Key: "armSkuName", ValueRegex: strPtr(fmt.Sprintf("/^%s$/i", instanceType))},
 				{Key: "productName", ValueRegex: strPtr(productNameRe)},
 			},
@@ -88,4 +95,44 @@ func linuxVirtualMachineCostComponent(region string, instanceType string, monthl
 			Unit:           strPtr("1 Hour"),
 		},
 	}
+	c.SetCustomPriceMultiplier(multiplier)
+
+	return c
+}
+
+// virtualMachineSpotPricing returns the skuName filter, the purchase option
+// label and the custom price multiplier for a virtual machine with the given
+// priority. Spot virtual machines are priced with their Spot SKUs, unless a
+// spot_discount is given in the usage file. Then they are priced as a
+// discount of the regular price, which can be used for sizes and regions that
+// don't have Spot prices. The label is empty for regular virtual machines.
+func virtualMachineSpotPricing(priority string, spotDiscount *float64, regularLabel string) (string, string, *decimal.Decimal) {
+	regularSkuNameRe := "/^(?!.*(Low Priority|Spot)$).*$/i"
+
+	if !strings.EqualFold(priority, "spot") {
+		return regularSkuNameRe, "", nil
+	}
+
+	if spotDiscount == nil {
+		return "/ Spot$/i", "spot", nil
+	}
+
+	discount := decimal.NewFromFloat(*spotDiscount)
+	if discount.LessThan(decimal.Zero) || discount.GreaterThan(decimal.NewFromInt(1)) {
+		log.Warnf("Invalid spot_discount %s, it should be between 0 and 1", discount)
+		return "/ Spot$/i", "spot", nil
+	}
+
+	label := fmt.Sprintf("spot, %s%% off %s", discount.Mul(decimal.NewFromInt(100)).Round(2), regularLabel)
+	return regularSkuNameRe, label, decimalPtr(decimal.NewFromInt(1).Sub(discount))
+}
+
+// virtualMachineSpotDiscount returns the spot_discount usage value of a
+// virtual machine or scale set.
+func virtualMachineSpotDiscount(u *schema.UsageData) *float64 {
+	if u == nil {
+		return nil
+	}
+
+	return u.GetFloat("spot_discount")
 }
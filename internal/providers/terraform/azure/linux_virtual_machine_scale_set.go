@@ -10,6 +10,9 @@ func GetAzureRMLinuxVirtualMachineScaleSetRegistryItem() *schema.RegistryItem {
 	return &schema.RegistryItem{
 		Name:  "azurerm_linux_virtual_machine_scale_set",
 		RFunc: NewAzureRMLinuxVirtualMachineScaleSet,
+		Notes: []string{
+			"Spot instances are priced with their Spot prices, or with the spot_discount usage value if it's set.",
+		},
 	}
 }
 
@@ -18,7 +21,7 @@ func NewAzureRMLinuxVirtualMachineScaleSet(d *schema.ResourceData, u *schema.Usa
 
 	instanceType := d.Get("sku").String()
 
-	costComponents := []*schema.CostComponent{linuxVirtualMachineCostComponent(region, instanceType, nil)}
+	costComponents := []*schema.CostComponent{linuxVirtualMachineCostComponent(region, instanceType, d.Get("priority").String(), nil, virtualMachineSpotDiscount(u))}
 	subResources := make([]*schema.Resource, 0)
 
 	if d.Get("additional_capabilities.0.ultra_ssd_enabled").Bool() {
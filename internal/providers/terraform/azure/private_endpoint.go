@@ -79,7 +79,7 @@ func privateEndpointCostComponent(region, name, meterName string) *schema.CostCo
 		Name:            name,
 		Unit:            "hour",
 		UnitMultiplier:  decimal.NewFromInt(1),
-		MonthlyQuantity: decimalPtr(decimal.NewFromInt(730)),
+		MonthlyQuantity: decimalPtr(schema.HourToMonthUnitMultiplier),
 		ProductFilter: &schema.ProductFilter{
 			VendorName:    strPtr("azure"),
 			Region:        strPtr(region),
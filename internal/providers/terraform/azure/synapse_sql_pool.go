@@ -56,7 +56,7 @@ func synapseDedicatedSQLPoolCostComponent(region, name, sku string) *schema.Cost
 		Name:            fmt.Sprintf("%s (%s)", name, sku),
 		Unit:            "hours",
 		UnitMultiplier:  decimal.NewFromInt(1),
-		MonthlyQuantity: decimalPtr(decimal.NewFromInt(730)),
+		MonthlyQuantity: decimalPtr(schema.HourToMonthUnitMultiplier),
 		ProductFilter: &schema.ProductFilter{
 			VendorName:    strPtr("azure"),
 			Region:        strPtr(region),
@@ -10,6 +10,9 @@ func GetAzureRMWindowsVirtualMachineScaleSetRegistryItem() *schema.RegistryItem
 	return &schema.RegistryItem{
 		Name:  "azurerm_windows_virtual_machine_scale_set",
 		RFunc: NewAzureRMWindowsVirtualMachineScaleSet,
+		Notes: []string{
+			"Spot instances are priced with their Spot prices, or with the spot_discount usage value if it's set.",
+		},
 	}
 }
 
@@ -19,7 +22,7 @@ func NewAzureRMWindowsVirtualMachineScaleSet(d *schema.ResourceData, u *schema.U
 	instanceType := d.Get("sku").String()
 	licenseType := d.Get("license_type").String()
 
-	costComponents := []*schema.CostComponent{windowsVirtualMachineCostComponent(region, instanceType, licenseType, nil)}
+	costComponents := []*schema.CostComponent{windowsVirtualMachineCostComponent(region, instanceType, licenseType, d.Get("priority").String(), nil, virtualMachineSpotDiscount(u))}
 
 	if d.Get("additional_capabilities.0.ultra_ssd_enabled").Bool() {
 		costComponents = append(costComponents, ultraSSDReservationCostComponent(region))
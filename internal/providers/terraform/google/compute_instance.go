@@ -1,6 +1,8 @@
 package google
 
 import (
+	"strings"
+
 	"github.com/infracost/infracost/internal/resources/google"
 	"github.com/infracost/infracost/internal/schema"
 	"github.com/tidwall/gjson"
@@ -68,10 +70,10 @@ func newComputeInstance(d *schema.ResourceData, u *schema.UsageData) *schema.Res
 }
 
 // getComputePurchaseOption determines the purchase option for Compute
-// resources.
+// resources. Spot VMs are priced with the same SKUs as preemptible VMs.
 func getComputePurchaseOption(d gjson.Result) string {
 	purchaseOption := "on_demand"
-	if d.Get("scheduling.0.preemptible").Bool() {
+	if d.Get("scheduling.0.preemptible").Bool() || strings.EqualFold(d.Get("scheduling.0.provisioning_model").String(), "SPOT") {
 		purchaseOption = "preemptible"
 	}
 
@@ -30,9 +30,60 @@ func GetResourceRegion(resourceType string, v gjson.Result) string {
 	return ""
 }
 
+// untaggableResources are the priced resource types that have no labels
+// attribute in the Google provider schema.
+var untaggableResources = map[string]bool{
+	"google_compute_instance_group_manager":        true,
+	"google_compute_machine_image":                 true,
+	"google_compute_per_instance_config":           true,
+	"google_compute_region_instance_group_manager": true,
+	"google_compute_region_per_instance_config":    true,
+	"google_compute_region_target_http_proxy":      true,
+	"google_compute_region_target_https_proxy":     true,
+	"google_compute_router_nat":                    true,
+	"google_compute_target_grpc_proxy":             true,
+	"google_compute_target_http_proxy":             true,
+	"google_compute_target_https_proxy":            true,
+	"google_compute_target_ssl_proxy":              true,
+	"google_compute_target_tcp_proxy":              true,
+	"google_compute_vpn_gateway":                   true,
+	"google_container_node_pool":                   true,
+	"google_container_registry":                    true,
+	"google_dns_record_set":                        true,
+	"google_logging_billing_account_bucket_config": true,
+	"google_logging_billing_account_sink":          true,
+	"google_logging_folder_bucket_config":          true,
+	"google_logging_folder_sink":                   true,
+	"google_logging_organization_bucket_config":    true,
+	"google_logging_organization_sink":             true,
+	"google_logging_project_bucket_config":         true,
+	"google_logging_project_sink":                  true,
+	"google_monitoring_metric_descriptor":          true,
+	"google_secret_manager_secret_version":         true,
+	"google_service_networking_connection":         true,
+}
+
+// labelsAttributes are the attributes of the labels of the resource types that
+// don't use the labels attribute.
+var labelsAttributes = map[string]string{
+	"google_container_cluster":     "resource_labels",
+	"google_sql_database_instance": "settings.0.user_labels",
+}
+
+// ParseTags returns the labels of the resource, or nil if the resource type
+// doesn't support labels.
 func ParseTags(resourceType string, v gjson.Result) map[string]string {
+	if untaggableResources[resourceType] {
+		return nil
+	}
+
+	attr, ok := labelsAttributes[resourceType]
+	if !ok {
+		attr = "labels"
+	}
+
 	tags := make(map[string]string)
-	for k, v := range v.Get("labels").Map() {
+	for k, v := range v.Get(attr).Map() {
 		tags[k] = v.String()
 	}
 	return tags
@@ -36,6 +36,7 @@ type HCLProvider struct {
 	logger         *log.Entry
 
 	schema *PlanSchema
+	moves  []hcl.Move
 	ctx    *config.ProjectContext
 	cache  []*hcl.Module
 	config HCLProviderConfig
@@ -44,6 +45,9 @@ type HCLProvider struct {
 type HCLProviderConfig struct {
 	SuppressLogging     bool
 	CacheParsingModules bool
+
+	// remoteStateChain holds the projects whose outputs are being evaluated to resolve remote states.
+	remoteStateChain []string
 }
 
 type flagStringSlice []string
@@ -111,6 +115,19 @@ func NewHCLProvider(ctx *config.ProjectContext, config *HCLProviderConfig, opts
 		options = append(options, withInputVars)
 	}
 
+	if ctx.ProjectConfig.DataStubsFile != "" {
+		stubs, err := hcl.LoadDataStubsFile(ctx.ProjectConfig.DataStubsFile)
+		if err != nil {
+			return nil, err
+		}
+
+		options = append(options, hcl.OptionWithDataStubs(stubs))
+	}
+
+	if len(ctx.ProjectConfig.RemoteStates) > 0 {
+		options = append(options, hcl.OptionWithRemoteStates(newRemoteStates(ctx, config.remoteStateChain)))
+	}
+
 	options = append(options, opts...)
 
 	credsSource, err := modules.NewTerraformCredentialsSource(modules.BaseCredentialSet{
@@ -239,7 +256,11 @@ func (p *HCLProvider) newProject(parsed HCLProject) *schema.Project {
 				Data:    warning.Data,
 			}
 
-			ui.PrintWarning(p.ctx.RunContext.ErrWriter, warning.FriendlyMessage)
+			// evaluation diagnostics are listed by the diagnose command, most projects have attributes that
+			// are only known after apply so printing them on every run would be noise.
+			if warning.Code != hcl.WarningEvaluationDiagnostics {
+				ui.PrintWarning(p.ctx.RunContext.ErrWriter, warning.FriendlyMessage)
+			}
 		}
 
 		metadata.Warnings = warnings
@@ -374,6 +395,7 @@ func (p *HCLProvider) InvalidateCache() *HCLProvider {
 }
 
 func (p *HCLProvider) newPlanSchema() {
+	p.moves = nil
 	p.schema = &PlanSchema{
 		FormatVersion:    "1.0",
 		TerraformVersion: "1.1.0",
@@ -426,6 +448,10 @@ func (p *HCLProvider) marshalModule(module *hcl.Module) ModuleOut {
 		}
 	}
 
+	// Moves are collected before the resources are marshalled since moved
+	// blocks can refer to resources of the module and its child modules.
+	p.moves = append(p.moves, module.Moves()...)
+
 	configResources := map[string]struct{}{}
 	for _, block := range module.Blocks {
 		if block.Type() == "resource" {
@@ -472,8 +498,9 @@ func (p *HCLProvider) getResourceOutput(block *hcl.Block) ResourceOutput {
 		Index:         block.Index(),
 		SchemaVersion: 0,
 		InfracostMetadata: map[string]interface{}{
-			"filename": block.Filename,
-			"calls":    block.CallDetails(),
+			"filename":  block.Filename,
+			"startLine": block.StartLine(),
+			"calls":     block.CallDetails(),
 		},
 	}
 
@@ -487,6 +514,7 @@ func (p *HCLProvider) getResourceOutput(block *hcl.Block) ResourceOutput {
 		Change: ResourceChange{
 			Actions: []string{"create"},
 		},
+		PreviousAddress: p.previousAddress(block.FullName()),
 	}
 
 	jsonValues := marshalAttributeValues(block.Type(), block.Values())
@@ -540,6 +568,49 @@ func (p *HCLProvider) getResourceOutput(block *hcl.Block) ResourceOutput {
 	}
 }
 
+// previousAddress returns the address the resource had before the moves were
+// applied, or an empty string if it hasn't been moved. Moves of a module apply
+// to all the resources in it, and chained moves are followed back to the first
+// address.
+func (p *HCLProvider) previousAddress(addr string) string {
+	prev := addr
+
+	for i := 0; i < len(p.moves); i++ {
+		moved := false
+		for _, m := range p.moves {
+			if rest, ok := addressWithin(prev, m.To); ok {
+				prev = m.From + rest
+				moved = true
+				break
+			}
+		}
+
+		if !moved {
+			break
+		}
+	}
+
+	if prev == addr {
+		return ""
+	}
+
+	return prev
+}
+
+// addressWithin returns the rest of addr after prefix if addr is prefix or an
+// address within it, e.g. a resource of a module or an instance of a resource.
+func addressWithin(addr, prefix string) (string, bool) {
+	if addr == prefix {
+		return "", true
+	}
+
+	if strings.HasPrefix(addr, prefix) && (addr[len(prefix)] == '.' || addr[len(prefix)] == '[') {
+		return addr[len(prefix):], true
+	}
+
+	return "", false
+}
+
 func (p *HCLProvider) marshalProviderBlock(block *hcl.Block) string {
 	name := block.TypeLabel()
 	if a := block.GetAttribute("alias"); a != nil {
@@ -548,18 +619,58 @@ func (p *HCLProvider) marshalProviderBlock(block *hcl.Block) string {
 
 	region := block.GetAttribute("region").AsString()
 
-	p.schema.Configuration.ProviderConfig[name] = ProviderConfig{
-		Name: name,
-		Expressions: map[string]interface{}{
-			"region": map[string]interface{}{
-				"constant_value": region,
-			},
+	expressions := map[string]interface{}{
+		"region": map[string]interface{}{
+			"constant_value": region,
 		},
 	}
 
+	// The default tags of the AWS provider are a nested block and the default
+	// labels of the Google provider are an attribute, the same as they are in
+	// the provider config of a plan JSON.
+	if b := block.GetChildBlock("default_tags"); b != nil {
+		if tags := constantValue(b.GetAttribute("tags")); tags != nil {
+			expressions["default_tags"] = []interface{}{
+				map[string]interface{}{"tags": tags},
+			}
+		}
+	}
+
+	if labels := constantValue(block.GetAttribute("default_labels")); labels != nil {
+		expressions["default_labels"] = labels
+	}
+
+	p.schema.Configuration.ProviderConfig[name] = ProviderConfig{
+		Name:        name,
+		Expressions: expressions,
+	}
+
 	return name
 }
 
+// constantValue returns the value of the attribute as a plan JSON constant_value
+// expression, or nil if the attribute isn't set or its value isn't known.
+func constantValue(attr *hcl.Attribute) map[string]interface{} {
+	if attr == nil {
+		return nil
+	}
+
+	v := attr.Value()
+	if v == cty.NilVal || v.IsNull() || !v.IsWhollyKnown() {
+		return nil
+	}
+
+	b, err := ctyJson.Marshal(v, v.Type())
+	if err != nil {
+		logging.Logger.WithError(err).Debugf("could not marshal attribute %s", attr.Name())
+		return nil
+	}
+
+	return map[string]interface{}{
+		"constant_value": json.RawMessage(b),
+	}
+}
+
 func (p *HCLProvider) countReferences(block *hcl.Block) *countExpression {
 	for _, attribute := range block.GetAttributes() {
 		name := attribute.Name()
@@ -711,6 +822,8 @@ type ResourceChangesJSON struct {
 	Name          string         `json:"name"`
 	Index         *int64         `json:"index,omitempty"`
 	Change        ResourceChange `json:"change"`
+	// PreviousAddress is only set if the resource has been moved
+	PreviousAddress string `json:"previous_address,omitempty"`
 }
 
 type ResourceChange struct {
@@ -102,6 +102,7 @@ func TestHCLProvider_LoadPlanJSON(t *testing.T) {
 					"arn": "svc-2-arn",
 				},
 			},
+			warnings: []hcl.WarningCode{hcl.WarningEvaluationDiagnostics},
 		},
 		{
 			name: "renders multiple count resources correctly",
@@ -157,6 +158,27 @@ func TestHCLProvider_LoadPlanJSON(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "renders moved resources",
+			attrs: map[string]map[string]string{
+				"aws_eip.new": {
+					"id":  "eip-new",
+					"arn": "eip-new-arn",
+				},
+				"aws_eip.unchanged": {
+					"id":  "eip-unchanged",
+					"arn": "eip-unchanged-arn",
+				},
+				"module.web.aws_eip.eip": {
+					"id":  "eip-web",
+					"arn": "eip-web-arn",
+				},
+				"module.db.aws_eip.eip": {
+					"id":  "eip-db",
+					"arn": "eip-db-arn",
+				},
+			},
+		},
 		{
 			name: "does not panic on double attribute definition",
 			attrs: map[string]map[string]string{
@@ -3,6 +3,7 @@ package ibm
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
 
 	"github.com/infracost/infracost/internal/schema"
 	"github.com/tidwall/gjson"
@@ -33,8 +34,18 @@ func GetResourceRegion(resourceType string, v gjson.Result) string {
 	return ""
 }
 
+// ParseTags returns the labels of the resource merged with its tags. IBM Cloud
+// tags are a list of strings that are either key:value pairs or just a key.
 func ParseTags(resourceType string, v gjson.Result) map[string]string {
 	tags := make(map[string]string)
+	for _, t := range v.Get("tags").Array() {
+		p := strings.SplitN(t.String(), ":", 2)
+		if len(p) == 2 {
+			tags[p[0]] = p[1]
+		} else {
+			tags[p[0]] = ""
+		}
+	}
 	for k, v := range v.Get("labels").Map() {
 		tags[k] = v.String()
 	}
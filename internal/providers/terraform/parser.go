@@ -64,7 +64,7 @@ func (p *Parser) createPartialResource(d *schema.ResourceData, u *schema.UsageDa
 		if registryItem.CoreRFunc != nil {
 			coreRes := registryItem.CoreRFunc(d)
 			if coreRes != nil {
-				return &schema.PartialResource{ResourceData: d, CoreResource: coreRes, CloudResourceIDs: registryItem.CloudResourceIDFunc(d)}
+				return &schema.PartialResource{ResourceData: d, CoreResource: coreRes, CloudResourceIDs: registryItem.CloudResourceIDFunc(d), WithUsage: p.withUsageFunc(d)}
 			}
 		} else {
 			res := registryItem.RFunc(d, u)
@@ -73,7 +73,7 @@ func (p *Parser) createPartialResource(d *schema.ResourceData, u *schema.UsageDa
 					res.EstimationSummary = u.CalcEstimationSummary()
 				}
 
-				return &schema.PartialResource{ResourceData: d, Resource: res, CloudResourceIDs: registryItem.CloudResourceIDFunc(d)}
+				return &schema.PartialResource{ResourceData: d, Resource: res, CloudResourceIDs: registryItem.CloudResourceIDFunc(d), WithUsage: p.withUsageFunc(d)}
 			}
 		}
 	}
@@ -88,6 +88,17 @@ func (p *Parser) createPartialResource(d *schema.ResourceData, u *schema.UsageDa
 	}
 }
 
+// withUsageFunc returns a function that creates the PartialResource for a copy of
+// the ResourceData with different usage data. This is used to price the resource
+// with each of its usage scenarios.
+func (p *Parser) withUsageFunc(d *schema.ResourceData) func(u *schema.UsageData) *schema.PartialResource {
+	return func(u *schema.UsageData) *schema.PartialResource {
+		rd := *d
+		rd.UsageData = u
+		return p.createPartialResource(&rd, u)
+	}
+}
+
 func (p *Parser) parseJSONResources(parsePrior bool, baseResources []*schema.PartialResource, usage map[string]*schema.UsageData, parsed, providerConf, conf, vars gjson.Result) []*schema.PartialResource {
 	var resources []*schema.PartialResource
 	resources = append(resources, baseResources...)
@@ -166,13 +177,16 @@ func (p *Parser) parseJSON(j []byte, usage map[string]*schema.UsageData) ([]*sch
 	conf := parsed.Get("configuration.root_module")
 	vars := parsed.Get("variables")
 
+	resourceChanges := parsed.Get("resource_changes").Array()
+
 	resources := p.parseJSONResources(false, baseResources, usage, parsed, providerConf, conf, vars)
+	setPreviousAddresses(resources, resourceChanges)
+	setReplacements(resources, resourceChanges)
 	if !p.includePastResources {
 		return nil, resources, nil
 	}
 
 	pastResources := p.parseJSONResources(true, baseResources, usage, parsed, providerConf, conf, vars)
-	resourceChanges := parsed.Get("resource_changes").Array()
 	pastResources = stripNonTargetResources(pastResources, resources, resourceChanges)
 
 	return pastResources, resources, nil
@@ -226,6 +240,10 @@ func stripNonTargetResources(pastResources []*schema.PartialResource, resources
 	diffAddrMap := make(map[string]bool, len(resourceChanges))
 	for _, change := range resourceChanges {
 		diffAddrMap[change.Get("address").String()] = true
+
+		if prev := change.Get("previous_address").String(); prev != "" {
+			diffAddrMap[prev] = true
+		}
 	}
 
 	var filteredResources []*schema.PartialResource
@@ -239,6 +257,71 @@ func stripNonTargetResources(pastResources []*schema.PartialResource, resources
 	return filteredResources
 }
 
+// setPreviousAddresses sets the previous address of the resources that have
+// been moved, which the resource_changes in the plan have when the address of
+// a resource is changed with a moved block.
+func setPreviousAddresses(resources []*schema.PartialResource, resourceChanges []gjson.Result) {
+	previousAddrs := make(map[string]string)
+	for _, change := range resourceChanges {
+		prev := change.Get("previous_address").String()
+		if prev != "" {
+			previousAddrs[change.Get("address").String()] = prev
+		}
+	}
+
+	if len(previousAddrs) == 0 {
+		return
+	}
+
+	for _, r := range resources {
+		if prev, ok := previousAddrs[r.ResourceData.Address]; ok {
+			r.ResourceData.PreviousAddress = prev
+		}
+	}
+}
+
+// setReplacements sets how the resources that the plan replaces are replaced.
+// Terraform orders the actions of a replace by the create_before_destroy
+// lifecycle setting of the resource, so ["create","delete"] means the new
+// resource is created before the old one is destroyed and ["delete","create"]
+// means it is destroyed first.
+func setReplacements(resources []*schema.PartialResource, resourceChanges []gjson.Result) {
+	replacements := make(map[string]string)
+	for _, change := range resourceChanges {
+		if replacement := replacementForActions(change.Get("change.actions").Array()); replacement != "" {
+			replacements[change.Get("address").String()] = replacement
+		}
+	}
+
+	if len(replacements) == 0 {
+		return
+	}
+
+	for _, r := range resources {
+		if replacement, ok := replacements[r.ResourceData.Address]; ok {
+			r.ResourceData.Replacement = replacement
+		}
+	}
+}
+
+// replacementForActions returns the replacement for the actions of a resource
+// change, or an empty string if the actions aren't a replace.
+func replacementForActions(actions []gjson.Result) string {
+	if len(actions) != 2 {
+		return ""
+	}
+
+	first, second := actions[0].String(), actions[1].String()
+	switch {
+	case first == "create" && second == "delete":
+		return schema.ReplacementCreateBeforeDestroy
+	case first == "delete" && second == "create":
+		return schema.ReplacementDestroyBeforeCreate
+	}
+
+	return ""
+}
+
 func (p *Parser) parseResourceData(isState bool, providerConf, planVals gjson.Result, conf gjson.Result, vars gjson.Result) map[string]*schema.ResourceData {
 	resources := make(map[string]*schema.ResourceData)
 
@@ -284,6 +367,13 @@ func (p *Parser) parseResourceData(isState bool, providerConf, planVals gjson.Re
 		v = schema.AddRawValue(v, "region", region)
 
 		tags := parseTags(t, v)
+		if tags != nil {
+			for k, val := range providerDefaultTags(providerConf, t, resConf) {
+				if _, ok := tags[k]; !ok {
+					tags[k] = val
+				}
+			}
+		}
 
 		data := schema.NewResourceData(t, provider, addr, tags, v)
 		data.Metadata = r.Get("infracost_metadata").Map()
@@ -336,6 +426,34 @@ func parseTags(resourceType string, v gjson.Result) map[string]string {
 	}
 }
 
+// providerDefaultTags returns the tags that the provider of the resource adds
+// to all the resources it manages, i.e. the default_tags of the AWS provider
+// and the default_labels of the Google provider. Only constant values are
+// supported.
+func providerDefaultTags(providerConf gjson.Result, resourceType string, resConf gjson.Result) map[string]string {
+	var path string
+	switch getProviderPrefix(resourceType) {
+	case "aws":
+		path = "expressions.default_tags.0.tags.constant_value"
+	case "google":
+		path = "expressions.default_labels.constant_value"
+	default:
+		return nil
+	}
+
+	providerKey := parseProviderKey(resConf)
+	if providerKey == "" || !providerConf.Get(gjsonEscape(providerKey)).Exists() {
+		providerKey = getProviderPrefix(resourceType)
+	}
+
+	tags := make(map[string]string)
+	for k, v := range providerConf.Get(fmt.Sprintf("%s.%s", gjsonEscape(providerKey), path)).Map() {
+		tags[k] = v.String()
+	}
+
+	return tags
+}
+
 func overrideRegion(addr string, resourceType string, config *config.Config) string {
 	region := ""
 	providerPrefix := getProviderPrefix(resourceType)
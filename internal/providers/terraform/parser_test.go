@@ -6,6 +6,7 @@ import (
 	"github.com/shopspring/decimal"
 	log "github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/tidwall/gjson"
 
 	"github.com/infracost/infracost/internal/config"
@@ -675,6 +676,166 @@ func TestParseResourceData(t *testing.T) {
 	}
 }
 
+func TestParseResourceData_defaultTags(t *testing.T) {
+	providerConf := gjson.Result{
+		Type: gjson.JSON,
+		Raw: `{
+			"aws": {
+				"name": "aws",
+				"expressions": {
+					"region": {
+						"constant_value": "us-west-2"
+					},
+					"default_tags": [
+						{
+							"tags": {
+								"constant_value": {"env": "prod", "team": "platform"}
+							}
+						}
+					]
+				}
+			}
+		}`,
+	}
+
+	planVals := gjson.Result{
+		Type: gjson.JSON,
+		Raw: `{
+			"resources": [
+				{
+					"address": "aws_instance.instance1",
+					"mode": "managed",
+					"type": "aws_instance",
+					"name": "instance1",
+					"provider_name": "registry.terraform.io/hashicorp/aws",
+					"values": {"tags": {"team": "web"}}
+				},
+				{
+					"address": "aws_instance.instance2",
+					"mode": "managed",
+					"type": "aws_instance",
+					"name": "instance2",
+					"provider_name": "registry.terraform.io/hashicorp/aws",
+					"values": {"tags_all": {"env": "dev", "team": "data"}}
+				},
+				{
+					"address": "aws_route53_record.record",
+					"mode": "managed",
+					"type": "aws_route53_record",
+					"name": "record",
+					"provider_name": "registry.terraform.io/hashicorp/aws",
+					"values": {}
+				}
+			]
+		}`,
+	}
+
+	conf := gjson.Result{
+		Type: gjson.JSON,
+		Raw: `{
+			"resources": [
+				{"address": "aws_instance.instance1", "provider_config_key": "aws"},
+				{"address": "aws_instance.instance2", "provider_config_key": "aws"},
+				{"address": "aws_route53_record.record", "provider_config_key": "aws"}
+			]
+		}`,
+	}
+
+	p := NewParser(config.NewProjectContext(config.EmptyRunContext(), &config.Project{}, log.Fields{}), true)
+	actual := p.parseResourceData(false, providerConf, planVals, conf, gjson.Result{})
+
+	assert.Equal(t, map[string]string{"env": "prod", "team": "web"}, actual["aws_instance.instance1"].Tags)
+	assert.Equal(t, map[string]string{"env": "dev", "team": "data"}, actual["aws_instance.instance2"].Tags)
+	assert.Nil(t, actual["aws_route53_record.record"].Tags)
+}
+
+func TestParseJSON_previousAddress(t *testing.T) {
+	plan := `{
+		"format_version": "1.1",
+		"terraform_version": "1.5.0",
+		"planned_values": {
+			"root_module": {
+				"resources": [
+					{"address": "module.web.aws_eip.eip", "mode": "managed", "type": "aws_eip", "name": "eip", "provider_name": "registry.terraform.io/hashicorp/aws", "values": {}},
+					{"address": "aws_eip.unchanged", "mode": "managed", "type": "aws_eip", "name": "unchanged", "provider_name": "registry.terraform.io/hashicorp/aws", "values": {}}
+				]
+			}
+		},
+		"prior_state": {
+			"values": {
+				"root_module": {
+					"resources": [
+						{"address": "aws_eip.web", "mode": "managed", "type": "aws_eip", "name": "web", "provider_name": "registry.terraform.io/hashicorp/aws", "values": {}},
+						{"address": "aws_eip.unchanged", "mode": "managed", "type": "aws_eip", "name": "unchanged", "provider_name": "registry.terraform.io/hashicorp/aws", "values": {}}
+					]
+				}
+			}
+		},
+		"resource_changes": [
+			{"address": "module.web.aws_eip.eip", "previous_address": "aws_eip.web", "mode": "managed", "type": "aws_eip", "name": "eip", "change": {"actions": ["no-op"]}},
+			{"address": "aws_eip.unchanged", "mode": "managed", "type": "aws_eip", "name": "unchanged", "change": {"actions": ["no-op"]}}
+		]
+	}`
+
+	p := NewParser(config.NewProjectContext(config.EmptyRunContext(), &config.Project{}, log.Fields{}), true)
+	past, current, err := p.parseJSON([]byte(plan), map[string]*schema.UsageData{})
+	require.NoError(t, err)
+
+	previousAddrs := make(map[string]string)
+	for _, r := range current {
+		previousAddrs[r.ResourceData.Address] = r.ResourceData.PreviousAddress
+	}
+	assert.Equal(t, map[string]string{"module.web.aws_eip.eip": "aws_eip.web", "aws_eip.unchanged": ""}, previousAddrs)
+
+	// The past resource at the previous address isn't stripped as a non-target resource
+	pastAddrs := make([]string, 0, len(past))
+	for _, r := range past {
+		pastAddrs = append(pastAddrs, r.ResourceData.Address)
+	}
+	assert.ElementsMatch(t, []string{"aws_eip.web", "aws_eip.unchanged"}, pastAddrs)
+
+	for _, r := range current {
+		if r.ResourceData.Address == "module.web.aws_eip.eip" {
+			assert.Equal(t, "aws_eip.web", schema.BuildResource(r, nil).MovedFrom)
+		}
+	}
+}
+
+func TestParseJSON_replacements(t *testing.T) {
+	plan := `{
+		"format_version": "1.1",
+		"terraform_version": "1.5.0",
+		"planned_values": {
+			"root_module": {
+				"resources": [
+					{"address": "aws_eip.cbd", "mode": "managed", "type": "aws_eip", "name": "cbd", "provider_name": "registry.terraform.io/hashicorp/aws", "values": {}},
+					{"address": "aws_eip.dbc", "mode": "managed", "type": "aws_eip", "name": "dbc", "provider_name": "registry.terraform.io/hashicorp/aws", "values": {}},
+					{"address": "aws_eip.updated", "mode": "managed", "type": "aws_eip", "name": "updated", "provider_name": "registry.terraform.io/hashicorp/aws", "values": {}}
+				]
+			}
+		},
+		"resource_changes": [
+			{"address": "aws_eip.cbd", "mode": "managed", "type": "aws_eip", "name": "cbd", "change": {"actions": ["create", "delete"]}},
+			{"address": "aws_eip.dbc", "mode": "managed", "type": "aws_eip", "name": "dbc", "change": {"actions": ["delete", "create"]}},
+			{"address": "aws_eip.updated", "mode": "managed", "type": "aws_eip", "name": "updated", "change": {"actions": ["update"]}}
+		]
+	}`
+
+	p := NewParser(config.NewProjectContext(config.EmptyRunContext(), &config.Project{}, log.Fields{}), false)
+	_, current, err := p.parseJSON([]byte(plan), map[string]*schema.UsageData{})
+	require.NoError(t, err)
+
+	replacements := make(map[string]string)
+	for _, r := range current {
+		replacements[r.ResourceData.Address] = schema.BuildResource(r, nil).Replacement
+	}
+	assert.Equal(t, map[string]string{
+		"aws_eip.cbd":     schema.ReplacementCreateBeforeDestroy,
+		"aws_eip.dbc":     schema.ReplacementDestroyBeforeCreate,
+		"aws_eip.updated": "",
+	}, replacements)
+}
+
 func TestParseReferences_plan(t *testing.T) {
 	vol1 := schema.NewResourceData(
 		"aws_ebs_volume",
@@ -0,0 +1,102 @@
+package terraform
+
+import (
+	"regexp"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+var (
+	// awsRegionRegex matches AWS regions, e.g. us-east-1, us-gov-west-1 or us-west-2-lax-1.
+	awsRegionRegex = regexp.MustCompile(`^[a-z]{2}(-[a-z0-9]+)*-\d+$`)
+	// googleRegionRegex matches Google regions, e.g. us-central1 or europe-west1.
+	googleRegionRegex = regexp.MustCompile(`^[a-z]+-[a-z]+\d+$`)
+	// azureRegionRegex matches Azure regions, e.g. eastus2 or westeurope.
+	azureRegionRegex = regexp.MustCompile(`^[a-z][a-z0-9]*$`)
+)
+
+// RegionProvider returns the provider prefix of the resource types that the
+// region belongs to, i.e. aws, azurerm or google, based on the naming of the
+// regions of the providers. It returns an empty string if the region doesn't
+// follow the naming of any of them.
+func RegionProvider(region string) string {
+	switch {
+	case awsRegionRegex.MatchString(region):
+		return "aws"
+	case googleRegionRegex.MatchString(region):
+		return "google"
+	case azureRegionRegex.MatchString(region):
+		return "azurerm"
+	}
+
+	return ""
+}
+
+// ProjectInRegion returns a new project with the partial resources of project
+// rebuilt from their parsed resource data as if they were deployed in region.
+// The region is set the same way that INFRACOST_AWS_OVERRIDE_REGION,
+// INFRACOST_AZURE_OVERRIDE_REGION and INFRACOST_GOOGLE_OVERRIDE_REGION set it
+// when the project is parsed, so the Terraform code doesn't need to be parsed
+// again. Only the resources of the provider that the region belongs to are
+// priced in the region. The resources of the other cloud providers, and
+// CloudFormation resources, can't be priced in the region so they aren't
+// added to the project and their addresses are returned instead. Resources
+// that aren't priced by region are kept as they are.
+func ProjectInRegion(ctx *config.ProjectContext, project *schema.Project, region string) (*schema.Project, []string) {
+	p := NewParser(ctx, false)
+
+	overrideConfig := &config.Config{}
+	switch RegionProvider(region) {
+	case "aws":
+		overrideConfig.AWSOverrideRegion = region
+	case "azurerm":
+		overrideConfig.AzureOverrideRegion = region
+	case "google":
+		overrideConfig.GoogleOverrideRegion = region
+	}
+
+	// Each region is priced separately, so the warnings that pricing adds to the
+	// metadata of a region project mustn't be added to the original project or
+	// the other region projects.
+	metadata := *project.Metadata
+	metadata.Warnings = append([]schema.Warning{}, project.Metadata.Warnings...)
+
+	regionProject := schema.NewProject(project.Name, &metadata)
+	regionProject.Commitments = project.Commitments
+
+	var notCompared []string
+
+	for _, partial := range project.PartialResources {
+		d := partial.ResourceData
+
+		if d.CFResource != nil {
+			notCompared = append(notCompared, d.Address)
+			continue
+		}
+
+		r := overrideRegion(d.Address, d.Type, overrideConfig)
+		if r == "" {
+			switch getProviderPrefix(d.Type) {
+			case "aws", "azurerm", "google":
+				notCompared = append(notCompared, d.Address)
+			default:
+				regionProject.PartialResources = append(regionProject.PartialResources, partial)
+			}
+
+			continue
+		}
+
+		regionData := *d
+		regionData.RawValues = schema.AddRawValue(d.RawValues, "region", r)
+
+		// Azure resources look up their location before the provider region
+		if getProviderPrefix(d.Type) == "azurerm" {
+			regionData.RawValues = schema.AddRawValue(regionData.RawValues, "location", r)
+		}
+
+		regionProject.PartialResources = append(regionProject.PartialResources, p.createPartialResource(&regionData, regionData.UsageData))
+	}
+
+	return regionProject, notCompared
+}
@@ -0,0 +1,90 @@
+package terraform
+
+import (
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func TestRegionProvider(t *testing.T) {
+	tests := map[string]string{
+		"us-east-1":       "aws",
+		"us-gov-west-1":   "aws",
+		"us-west-2-lax-1": "aws",
+		"me-central-1":    "aws",
+		"us-central1":     "google",
+		"me-central1":     "google",
+		"europe-west1":    "google",
+		"westeurope":      "azurerm",
+		"eastus2":         "azurerm",
+		"US East":         "",
+	}
+
+	for region, expected := range tests {
+		assert.Equal(t, expected, RegionProvider(region), region)
+	}
+}
+
+func TestProjectInRegion(t *testing.T) {
+	ctx := config.NewProjectContext(config.EmptyRunContext(), &config.Project{}, log.Fields{})
+	p := NewParser(ctx, false)
+
+	resourceData := []*schema.ResourceData{
+		schema.NewResourceData("aws_instance", "aws", "aws_instance.web", nil, gjson.Parse(`{"region":"us-east-1","instance_type":"m5.large"}`)),
+		schema.NewResourceData("google_compute_address", "google", "google_compute_address.ip", nil, gjson.Parse(`{"region":"us-central1","address_type":"EXTERNAL"}`)),
+		schema.NewResourceData("azurerm_public_ip", "azurerm", "azurerm_public_ip.ip", nil, gjson.Parse(`{"region":"eastus","location":"eastus","sku":"Basic","allocation_method":"Static"}`)),
+		schema.NewResourceData("fake_resource", "fake", "fake_resource.fake", nil, gjson.Parse(`{"region":"us-east-1"}`)),
+	}
+
+	project := schema.NewProject("test", &schema.ProjectMetadata{
+		Path:     "test",
+		Warnings: []schema.Warning{{Code: 1, Message: "parse warning"}},
+	})
+	for _, d := range resourceData {
+		project.PartialResources = append(project.PartialResources, p.createPartialResource(d, nil))
+	}
+
+	tests := []struct {
+		region      string
+		compared    string
+		notCompared []string
+	}{
+		{"eu-west-1", "aws_instance.web", []string{"google_compute_address.ip", "azurerm_public_ip.ip"}},
+		{"europe-west1", "google_compute_address.ip", []string{"aws_instance.web", "azurerm_public_ip.ip"}},
+		{"westeurope", "azurerm_public_ip.ip", []string{"aws_instance.web", "google_compute_address.ip"}},
+	}
+
+	for _, tt := range tests {
+		regionProject, notCompared := ProjectInRegion(ctx, project, tt.region)
+		schema.BuildResources([]*schema.Project{regionProject}, nil)
+
+		assert.Equal(t, "test", regionProject.Name)
+		assert.Equal(t, tt.notCompared, notCompared, tt.region)
+
+		require.Len(t, regionProject.Resources, 2, tt.region)
+		r := regionProject.Resources[0]
+		assert.Equal(t, tt.compared, r.Name)
+		require.NotEmpty(t, r.CostComponents, r.Name)
+		assert.Equal(t, tt.region, *r.CostComponents[0].ProductFilter.Region, r.Name)
+
+		assert.Same(t, project.PartialResources[3], regionProject.PartialResources[1])
+
+		// Warnings added while pricing one region aren't added to the others
+		assert.Equal(t, "test", regionProject.Metadata.Path)
+		assert.Equal(t, project.Metadata.Warnings, regionProject.Metadata.Warnings)
+		regionProject.Metadata.Warnings = append(regionProject.Metadata.Warnings, schema.Warning{Code: 2, Message: tt.region})
+	}
+
+	assert.Len(t, project.Metadata.Warnings, 1)
+
+	// The parsed resource data isn't modified
+	assert.Equal(t, "us-east-1", resourceData[0].Get("region").String())
+	assert.Equal(t, "us-central1", resourceData[1].Get("region").String())
+	assert.Equal(t, "eastus", resourceData[2].Get("location").String())
+}
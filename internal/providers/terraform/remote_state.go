@@ -0,0 +1,110 @@
+package terraform
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/hcl"
+)
+
+type projectOutputs struct {
+	value cty.Value
+	err   error
+}
+
+// newRemoteStates returns the hcl.RemoteStates that resolve the terraform_remote_state data blocks
+// of the project from the state files and projects that they are mapped to in the config file. The
+// outputs of a project are evaluated with its own HCLProvider. chain holds the projects that are
+// already being evaluated, so that cycles between the projects are detected.
+func newRemoteStates(ctx *config.ProjectContext, chain []string) *hcl.RemoteStates {
+	remoteStates := &hcl.RemoteStates{
+		StateFiles: make(map[string]string),
+		Projects:   make(map[string]string),
+	}
+
+	for addr, state := range ctx.ProjectConfig.RemoteStates {
+		if state.StateFile != "" {
+			remoteStates.StateFiles[addr] = state.StateFile
+			continue
+		}
+
+		remoteStates.Projects[addr] = state.Project
+	}
+
+	if len(remoteStates.Projects) == 0 {
+		return remoteStates
+	}
+
+	chain = append(chain[:len(chain):len(chain)], projectRef(ctx.ProjectConfig))
+	logger := ctx.Logger()
+
+	var mu sync.Mutex
+	cache := make(map[string]projectOutputs)
+	remoteStates.ProjectOutputs = func(project string) (cty.Value, error) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if outputs, ok := cache[project]; ok {
+			return outputs.value, outputs.err
+		}
+
+		value, err := loadProjectOutputs(ctx, project, chain)
+		if err != nil {
+			logger.WithError(err).Warnf("could not resolve terraform_remote_state outputs from project %s", project)
+		}
+
+		cache[project] = projectOutputs{value: value, err: err}
+		return value, err
+	}
+
+	return remoteStates
+}
+
+// loadProjectOutputs evaluates the output blocks of the project in the config file.
+func loadProjectOutputs(ctx *config.ProjectContext, project string, chain []string) (cty.Value, error) {
+	projectCfg := config.FindProject(ctx.RunContext.Config.Projects, project)
+	if projectCfg == nil {
+		return cty.NilVal, fmt.Errorf("project %s is not in the config file", project)
+	}
+
+	ref := projectRef(projectCfg)
+	for _, visited := range chain {
+		if visited == ref {
+			return cty.NilVal, fmt.Errorf("remote state cycle detected between projects %s", strings.Join(append(chain, ref), " -> "))
+		}
+	}
+
+	fields := ctx.Logger().WithField("remote_state_project", ref).Data
+
+	h, err := NewHCLProvider(
+		config.NewProjectContext(ctx.RunContext, projectCfg, fields),
+		&HCLProviderConfig{SuppressLogging: true, remoteStateChain: chain},
+	)
+	if err != nil {
+		return cty.NilVal, fmt.Errorf("could not create provider for project %s %w", ref, err)
+	}
+
+	mods, err := h.Modules()
+	if err != nil {
+		return cty.NilVal, fmt.Errorf("could not evaluate project %s %w", ref, err)
+	}
+
+	if len(mods) != 1 {
+		return cty.NilVal, fmt.Errorf("project %s must have a single Terraform root module to resolve its outputs, found %d", ref, len(mods))
+	}
+
+	return mods[0].Blocks.Outputs(true), nil
+}
+
+// projectRef returns the name of the project, or its path if it doesn't have a name.
+func projectRef(projectCfg *config.Project) string {
+	if projectCfg.Name != "" {
+		return projectCfg.Name
+	}
+
+	return projectCfg.Path
+}
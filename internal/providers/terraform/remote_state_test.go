@@ -0,0 +1,78 @@
+package terraform
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infracost/infracost/internal/config"
+)
+
+func TestRemoteStatesFromProjects(t *testing.T) {
+	testPath := filepath.Join("testdata", "remote_state_test")
+	// the module loader writes a modules manifest to the repo path
+	t.Cleanup(func() {
+		_ = os.RemoveAll(filepath.Join(testPath, config.InfracostDir))
+	})
+
+	runCtx := config.EmptyRunContext()
+	runCtx.Config.RootPath = testPath
+	runCtx.Config.Projects = []*config.Project{
+		{
+			Name: "network",
+			Path: filepath.Join(testPath, "network"),
+		},
+		{
+			Name: "app",
+			Path: filepath.Join(testPath, "app"),
+			RemoteStates: map[string]config.RemoteState{
+				"data.terraform_remote_state.network": {Project: "network"},
+			},
+		},
+		{
+			Name: "cycle_a",
+			Path: filepath.Join(testPath, "cycle_a"),
+			RemoteStates: map[string]config.RemoteState{
+				"data.terraform_remote_state.cycle_b": {Project: "cycle_b"},
+			},
+		},
+		{
+			Name: "cycle_b",
+			Path: filepath.Join(testPath, "cycle_b"),
+			RemoteStates: map[string]config.RemoteState{
+				"data.terraform_remote_state.cycle_a": {Project: "cycle_a"},
+			},
+		},
+	}
+
+	t.Run("resolves outputs of another project", func(t *testing.T) {
+		ctx := config.NewProjectContext(runCtx, runCtx.Config.Projects[1], log.Fields{})
+		p, err := NewHCLProvider(ctx, &HCLProviderConfig{SuppressLogging: true})
+		require.NoError(t, err)
+
+		mods, err := p.Modules()
+		require.NoError(t, err)
+		require.Len(t, mods, 1)
+
+		instances := mods[0].Blocks.OfType("resource")
+		require.Len(t, instances, 2)
+		for _, instance := range instances {
+			assert.Equal(t, "m5.large", instance.GetAttribute("instance_type").Value().AsString())
+		}
+
+		assert.Empty(t, mods[0].Warnings)
+		assert.NotContains(t, ctx.Logger().Data, "remote_state_project", "the project logger fields should not be changed")
+	})
+
+	t.Run("detects cycles between projects", func(t *testing.T) {
+		ctx := config.NewProjectContext(runCtx, runCtx.Config.Projects[3], log.Fields{})
+		remoteStates := newRemoteStates(ctx, []string{"cycle_a"})
+
+		_, err := remoteStates.ProjectOutputs("cycle_a")
+		require.EqualError(t, err, "remote state cycle detected between projects cycle_a -> cycle_b -> cycle_a")
+	})
+}
@@ -0,0 +1,36 @@
+provider "aws" {
+  region                      = "us-east-1"
+  skip_credentials_validation = true
+  skip_requesting_account_id  = true
+  access_key                  = "mock_access_key"
+  secret_key                  = "mock_secret_key"
+}
+
+resource "aws_eip" "new" {
+}
+
+moved {
+  from = aws_eip.old
+  to   = aws_eip.new
+}
+
+module "web" {
+  source = "./module/eip"
+}
+
+moved {
+  from = aws_eip.web
+  to   = module.web.aws_eip.eip
+}
+
+module "db" {
+  source = "./module/eip"
+}
+
+moved {
+  from = module.database
+  to   = module.db
+}
+
+resource "aws_eip" "unchanged" {
+}
@@ -0,0 +1,2 @@
+resource "aws_eip" "eip" {
+}
@@ -0,0 +1,19 @@
+provider "aws" {
+  region = "us-east-1"
+}
+
+data "terraform_remote_state" "network" {
+  backend = "s3"
+
+  config = {
+    bucket = "terraform-state"
+    key    = "network/terraform.tfstate"
+    region = "us-east-1"
+  }
+}
+
+resource "aws_instance" "app" {
+  count         = data.terraform_remote_state.network.outputs.instance_count
+  ami           = "ami-0123456789abcdef0"
+  instance_type = data.terraform_remote_state.network.outputs.instance_type
+}
@@ -0,0 +1,7 @@
+data "terraform_remote_state" "cycle_b" {
+  backend = "s3"
+}
+
+output "instance_type" {
+  value = data.terraform_remote_state.cycle_b.outputs.instance_type
+}
@@ -0,0 +1,18 @@
+variable "env" {
+  default = "prod"
+}
+
+locals {
+  instance_types = {
+    prod = "m5.large"
+    dev  = "t3.micro"
+  }
+}
+
+output "instance_type" {
+  value = local.instance_types[var.env]
+}
+
+output "instance_count" {
+  value = 2
+}
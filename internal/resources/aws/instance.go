@@ -42,6 +42,7 @@ type Instance struct {
 	MonthlyCPUCreditHours         *int64   `infracost_usage:"monthly_cpu_credit_hrs"`
 	VCPUCount                     *int64   `infracost_usage:"vcpu_count"`
 	MonthlyHours                  *float64 `infracost_usage:"monthly_hrs"`
+	SpotDiscount                  *float64 `infracost_usage:"spot_discount"`
 }
 
 func (a *Instance) CoreType() string {
@@ -60,6 +61,7 @@ var InstanceUsageSchema = []*schema.UsageItem{
 	{Key: "monthly_cpu_credit_hrs", DefaultValue: 0, ValueType: schema.Int64},
 	{Key: "vcpu_count", DefaultValue: 0, ValueType: schema.Int64},
 	{Key: "monthly_hrs", DefaultValue: 730, ValueType: schema.Float64},
+	{Key: "spot_discount", DefaultValue: 0, ValueType: schema.Float64},
 }
 
 func (a *Instance) PopulateUsage(u *schema.UsageData) {
@@ -191,12 +193,29 @@ func (a *Instance) computeCostComponent() *schema.CostComponent {
 		purchaseOptionLabel = "reserved"
 	}
 
-	qty := decimal.NewFromFloat(730)
+	// When a spot discount is given the spot instances are priced from the
+	// on-demand price instead of the spot prices, so the discount can be used
+	// for instance types and regions that don't have spot prices.
+	var spotMultiplier *decimal.Decimal
+	if a.PurchaseOption == "spot" && a.ReservedInstanceType == nil && a.SpotDiscount != nil {
+		discount := decimal.NewFromFloat(*a.SpotDiscount)
+		if discount.LessThan(decimal.Zero) || discount.GreaterThan(decimal.NewFromInt(1)) {
+			log.Warnf("Invalid spot_discount %s for %s, it should be between 0 and 1", discount, a.Address)
+		} else {
+			priceFilter = &schema.PriceFilter{
+				PurchaseOption: strPtr("on_demand"),
+			}
+			purchaseOptionLabel = fmt.Sprintf("spot, %s%% off on-demand", discount.Mul(decimal.NewFromInt(100)).Round(2))
+			spotMultiplier = decimalPtr(decimal.NewFromInt(1).Sub(discount))
+		}
+	}
+
+	qty := schema.HourToMonthUnitMultiplier
 	if a.MonthlyHours != nil {
 		qty = decimal.NewFromFloat(*a.MonthlyHours)
 	}
 
-	return &schema.CostComponent{
+	c := &schema.CostComponent{
 		Name:            fmt.Sprintf("Instance usage (%s, %s, %s)", osLabel, purchaseOptionLabel, a.InstanceType),
 		Unit:            "hours",
 		UnitMultiplier:  decimal.NewFromInt(1),
@@ -217,6 +236,9 @@ func (a *Instance) computeCostComponent() *schema.CostComponent {
 		},
 		PriceFilter: priceFilter,
 	}
+	c.SetCustomPriceMultiplier(spotMultiplier)
+
+	return c
 }
 
 func (a *Instance) ebsOptimizedCostComponent() *schema.CostComponent {
@@ -231,7 +253,7 @@ func (a *Instance) ebsOptimizedCostComponent() *schema.CostComponent {
 	 *    > The hourly price for EBS-optimized instances is in addition to the hourly usage fee
 	 *    > for supported instance types.
 	 */
-	qty := decimal.NewFromFloat(730)
+	qty := schema.HourToMonthUnitMultiplier
 	if a.MonthlyHours != nil {
 		qty = decimal.NewFromFloat(*a.MonthlyHours)
 	}
@@ -282,7 +304,7 @@ func (a *Instance) elasticInferenceAcceleratorCostComponent() *schema.CostCompon
 	 *    > With Amazon Elastic Inference, you pay only for the accelerator hours you use.
 	 *    > There are no upfront costs or minimum fees.
 	 */
-	qty := decimal.NewFromFloat(730)
+	qty := schema.HourToMonthUnitMultiplier
 	if a.MonthlyHours != nil {
 		qty = decimal.NewFromFloat(*a.MonthlyHours)
 	}
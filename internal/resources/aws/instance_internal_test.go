@@ -0,0 +1,78 @@
+package aws_test
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	resources "github.com/infracost/infracost/internal/resources/aws"
+)
+
+func TestInstanceSpotDiscount(t *testing.T) {
+	t.Parallel()
+
+	spotDiscount := 0.7
+
+	tests := []struct {
+		name               string
+		purchaseOption     string
+		spotDiscount       *float64
+		expectedName       string
+		expectedOption     string
+		expectedMultiplier *decimal.Decimal
+	}{
+		{
+			name:           "spot prices",
+			purchaseOption: "spot",
+			expectedName:   "Instance usage (Linux/UNIX, spot, m5.large)",
+			expectedOption: "spot",
+		},
+		{
+			name:               "spot discount",
+			purchaseOption:     "spot",
+			spotDiscount:       &spotDiscount,
+			expectedName:       "Instance usage (Linux/UNIX, spot, 70% off on-demand, m5.large)",
+			expectedOption:     "on_demand",
+			expectedMultiplier: decimalPtr(decimal.RequireFromString("0.3")),
+		},
+		{
+			name:           "spot discount ignored for on-demand",
+			purchaseOption: "on_demand",
+			spotDiscount:   &spotDiscount,
+			expectedName:   "Instance usage (Linux/UNIX, on-demand, m5.large)",
+			expectedOption: "on_demand",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			instance := &resources.Instance{
+				Address:        "aws_instance.web",
+				Region:         "us-east-1",
+				PurchaseOption: test.purchaseOption,
+				InstanceType:   "m5.large",
+				SpotDiscount:   test.spotDiscount,
+			}
+
+			r := instance.BuildResource()
+			require.NotEmpty(t, r.CostComponents)
+
+			c := r.CostComponents[0]
+			assert.Equal(t, test.expectedName, c.Name)
+			assert.Equal(t, test.expectedOption, *c.PriceFilter.PurchaseOption)
+
+			if test.expectedMultiplier == nil {
+				assert.Nil(t, c.CustomPriceMultiplier())
+			} else {
+				require.NotNil(t, c.CustomPriceMultiplier())
+				assert.True(t, test.expectedMultiplier.Equal(*c.CustomPriceMultiplier()))
+			}
+		})
+	}
+}
+
+func decimalPtr(d decimal.Decimal) *decimal.Decimal {
+	return &d
+}
@@ -29,13 +29,14 @@ type LaunchTemplate struct {
 
 	// "usage" args
 	// These are populated from the Autoscaling Group/EKS Node Group resource
-	InstanceCount                 *int64  `infracost_usage:"instances"`
-	OperatingSystem               *string `infracost_usage:"operating_system"`
-	ReservedInstanceType          *string `infracost_usage:"reserved_instance_type"`
-	ReservedInstanceTerm          *string `infracost_usage:"reserved_instance_term"`
-	ReservedInstancePaymentOption *string `infracost_usage:"reserved_instance_payment_option"`
-	MonthlyCPUCreditHours         *int64  `infracost_usage:"monthly_cpu_credit_hrs"`
-	VCPUCount                     *int64  `infracost_usage:"vcpu_count"`
+	InstanceCount                 *int64   `infracost_usage:"instances"`
+	OperatingSystem               *string  `infracost_usage:"operating_system"`
+	ReservedInstanceType          *string  `infracost_usage:"reserved_instance_type"`
+	ReservedInstanceTerm          *string  `infracost_usage:"reserved_instance_term"`
+	ReservedInstancePaymentOption *string  `infracost_usage:"reserved_instance_payment_option"`
+	MonthlyCPUCreditHours         *int64   `infracost_usage:"monthly_cpu_credit_hrs"`
+	VCPUCount                     *int64   `infracost_usage:"vcpu_count"`
+	SpotDiscount                  *float64 `infracost_usage:"spot_discount"`
 }
 
 var LaunchTemplateUsageSchema = InstanceUsageSchema
@@ -57,6 +58,7 @@ func (a *LaunchTemplate) BuildResource() *schema.Resource {
 	costComponents := make([]*schema.CostComponent, 0)
 
 	instance := &Instance{
+		Address:                         a.Address,
 		Region:                          a.Region,
 		Tenancy:                         a.Tenancy,
 		AMI:                             a.AMI,
@@ -73,6 +75,7 @@ func (a *LaunchTemplate) BuildResource() *schema.Resource {
 		ReservedInstancePaymentOption:   a.ReservedInstancePaymentOption,
 		MonthlyCPUCreditHours:           a.MonthlyCPUCreditHours,
 		VCPUCount:                       a.VCPUCount,
+		SpotDiscount:                    a.SpotDiscount,
 	}
 	instanceResource := instance.BuildResource()
 
@@ -227,7 +227,7 @@ func (r *RDSCluster) auroraBacktrackCostComponent(backtrackChangeRecords *decima
 
 func (r *RDSCluster) calculateIORequests(writeRequestPerSecond decimal.Decimal, readRequestsPerSecond decimal.Decimal) decimal.Decimal {
 	ioPerSecond := writeRequestPerSecond.Add(readRequestsPerSecond)
-	monthlyIO := ioPerSecond.Mul(decimal.NewFromInt(730)).Mul(decimal.NewFromInt(60)).Mul(decimal.NewFromInt(60))
+	monthlyIO := ioPerSecond.Mul(schema.HourToMonthUnitMultiplier).Mul(decimal.NewFromInt(60)).Mul(decimal.NewFromInt(60))
 	return monthlyIO
 }
 
@@ -236,5 +236,5 @@ func (r *RDSCluster) calculateBackupStorage(snapShotStorageSize decimal.Decimal,
 }
 
 func (r *RDSCluster) calculateBacktrack(averageStatements decimal.Decimal, changeRecords decimal.Decimal, windowHours decimal.Decimal) decimal.Decimal {
-	return averageStatements.Mul(decimal.NewFromInt(730)).Mul(changeRecords).Mul(windowHours)
+	return averageStatements.Mul(schema.HourToMonthUnitMultiplier).Mul(changeRecords).Mul(windowHours)
 }
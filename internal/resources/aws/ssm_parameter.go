@@ -78,7 +78,7 @@ func (r *SSMParameter) tierValue() string {
 }
 
 func (r *SSMParameter) parameterStorageCostComponent() *schema.CostComponent {
-	parameterStorageHours := decimal.NewFromInt(730)
+	parameterStorageHours := schema.HourToMonthUnitMultiplier
 	if r.ParameterStorageHrs != nil {
 		parameterStorageHours = decimal.NewFromInt(*r.ParameterStorageHrs)
 	}
@@ -744,3 +744,22 @@ func (r rdsReservationResolver) PriceFilter() (*schema.PriceFilter, error) {
 		TermPurchaseOption: strPtr(purchaseOption),
 	}, nil
 }
+
+// ReservedPriceFilter returns the price filter for the reserved price of an
+// instance type of the given service (ec2, rds or elasticache). It is used to
+// price the Reserved Instance commitments declared in the usage file.
+func ReservedPriceFilter(service, instanceType, term, paymentOption, offeringClass string) (*schema.PriceFilter, error) {
+	switch service {
+	case "ec2":
+		if offeringClass == "" {
+			offeringClass = "standard"
+		}
+		return ec2ReservationResolver{term: term, paymentOption: paymentOption, termOfferingClass: offeringClass}.PriceFilter()
+	case "rds":
+		return rdsReservationResolver{term: term, paymentOption: paymentOption}.PriceFilter()
+	case "elasticache":
+		return elasticacheReservationResolver{term: term, paymentOption: paymentOption, cacheNodeType: instanceType}.PriceFilter()
+	}
+
+	return nil, fmt.Errorf("Unsupported reserved instance service %s", service)
+}
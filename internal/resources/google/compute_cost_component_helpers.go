@@ -314,7 +314,7 @@ func guestAcceleratorCostComponent(region string, purchaseOption string, guestAc
 		sustainedUseDiscount = 0.3
 	}
 
-	qty := decimal.NewFromFloat(730)
+	qty := schema.HourToMonthUnitMultiplier
 	if monthlyHours != nil {
 		qty = decimal.NewFromFloat(*monthlyHours)
 	}
@@ -0,0 +1,64 @@
+package schema
+
+import (
+	"errors"
+	"fmt"
+)
+
+const (
+	// BudgetLevelWarning reports budget breaches without failing the run.
+	BudgetLevelWarning = "warning"
+	// BudgetLevelBlock reports budget breaches and fails the run.
+	BudgetLevelBlock = "block"
+)
+
+// Budget is the monthly budget and the maximum allowed cost increases of a
+// project. It is defined in the budget block of a project in the config file
+// and is checked locally as a guardrail.
+type Budget struct {
+	// MonthlyBudget is the maximum total monthly cost of the project.
+	MonthlyBudget *float64 `yaml:"monthly_budget,omitempty" json:"monthlyBudget,omitempty"`
+	// MaxIncrease is the maximum increase in the total monthly cost of the
+	// project compared to the past breakdown.
+	MaxIncrease *float64 `yaml:"max_increase,omitempty" json:"maxIncrease,omitempty"`
+	// MaxIncreasePercent is the maximum increase in the total monthly cost of
+	// the project as a percentage of the past total monthly cost.
+	MaxIncreasePercent *float64 `yaml:"max_increase_percent,omitempty" json:"maxIncreasePercent,omitempty"`
+	// Level is either warning or block, and defaults to warning.
+	Level string `yaml:"level,omitempty" json:"level,omitempty"`
+}
+
+// Validate returns an error if the budget has no thresholds, any of them are
+// negative or the level isn't valid.
+func (b *Budget) Validate() error {
+	if b.MonthlyBudget == nil && b.MaxIncrease == nil && b.MaxIncreasePercent == nil {
+		return errors.New("budget must have at least one of monthly_budget, max_increase or max_increase_percent")
+	}
+
+	thresholds := []struct {
+		key   string
+		value *float64
+	}{
+		{"monthly_budget", b.MonthlyBudget},
+		{"max_increase", b.MaxIncrease},
+		{"max_increase_percent", b.MaxIncreasePercent},
+	}
+	for _, t := range thresholds {
+		if t.value != nil && *t.value < 0 {
+			return fmt.Errorf("budget %s must not be negative", t.key)
+		}
+	}
+
+	switch b.Level {
+	case "", BudgetLevelWarning, BudgetLevelBlock:
+	default:
+		return fmt.Errorf("budget level '%s' is not valid, valid levels are %s and %s", b.Level, BudgetLevelWarning, BudgetLevelBlock)
+	}
+
+	return nil
+}
+
+// IsBlocking returns true if breaches of the budget should fail the run.
+func (b *Budget) IsBlocking() bool {
+	return b.Level == BudgetLevelBlock
+}
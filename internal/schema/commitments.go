@@ -0,0 +1,126 @@
+package schema
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Commitments are the Reserved Instances and Savings Plans that cover some of
+// the usage of a project. They are read from the commitments section of the
+// usage file and applied when the project is priced.
+type Commitments struct {
+	ReservedInstances []*ReservedInstanceCommitment `yaml:"reserved_instances,omitempty"`
+	SavingsPlans      []*SavingsPlanCommitment      `yaml:"savings_plans,omitempty"`
+}
+
+// ReservedInstanceCommitment covers instances of a given type or family in a
+// region with reserved pricing.
+type ReservedInstanceCommitment struct {
+	// Service is one of ec2, rds or elasticache.
+	Service string `yaml:"service"`
+	// InstanceType matches a single instance type, e.g. m5.large. Either it or
+	// InstanceFamily should be set.
+	InstanceType string `yaml:"instance_type,omitempty"`
+	// InstanceFamily matches all the sizes of a family, e.g. m5, db.r5 or cache.r6g.
+	InstanceFamily string `yaml:"instance_family,omitempty"`
+	Region         string `yaml:"region"`
+	// Term is one of 1_year or 3_year.
+	Term string `yaml:"term"`
+	// PaymentOption is one of no_upfront, partial_upfront or all_upfront.
+	PaymentOption string `yaml:"payment_option"`
+	// OfferingClass is one of standard or convertible and only applies to EC2.
+	// It defaults to standard.
+	OfferingClass string `yaml:"offering_class,omitempty"`
+	// Count is the number of instances that are reserved. If it is zero all
+	// the matching instances are covered.
+	Count int64 `yaml:"count,omitempty"`
+}
+
+// SavingsPlanCommitment covers on-demand compute spend up to an hourly
+// commitment at a discounted rate. The Cloud Pricing API doesn't have Savings
+// Plan rates so the discount has to be provided.
+type SavingsPlanCommitment struct {
+	// Type is compute, which covers EC2 instances and Fargate in any region, or
+	// ec2_instance, which only covers an instance family in a region.
+	Type string `yaml:"type"`
+	// InstanceFamily and Region are required for ec2_instance Savings Plans.
+	InstanceFamily string `yaml:"instance_family,omitempty"`
+	Region         string `yaml:"region,omitempty"`
+	// HourlyCommitment is the amount spent per hour at Savings Plan rates.
+	HourlyCommitment float64 `yaml:"hourly_commitment"`
+	// DiscountPerc is the discount of the Savings Plan rates compared to
+	// on-demand rates, e.g. 0.25 for 25%.
+	DiscountPerc float64 `yaml:"discount_perc"`
+}
+
+var (
+	reservedInstanceServices = []string{"ec2", "rds", "elasticache"}
+	commitmentTerms          = []string{"1_year", "3_year"}
+	commitmentPaymentOptions = []string{"no_upfront", "partial_upfront", "all_upfront"}
+	savingsPlanTypes         = []string{"compute", "ec2_instance"}
+)
+
+// Validate returns an error describing the first invalid commitment.
+func (c *Commitments) Validate() error {
+	if c == nil {
+		return nil
+	}
+
+	for i, ri := range c.ReservedInstances {
+		if !containsString(reservedInstanceServices, ri.Service) {
+			return fmt.Errorf("reserved_instances[%d]: invalid service %q, expected one of: %s", i, ri.Service, strings.Join(reservedInstanceServices, ", "))
+		}
+		if ri.InstanceType == "" && ri.InstanceFamily == "" {
+			return fmt.Errorf("reserved_instances[%d]: instance_type or instance_family is required", i)
+		}
+		if ri.Region == "" {
+			return fmt.Errorf("reserved_instances[%d]: region is required", i)
+		}
+		if !containsString(commitmentTerms, ri.Term) {
+			return fmt.Errorf("reserved_instances[%d]: invalid term %q, expected one of: %s", i, ri.Term, strings.Join(commitmentTerms, ", "))
+		}
+		if !containsString(commitmentPaymentOptions, ri.PaymentOption) {
+			return fmt.Errorf("reserved_instances[%d]: invalid payment_option %q, expected one of: %s", i, ri.PaymentOption, strings.Join(commitmentPaymentOptions, ", "))
+		}
+		if ri.Count < 0 {
+			return fmt.Errorf("reserved_instances[%d]: count can't be negative", i)
+		}
+	}
+
+	for i, sp := range c.SavingsPlans {
+		if !containsString(savingsPlanTypes, sp.Type) {
+			return fmt.Errorf("savings_plans[%d]: invalid type %q, expected one of: %s", i, sp.Type, strings.Join(savingsPlanTypes, ", "))
+		}
+		if sp.Type == "ec2_instance" && (sp.InstanceFamily == "" || sp.Region == "") {
+			return fmt.Errorf("savings_plans[%d]: instance_family and region are required for ec2_instance Savings Plans", i)
+		}
+		if sp.HourlyCommitment <= 0 {
+			return fmt.Errorf("savings_plans[%d]: hourly_commitment must be greater than 0", i)
+		}
+		if sp.DiscountPerc <= 0 || sp.DiscountPerc >= 1 {
+			return fmt.Errorf("savings_plans[%d]: discount_perc must be between 0 and 1", i)
+		}
+	}
+
+	return nil
+}
+
+// Label returns a short description of the reservation for the output.
+func (ri *ReservedInstanceCommitment) Label() string {
+	return fmt.Sprintf("reserved instance, %s, %s", strings.ReplaceAll(ri.Term, "_", " "), strings.ReplaceAll(ri.PaymentOption, "_", " "))
+}
+
+// Label returns a short description of the Savings Plan for the output.
+func (sp *SavingsPlanCommitment) Label() string {
+	return fmt.Sprintf("%s savings plan", strings.ReplaceAll(sp.Type, "_", " "))
+}
+
+func containsString(a []string, s string) bool {
+	for _, v := range a {
+		if v == s {
+			return true
+		}
+	}
+
+	return false
+}
@@ -42,11 +42,42 @@ type PartialResource struct {
 	// CloudResourceIDs are collected during parsing in case they need to be uploaded to the
 	// Cloud Usage API to be used in the usage estimate calculations.
 	CloudResourceIDs []string
+
+	// WithUsage returns a new PartialResource for the same ResourceData with different
+	// usage data, so the resource can be built with the usage of each of its usage
+	// scenarios. It is nil for providers that don't support usage scenarios.
+	WithUsage func(u *UsageData) *PartialResource
 }
 
 // BuildResource create a new Resource from the CoreResource, or (for backward compatibility) returns
-// a previously built Resource
+// a previously built Resource. If the usage data of the resource has usage scenarios the resource
+// is built with the usage of the expected scenario and the other scenarios are built as its
+// UsageScenarios.
 func BuildResource(partial *PartialResource, fetchedUsage *UsageData) *Resource {
+	names := partial.ResourceData.UsageData.ScenarioNames()
+	if len(names) == 0 || partial.WithUsage == nil {
+		return buildResource(partial, fetchedUsage)
+	}
+
+	u := partial.ResourceData.UsageData
+	res := BuildResource(partial.WithUsage(u.Scenario(ExpectedUsageScenario)), fetchedUsage)
+	if res.IsSkipped {
+		return res
+	}
+
+	res.UsageScenarios = make(map[string]*Resource, len(names))
+	for _, name := range names {
+		if name == ExpectedUsageScenario {
+			continue
+		}
+
+		res.UsageScenarios[name] = BuildResource(partial.WithUsage(u.Scenario(name)), fetchedUsage)
+	}
+
+	return res
+}
+
+func buildResource(partial *PartialResource, fetchedUsage *UsageData) *Resource {
 	var res *Resource
 	if partial.CoreResource != nil {
 		u := partial.ResourceData.UsageData
@@ -68,7 +99,10 @@ func BuildResource(partial *PartialResource, fetchedUsage *UsageData) *Resource
 
 	res.ResourceType = partial.ResourceData.Type
 	res.Tags = partial.ResourceData.Tags
+	res.Region = partial.ResourceData.Get("region").String()
 	res.Metadata = partial.ResourceData.Metadata
+	res.MovedFrom = partial.ResourceData.PreviousAddress
+	res.Replacement = partial.ResourceData.Replacement
 	return res
 }
 
@@ -83,7 +117,45 @@ func BuildResources(projects []*Project, projectPtrToUsageMap map[*Project]map[s
 
 		for _, partial := range project.PartialPastResources {
 			u := usageMap[partial.ResourceData.Address]
-			project.PastResources = append(project.PastResources, BuildResource(partial, u))
+			r := BuildResource(partial, u)
+			// Only the current resources are priced with their usage scenarios.
+			r.UsageScenarios = nil
+			project.PastResources = append(project.PastResources, r)
+		}
+	}
+}
+
+// BuildForecasts builds the resources of each month of a forecast of the given number of months
+// for the current resources of the projects. Resources whose usage data has growth functions are
+// built with the usage of each month, other resources are used as is for every month. It must be
+// called after BuildResources.
+func BuildForecasts(projects []*Project, months int, projectPtrToUsageMap map[*Project]map[string]*UsageData) {
+	for _, project := range projects {
+		// The resources are built from the partial resources in the same order
+		if len(project.PartialResources) != len(project.Resources) {
+			continue
+		}
+
+		usageMap := projectPtrToUsageMap[project]
+
+		for i, partial := range project.PartialResources {
+			r := project.Resources[i]
+			if r.IsSkipped {
+				continue
+			}
+
+			u := partial.ResourceData.UsageData
+			canGrow := partial.WithUsage != nil && u.HasGrowth()
+
+			r.ForecastMonths = make([]*Resource, months)
+			for m := 0; m < months; m++ {
+				if !canGrow {
+					r.ForecastMonths[m] = r
+					continue
+				}
+
+				r.ForecastMonths[m] = BuildResource(partial.WithUsage(u.ForecastMonth(m)), usageMap[partial.ResourceData.Address])
+			}
 		}
 	}
 }
@@ -0,0 +1,94 @@
+package schema
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildResourceUsageScenarios(t *testing.T) {
+	newPartial := func(u *UsageData) *PartialResource {
+		qty := decimal.NewFromInt(u.Get("monthly_requests").Int())
+		return &PartialResource{
+			ResourceData: &ResourceData{Address: "aws_lambda_function.fn", UsageData: u},
+			Resource: &Resource{
+				Name: "aws_lambda_function.fn",
+				CostComponents: []*CostComponent{
+					{Name: "Requests", UnitMultiplier: decimal.NewFromInt(1), MonthlyQuantity: &qty},
+				},
+			},
+		}
+	}
+
+	u := NewUsageData("aws_lambda_function.fn", ParseAttributes(map[string]interface{}{
+		"monthly_requests": 100,
+		"scenarios": map[string]interface{}{
+			"low":      map[string]interface{}{"monthly_requests": 10},
+			"expected": map[string]interface{}{"monthly_requests": 200},
+			"high":     map[string]interface{}{"monthly_requests": 1000},
+			"peak":     map[string]interface{}{"monthly_requests": 5000},
+		},
+	}))
+
+	partial := newPartial(u)
+	partial.WithUsage = newPartial
+
+	r := BuildResource(partial, nil)
+	assert.Equal(t, "200", r.CostComponents[0].MonthlyQuantity.String())
+	require.Len(t, r.UsageScenarios, 2)
+	assert.Equal(t, "10", r.UsageScenarios["low"].CostComponents[0].MonthlyQuantity.String())
+	assert.Equal(t, "1000", r.UsageScenarios["high"].CostComponents[0].MonthlyQuantity.String())
+
+	project := &Project{Resources: []*Resource{r}}
+	assert.Equal(t, []*Resource{r.UsageScenarios["low"], r.UsageScenarios["high"]}, project.UsageScenarioResources())
+
+	// Without WithUsage the resource is built as is
+	partial.WithUsage = nil
+	r = BuildResource(partial, nil)
+	assert.Equal(t, "100", r.CostComponents[0].MonthlyQuantity.String())
+	assert.Nil(t, r.UsageScenarios)
+}
+
+func TestBuildForecasts(t *testing.T) {
+	newPartial := func(address string, u *UsageData) *PartialResource {
+		qty := decimal.NewFromInt(u.Get("storage_gb").Int())
+		return &PartialResource{
+			ResourceData: &ResourceData{Address: address, UsageData: u},
+			Resource: &Resource{
+				Name: address,
+				CostComponents: []*CostComponent{
+					{Name: "Storage", UnitMultiplier: decimal.NewFromInt(1), MonthlyQuantity: &qty},
+				},
+			},
+		}
+	}
+
+	growing := newPartial("aws_s3_bucket.growing", NewUsageData("aws_s3_bucket.growing", ParseAttributes(map[string]interface{}{
+		"storage_gb": 100,
+		"growth":     map[string]interface{}{"storage_gb": map[string]interface{}{"linear": 50}},
+	})))
+	growing.WithUsage = func(u *UsageData) *PartialResource { return newPartial("aws_s3_bucket.growing", u) }
+
+	static := newPartial("aws_s3_bucket.static", NewUsageData("aws_s3_bucket.static", ParseAttributes(map[string]interface{}{
+		"storage_gb": 10,
+	})))
+	static.WithUsage = func(u *UsageData) *PartialResource { return newPartial("aws_s3_bucket.static", u) }
+
+	project := &Project{PartialResources: []*PartialResource{growing, static}}
+	BuildResources([]*Project{project}, nil)
+	BuildForecasts([]*Project{project}, 3, nil)
+
+	require.Len(t, project.Resources[0].ForecastMonths, 3)
+	assert.Equal(t, "100", project.Resources[0].ForecastMonths[0].CostComponents[0].MonthlyQuantity.String())
+	assert.Equal(t, "200", project.Resources[0].ForecastMonths[2].CostComponents[0].MonthlyQuantity.String())
+
+	// Resources without growth are used as is for every month
+	require.Len(t, project.Resources[1].ForecastMonths, 3)
+	for _, r := range project.Resources[1].ForecastMonths {
+		assert.Same(t, project.Resources[1], r)
+	}
+
+	assert.Len(t, project.ForecastResources(), 3)
+}
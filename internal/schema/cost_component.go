@@ -24,15 +24,41 @@ func (t *PriceTier) fillQuantities() {
 }
 
 type CostComponent struct {
-	Name                  string
-	Unit                  string
-	UnitMultiplier        decimal.Decimal
-	IgnoreIfMissingPrice  bool
-	ProductFilter         *ProductFilter
-	PriceFilter           *PriceFilter
-	HourlyQuantity        *decimal.Decimal
-	MonthlyQuantity       *decimal.Decimal
-	MonthlyDiscountPerc   float64
+	Name                 string
+	Unit                 string
+	UnitMultiplier       decimal.Decimal
+	IgnoreIfMissingPrice bool
+	ProductFilter        *ProductFilter
+	PriceFilter          *PriceFilter
+	HourlyQuantity       *decimal.Decimal
+	MonthlyQuantity      *decimal.Decimal
+	MonthlyDiscountPerc  float64
+	// PriceUnavailableReason is set when the price could not be retrieved from
+	// the pricing API, in which case the price is zero and the costs of the
+	// component's resource are a lower bound.
+	PriceUnavailableReason string
+	// PriceNotFound is set when the pricing API has no price for the product
+	// filter of the cost component, e.g. if the product isn't available in the
+	// region. The price is zero in this case.
+	PriceNotFound bool
+	// Commitment describes the Reserved Instances or Savings Plans that cover
+	// some or all of the usage. When it is set the price is the effective price
+	// after the commitments are applied and the on-demand costs are set too.
+	Commitment          string
+	onDemandPrice       *decimal.Decimal
+	OnDemandHourlyCost  *decimal.Decimal
+	OnDemandMonthlyCost *decimal.Decimal
+	// DiscountRule names the discount rules that were applied to the cost
+	// component. When it is set the list monthly cost is the monthly cost
+	// without the discount of the rules, see ApplyDiscountRule.
+	DiscountRule string
+	// listDiscountPerc is the MonthlyDiscountPerc from before the discount
+	// rules were applied, e.g. a sustained use discount.
+	listDiscountPerc float64
+	// PriceOverride names the price override that set the custom price or
+	// custom price multiplier of the cost component.
+	PriceOverride         string
+	ListMonthlyCost       *decimal.Decimal
 	price                 decimal.Decimal
 	priceTiers            []PriceTier
 	customPrice           *decimal.Decimal
@@ -40,6 +66,23 @@ type CostComponent struct {
 	priceHash             string
 	HourlyCost            *decimal.Decimal
 	MonthlyCost           *decimal.Decimal
+	// PriceChangeMonthlyCost and QuantityChangeMonthlyCost are only set on diff
+	// cost components whose price changed. They split the monthly cost change
+	// into the part caused by the new price at the past quantity, and the rest
+	// which is caused by the change in quantity.
+	PriceChangeMonthlyCost    *decimal.Decimal
+	QuantityChangeMonthlyCost *decimal.Decimal
+	// MonthlyCarbon is the estimated emissions of the monthly quantity in
+	// kgCO2e. It is only set if the carbon intensity of the cost component
+	// has been estimated.
+	MonthlyCarbon   *decimal.Decimal
+	carbonIntensity *decimal.Decimal
+}
+
+// PriceUnavailable returns true if the price of the cost component could not be
+// retrieved.
+func (c *CostComponent) PriceUnavailable() bool {
+	return c.PriceUnavailableReason != ""
 }
 
 func (c *CostComponent) CalculateCosts() {
@@ -102,9 +145,62 @@ func (c *CostComponent) CalculateCosts() {
 			discountMul := decimal.NewFromFloat(1.0 - c.MonthlyDiscountPerc)
 			c.MonthlyCost = decimalPtr(c.price.Mul(*c.MonthlyQuantity).Mul(discountMul))
 		}
+
+		if c.onDemandPrice != nil {
+			if c.HourlyQuantity != nil {
+				c.OnDemandHourlyCost = decimalPtr(c.onDemandPrice.Mul(*c.HourlyQuantity))
+			}
+			if c.MonthlyQuantity != nil {
+				discountMul := decimal.NewFromFloat(1.0 - c.MonthlyDiscountPerc)
+				c.OnDemandMonthlyCost = decimalPtr(c.onDemandPrice.Mul(*c.MonthlyQuantity).Mul(discountMul))
+			}
+		}
+	}
+
+	if c.DiscountRule != "" {
+		c.ListMonthlyCost = c.listMonthlyCost()
+	}
+
+	if c.carbonIntensity != nil && c.MonthlyQuantity != nil {
+		c.MonthlyCarbon = decimalPtr(c.carbonIntensity.Mul(*c.MonthlyQuantity))
 	}
 }
 
+// ApplyDiscountRule compounds the discount of the named discount rules with
+// the existing MonthlyDiscountPerc. The existing discount is kept so that the
+// list monthly cost only excludes the discount of the rules.
+func (c *CostComponent) ApplyDiscountRule(name string, discountPerc float64) {
+	if c.DiscountRule == "" {
+		c.listDiscountPerc = c.MonthlyDiscountPerc
+	}
+
+	c.MonthlyDiscountPerc = 1 - (1-c.MonthlyDiscountPerc)*(1-discountPerc)
+	c.DiscountRule = name
+}
+
+// listMonthlyCost returns the monthly cost with the discount from before the
+// discount rules were applied.
+func (c *CostComponent) listMonthlyCost() *decimal.Decimal {
+	if c.MonthlyQuantity == nil {
+		return nil
+	}
+
+	discountMul := decimal.NewFromFloat(1.0 - c.listDiscountPerc)
+
+	if c.priceTiers == nil {
+		return decimalPtr(c.price.Mul(*c.MonthlyQuantity).Mul(discountMul))
+	}
+
+	total := decimal.Zero
+	for _, tier := range c.priceTiers {
+		if tier.MonthlyQuantity != nil {
+			total = total.Add(tier.Price.Mul(*tier.MonthlyQuantity).Mul(discountMul))
+		}
+	}
+
+	return &total
+}
+
 func (c *CostComponent) fillQuantities() {
 	if c.MonthlyQuantity != nil && c.HourlyQuantity == nil {
 		c.HourlyQuantity = decimalPtr(c.MonthlyQuantity.Div(HourToMonthUnitMultiplier))
@@ -129,6 +225,27 @@ func (c *CostComponent) Price() decimal.Decimal {
 	return c.price
 }
 
+// SetOnDemandPrice sets the price the cost component would have without any
+// commitments, so the on-demand costs can be calculated alongside the
+// effective costs.
+func (c *CostComponent) SetOnDemandPrice(price decimal.Decimal) {
+	c.onDemandPrice = &price
+}
+
+func (c *CostComponent) OnDemandPrice() *decimal.Decimal {
+	return c.onDemandPrice
+}
+
+// SetCarbonIntensity sets the estimated emissions in kgCO2e per unit of the
+// monthly quantity, which the monthly carbon is calculated from.
+func (c *CostComponent) SetCarbonIntensity(intensity decimal.Decimal) {
+	c.carbonIntensity = &intensity
+}
+
+func (c *CostComponent) CarbonIntensity() *decimal.Decimal {
+	return c.carbonIntensity
+}
+
 func (c *CostComponent) SetCustomPriceMultiplier(customPriceMultiplier *decimal.Decimal) {
 	c.customPriceMultiplier = customPriceMultiplier
 }
@@ -29,8 +29,20 @@ func CalculateDiff(past []*Resource, current []*Resource) []*Resource {
 	// calculate the diff for them. This way a complete diff for
 	// all resources is calculated.
 
+	// Past resources that have been moved are keyed by their current name so
+	// they are matched with the current resource instead of being shown as
+	// removed and added.
+	moved := movedResourceNames(past, current)
+
 	pastRMap := make(map[string]*Resource)
-	fillResourcesMap(pastRMap, "", past)
+	for _, resource := range past {
+		key := resource.Name
+		if name, ok := moved[key]; ok {
+			key = name
+		}
+		pastRMap[key] = resource
+		fillResourcesMap(pastRMap, key, resource.SubResources)
+	}
 	currentRMap := make(map[string]*Resource)
 	fillResourcesMap(currentRMap, "", current)
 
@@ -38,6 +50,9 @@ func CalculateDiff(past []*Resource, current []*Resource) []*Resource {
 
 	for _, resource := range past {
 		resourceKey := resource.Name
+		if name, ok := moved[resourceKey]; ok {
+			resourceKey = name
+		}
 		changed, resources := diffResourcesByKey(resourceKey, pastRMap, currentRMap)
 		if changed {
 			diff = append(diff, resources)
@@ -58,6 +73,27 @@ func CalculateDiff(past []*Resource, current []*Resource) []*Resource {
 	return diff
 }
 
+// movedResourceNames returns the current names of the past resources that
+// have been moved, keyed by their past name. A resource is only treated as
+// moved if there is no past resource with its current name.
+func movedResourceNames(past []*Resource, current []*Resource) map[string]string {
+	pastNames := make(map[string]bool, len(past))
+	for _, r := range past {
+		pastNames[r.Name] = true
+	}
+
+	moved := make(map[string]string)
+	for _, r := range current {
+		if r.MovedFrom == "" || r.MovedFrom == r.Name || pastNames[r.Name] || !pastNames[r.MovedFrom] {
+			continue
+		}
+
+		moved[r.MovedFrom] = r.Name
+	}
+
+	return moved
+}
+
 // diffResourcesByKey calculates the diff between two resources given their resourcesMap and
 // their key.
 func diffResourcesByKey(resourceKey string, pastResMap, currentResMap map[string]*Resource) (bool, *Resource) {
@@ -83,9 +119,20 @@ func diffResourcesByKey(resourceKey string, pastResMap, currentResMap map[string
 		SkipMessage:  baseResource.SkipMessage,
 		ResourceType: baseResource.ResourceType,
 		Tags:         baseResource.Tags,
+		Region:       baseResource.Region,
 
-		HourlyCost:  diffDecimals(current.HourlyCost, past.HourlyCost),
-		MonthlyCost: diffDecimals(current.MonthlyCost, past.MonthlyCost),
+		HourlyCost:    diffDecimals(current.HourlyCost, past.HourlyCost),
+		MonthlyCost:   diffDecimals(current.MonthlyCost, past.MonthlyCost),
+		MonthlyCarbon: diffOptionalDecimals(current.MonthlyCarbon, past.MonthlyCarbon),
+	}
+	if pastOk && currentOk && past.Name != current.Name {
+		diff.MovedFrom = past.Name
+	}
+	// A replaced resource is shown in the diff even if its cost hasn't
+	// changed, since both copies of it can run while it is replaced.
+	if pastOk && currentOk && current.Replacement != "" {
+		diff.Replacement = current.Replacement
+		changed = true
 	}
 	for _, subResource := range past.SubResources {
 		subKey := fmt.Sprintf("%v.%v", resourceKey, subResource.Name)
@@ -194,6 +241,7 @@ func diffCostComponents(past *CostComponent, current *CostComponent) (bool, *Cos
 		price:               *diffDecimals(&current.price, &past.price),
 		HourlyCost:          diffDecimals(current.HourlyCost, past.HourlyCost),
 		MonthlyCost:         diffDecimals(current.MonthlyCost, past.MonthlyCost),
+		MonthlyCarbon:       diffOptionalDecimals(current.MonthlyCarbon, past.MonthlyCarbon),
 	}
 
 	// There are 3 cases with priceTiers:
@@ -243,9 +291,63 @@ func diffCostComponents(past *CostComponent, current *CostComponent) (bool, *Cos
 		changed = true
 	}
 
+	priceChange := diffPriceChange(past, current)
+	if priceChange != nil && !priceChange.IsZero() {
+		diff.PriceChangeMonthlyCost = priceChange
+		diff.QuantityChangeMonthlyCost = decimalPtr(diff.MonthlyCost.Sub(*priceChange))
+	}
+
 	return changed, diff
 }
 
+// diffPriceChange returns the part of the monthly cost change of a cost
+// component that is caused by a change in its price, which is the monthly cost
+// of the past quantity at the current price minus the past monthly cost. This
+// returns nil if the cost component was added or removed, or if its name
+// changed since then the product changed rather than its price.
+func diffPriceChange(past *CostComponent, current *CostComponent) *decimal.Decimal {
+	if past.Name != current.Name || past.MonthlyQuantity == nil || past.MonthlyCost == nil {
+		return nil
+	}
+
+	cost := current.monthlyCostAtQuantity(*past.MonthlyQuantity)
+	if cost == nil {
+		return nil
+	}
+
+	return diffDecimals(cost, past.MonthlyCost)
+}
+
+// monthlyCostAtQuantity returns what the monthly cost of the cost component
+// would be at a different monthly quantity. Tiered cost components are
+// recalculated from their tiers, for other cost components the effective price
+// is used so that any discounts are kept.
+func (c *CostComponent) monthlyCostAtQuantity(quantity decimal.Decimal) *decimal.Decimal {
+	if c.MonthlyCost == nil || c.MonthlyQuantity == nil {
+		return nil
+	}
+
+	if len(c.priceTiers) > 0 {
+		tiers := make([]PriceTier, len(c.priceTiers))
+		copy(tiers, c.priceTiers)
+
+		clone := &CostComponent{
+			MonthlyQuantity:     &quantity,
+			MonthlyDiscountPerc: c.MonthlyDiscountPerc,
+			priceTiers:          tiers,
+		}
+		clone.CalculateCosts()
+
+		return clone.MonthlyCost
+	}
+
+	if c.MonthlyQuantity.IsZero() {
+		return nil
+	}
+
+	return decimalPtr(quantity.Mul(*c.MonthlyCost).Div(*c.MonthlyQuantity))
+}
+
 // findMatchingCostComponent finds a matching cost component by first looking for an exact match by name
 // and if that's not found, looking for a match of everything before any brackets.
 func findMatchingCostComponent(costComponents []*CostComponent, name string) *CostComponent {
@@ -266,6 +368,16 @@ func findMatchingCostComponent(costComponents []*CostComponent, name string) *Co
 	return nil
 }
 
+// diffOptionalDecimals returns the diff of two values that are only set on
+// some resources, e.g. the monthly carbon. It returns nil if neither is set.
+func diffOptionalDecimals(current *decimal.Decimal, past *decimal.Decimal) *decimal.Decimal {
+	if current == nil && past == nil {
+		return nil
+	}
+
+	return diffDecimals(current, past)
+}
+
 // diffDecimals calculates the diff between two decimals.
 func diffDecimals(current *decimal.Decimal, past *decimal.Decimal) *decimal.Decimal {
 	var diff decimal.Decimal
@@ -5,6 +5,7 @@ import (
 
 	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestCalculateDiff(t *testing.T) {
@@ -92,6 +93,9 @@ func TestCalculateDiff(t *testing.T) {
 					price:               decimal.NewFromInt(1),
 					HourlyCost:          decimalPtr(decimal.NewFromInt(-3)),
 					MonthlyCost:         decimalPtr(decimal.NewFromInt(-2160)),
+
+					PriceChangeMonthlyCost:    decimalPtr(decimal.RequireFromString("-2880.0000000000000000")),
+					QuantityChangeMonthlyCost: decimalPtr(decimal.RequireFromString("720.0000000000000000")),
 				},
 			},
 		},
@@ -133,6 +137,76 @@ func TestCalculateDiff(t *testing.T) {
 	assert.Equal(t, expectedDiff, diff)
 }
 
+func TestCalculateDiffMovedResources(t *testing.T) {
+	newResource := func(name, movedFrom string, monthlyCost int64) *Resource {
+		return &Resource{
+			Name:        name,
+			MovedFrom:   movedFrom,
+			MonthlyCost: decimalPtr(decimal.NewFromInt(monthlyCost)),
+			CostComponents: []*CostComponent{
+				{Name: "cc", MonthlyCost: decimalPtr(decimal.NewFromInt(monthlyCost))},
+			},
+			SubResources: []*Resource{
+				{Name: "sub", CostComponents: []*CostComponent{{Name: "sub cc", MonthlyCost: decimalPtr(decimal.NewFromInt(10))}}},
+			},
+		}
+	}
+
+	past := []*Resource{
+		newResource("aws_instance.web", "", 100),
+		newResource("aws_instance.db", "", 200),
+		newResource("aws_instance.worker", "", 300),
+	}
+	current := []*Resource{
+		// Moved without a cost change so it isn't in the diff
+		newResource("module.app.aws_instance.web", "aws_instance.web", 100),
+		// Moved with a cost change so only the change is in the diff
+		newResource("module.app.aws_instance.db", "aws_instance.db", 250),
+		// The past resource still exists at the current name so the move is ignored
+		newResource("aws_instance.worker", "aws_instance.web", 300),
+	}
+
+	diff := CalculateDiff(past, current)
+	require.Len(t, diff, 1)
+	assert.Equal(t, "module.app.aws_instance.db", diff[0].Name)
+	assert.Equal(t, "aws_instance.db", diff[0].MovedFrom)
+	assert.Equal(t, "50", diff[0].MonthlyCost.String())
+	assert.Empty(t, diff[0].SubResources)
+}
+
+func TestCalculateDiffReplacedResources(t *testing.T) {
+	newResource := func(name, replacement string, monthlyCost int64) *Resource {
+		return &Resource{
+			Name:        name,
+			Replacement: replacement,
+			MonthlyCost: decimalPtr(decimal.NewFromInt(monthlyCost)),
+			CostComponents: []*CostComponent{
+				{Name: "cc", MonthlyCost: decimalPtr(decimal.NewFromInt(monthlyCost))},
+			},
+		}
+	}
+
+	past := []*Resource{
+		newResource("aws_db_instance.db", "", 100),
+		newResource("aws_instance.web", "", 200),
+	}
+	current := []*Resource{
+		// Replaced without a cost change so it is still in the diff
+		newResource("aws_db_instance.db", ReplacementCreateBeforeDestroy, 100),
+		newResource("aws_instance.web", "", 200),
+		// A new resource can't be a replacement
+		newResource("aws_instance.new", ReplacementDestroyBeforeCreate, 50),
+	}
+
+	diff := CalculateDiff(past, current)
+	require.Len(t, diff, 2)
+	assert.Equal(t, "aws_db_instance.db", diff[0].Name)
+	assert.Equal(t, ReplacementCreateBeforeDestroy, diff[0].Replacement)
+	assert.Equal(t, "0", diff[0].MonthlyCost.String())
+	assert.Equal(t, "aws_instance.new", diff[1].Name)
+	assert.Empty(t, diff[1].Replacement)
+}
+
 func TestDiffCostComponentsByResource(t *testing.T) {
 	pastRS := &Resource{
 		Name: "rs",
@@ -208,6 +282,9 @@ func TestDiffCostComponentsByResource(t *testing.T) {
 			price:               decimal.NewFromInt(1),
 			HourlyCost:          decimalPtr(decimal.NewFromInt(-3)),
 			MonthlyCost:         decimalPtr(decimal.NewFromInt(-2160)),
+
+			PriceChangeMonthlyCost:    decimalPtr(decimal.RequireFromString("-2880.0000000000000000")),
+			QuantityChangeMonthlyCost: decimalPtr(decimal.RequireFromString("720.0000000000000000")),
 		},
 		{
 			Name:                "cc2",
@@ -243,6 +320,76 @@ func TestDiffCostComponentsByResource(t *testing.T) {
 	assert.Equal(t, expectedDiff, diff)
 }
 
+func TestDiffPriceChange(t *testing.T) {
+	past := &CostComponent{
+		Name:            "cc1",
+		MonthlyQuantity: decimalPtr(decimal.NewFromInt(100)),
+		MonthlyCost:     decimalPtr(decimal.NewFromInt(100)),
+	}
+
+	tests := []struct {
+		name     string
+		current  *CostComponent
+		price    string
+		quantity string
+	}{
+		{
+			name: "price and quantity changed",
+			current: &CostComponent{
+				Name:            "cc1",
+				MonthlyQuantity: decimalPtr(decimal.NewFromInt(150)),
+				MonthlyCost:     decimalPtr(decimal.NewFromInt(300)),
+			},
+			price:    "100",
+			quantity: "100",
+		},
+		{
+			name: "only quantity changed",
+			current: &CostComponent{
+				Name:            "cc1",
+				MonthlyQuantity: decimalPtr(decimal.NewFromInt(150)),
+				MonthlyCost:     decimalPtr(decimal.NewFromInt(150)),
+			},
+		},
+		{
+			name: "product changed",
+			current: &CostComponent{
+				Name:            "cc2",
+				MonthlyQuantity: decimalPtr(decimal.NewFromInt(100)),
+				MonthlyCost:     decimalPtr(decimal.NewFromInt(200)),
+			},
+		},
+		{
+			name: "tiered price changed",
+			current: &CostComponent{
+				Name:            "cc1",
+				MonthlyQuantity: decimalPtr(decimal.NewFromInt(200)),
+				MonthlyCost:     decimalPtr(decimal.NewFromInt(250)),
+				priceTiers: []PriceTier{
+					{Price: decimal.NewFromInt(2), StartUsageAmount: decimal.Zero, EndUsageAmount: decimal.NewFromInt(50)},
+					{Price: decimal.NewFromInt(1), StartUsageAmount: decimal.NewFromInt(50), EndUsageAmount: decimal.NewFromInt(1000)},
+				},
+			},
+			price:    "50",
+			quantity: "100",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, diff := diffCostComponents(past, tt.current)
+			if tt.price == "" {
+				assert.Nil(t, diff.PriceChangeMonthlyCost)
+				assert.Nil(t, diff.QuantityChangeMonthlyCost)
+				return
+			}
+
+			assert.Equal(t, tt.price, diff.PriceChangeMonthlyCost.String())
+			assert.Equal(t, tt.quantity, diff.QuantityChangeMonthlyCost.String())
+		})
+	}
+}
+
 func TestDiffDecimals(t *testing.T) {
 	dc1 := decimalPtr(decimal.NewFromInt(10))
 	dc2 := decimalPtr(decimal.NewFromInt(20))
@@ -20,6 +20,19 @@ type Warning struct {
 	Data    interface{} `json:"data"`
 }
 
+// WarningCodePriceUnavailable is used for warnings about cost components whose
+// price could not be retrieved. The Data of these warnings is a
+// PriceUnavailableWarningData.
+const WarningCodePriceUnavailable = 101
+
+// PriceUnavailableWarningData identifies the cost component whose price could
+// not be retrieved and the reason it failed.
+type PriceUnavailableWarningData struct {
+	ResourceName      string `json:"resourceName"`
+	CostComponentName string `json:"costComponentName"`
+	Error             string `json:"error"`
+}
+
 type ProjectMetadata struct {
 	Path                string    `json:"path"`
 	Type                string    `json:"type"`
@@ -29,6 +42,8 @@ type ProjectMetadata struct {
 	VCSCodeChanged      *bool     `json:"vcsCodeChanged,omitempty"`
 	Warnings            []Warning `json:"warnings,omitempty"`
 	Policies            Policies  `json:"policies,omitempty"`
+	// Budget is only set if the project has a budget in the config file
+	Budget *Budget `json:"budget,omitempty"`
 }
 
 func (m *ProjectMetadata) WorkspaceLabel() string {
@@ -83,6 +98,8 @@ type Project struct {
 	Resources            []*Resource
 	Diff                 []*Resource
 	HasDiff              bool
+	// Commitments are applied to the resources when the project is priced.
+	Commitments *Commitments
 }
 
 func NewProject(name string, metadata *ProjectMetadata) *Project {
@@ -110,6 +127,34 @@ func (p *Project) AllResources() []*Resource {
 	return resources
 }
 
+// UsageScenarioResources returns the resources priced with the usage of the
+// usage scenarios of the current resources.
+func (p *Project) UsageScenarioResources() []*Resource {
+	var resources []*Resource
+	for _, r := range p.Resources {
+		for _, name := range UsageScenarios {
+			if s, ok := r.UsageScenarios[name]; ok {
+				resources = append(resources, s)
+			}
+		}
+	}
+	return resources
+}
+
+// ForecastResources returns the resources priced with the usage of each month
+// of the forecast whose usage is different from the current resources.
+func (p *Project) ForecastResources() []*Resource {
+	var resources []*Resource
+	for _, r := range p.Resources {
+		for _, f := range r.ForecastMonths {
+			if f != r {
+				resources = append(resources, f)
+			}
+		}
+	}
+	return resources
+}
+
 // AllPartialResources returns a pointer list of the current and past partial resources
 func (p *Project) AllPartialResources() []*PartialResource {
 	var resources []*PartialResource
@@ -8,8 +8,20 @@ import (
 	"github.com/tidwall/gjson"
 )
 
+// HourToMonthUnitMultiplier is the number of hours in a month that hourly
+// quantities are converted to monthly quantities with. It is 730 hours unless
+// the hours_per_month setting changes it.
 var HourToMonthUnitMultiplier = decimal.NewFromInt(730)
 
+const (
+	// ReplacementCreateBeforeDestroy is a replacement where the new resource is
+	// created before the old one is destroyed, so both run for a while.
+	ReplacementCreateBeforeDestroy = "create_before_destroy"
+	// ReplacementDestroyBeforeCreate is a replacement where the old resource is
+	// destroyed before the new one is created.
+	ReplacementDestroyBeforeCreate = "destroy_before_create"
+)
+
 type ResourceFunc func(*ResourceData, *UsageData) *Resource
 
 type Resource struct {
@@ -24,22 +36,48 @@ type Resource struct {
 	SkipMessage       string
 	ResourceType      string
 	Tags              map[string]string
+	Region            string
 	UsageSchema       []*UsageItem
 	EstimateUsage     EstimateFunc
 	EstimationSummary map[string]bool
 	Metadata          map[string]gjson.Result
+	// UsageScenarios are the resource priced with the usage of each of its usage
+	// scenarios other than the expected one, keyed by the scenario name.
+	UsageScenarios map[string]*Resource
+	// ForecastMonths are the resource priced with the usage of each month of the
+	// forecast. It is the resource itself for months its usage doesn't change.
+	ForecastMonths []*Resource
+	// MonthlyCarbon is the estimated monthly emissions of the resource in
+	// kgCO2e. It is only set if some of its cost components have an estimate.
+	MonthlyCarbon *decimal.Decimal
+	// MovedFrom is the name the resource had before its address was changed,
+	// e.g. with a moved block. The diff matches the resource with the past
+	// resource of this name.
+	MovedFrom string
+	// Replacement is how the resource is replaced if the plan replaces it,
+	// either ReplacementCreateBeforeDestroy or ReplacementDestroyBeforeCreate.
+	Replacement string
 }
 
 func CalculateCosts(project *Project) {
 	for _, r := range project.AllResources() {
 		r.CalculateCosts()
 	}
+
+	for _, r := range project.UsageScenarioResources() {
+		r.CalculateCosts()
+	}
+
+	for _, r := range project.ForecastResources() {
+		r.CalculateCosts()
+	}
 }
 
 func (r *Resource) CalculateCosts() {
 	h := decimal.Zero
 	m := decimal.Zero
 	hasCost := false
+	var carbon *decimal.Decimal
 
 	for _, c := range r.CostComponents {
 		c.CalculateCosts()
@@ -52,6 +90,7 @@ func (r *Resource) CalculateCosts() {
 		if c.MonthlyCost != nil {
 			m = m.Add(*c.MonthlyCost)
 		}
+		carbon = addDecimals(carbon, c.MonthlyCarbon)
 	}
 
 	for _, s := range r.SubResources {
@@ -65,12 +104,14 @@ func (r *Resource) CalculateCosts() {
 		if s.MonthlyCost != nil {
 			m = m.Add(*s.MonthlyCost)
 		}
+		carbon = addDecimals(carbon, s.MonthlyCarbon)
 	}
 
 	if hasCost {
 		r.HourlyCost = &h
 		r.MonthlyCost = &m
 	}
+	r.MonthlyCarbon = carbon
 	if r.NoPrice {
 		log.Debugf("Skipping free resource %s", r.Name)
 	}
@@ -125,3 +166,17 @@ func MultiplyQuantities(resource *Resource, multiplier decimal.Decimal) {
 func decimalPtr(d decimal.Decimal) *decimal.Decimal {
 	return &d
 }
+
+// addDecimals returns the sum of total and d, treating a nil total as zero.
+// If d is nil total is returned unchanged.
+func addDecimals(total, d *decimal.Decimal) *decimal.Decimal {
+	if d == nil {
+		return total
+	}
+
+	if total == nil {
+		return decimalPtr(*d)
+	}
+
+	return decimalPtr(total.Add(*d))
+}
@@ -18,6 +18,10 @@ type ResourceData struct {
 	CFResource    cloudformation.Resource
 	UsageData     *UsageData
 	Metadata      map[string]gjson.Result
+	// PreviousAddress is the address the resource had before it was moved.
+	PreviousAddress string
+	// Replacement is how the resource is replaced if the plan replaces it.
+	Replacement string
 }
 
 func NewResourceData(resourceType string, providerName string, address string, tags map[string]string, rawValues gjson.Result) *ResourceData {
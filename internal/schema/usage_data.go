@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"math"
+	"strconv"
 	"strings"
 
 	"github.com/imdario/mergo"
@@ -12,6 +13,25 @@ import (
 	"github.com/tidwall/gjson"
 )
 
+const (
+	// UsageScenariosKey is the key of the usage scenarios of a resource in the
+	// usage file. Each scenario has usage values that replace the usage values of
+	// the resource when the resource is priced with the scenario.
+	UsageScenariosKey = "scenarios"
+	// ExpectedUsageScenario is the usage scenario the cost of the resource is
+	// reported with. If it isn't set the usage values of the resource are used.
+	ExpectedUsageScenario = "expected"
+	// UsageGrowthKey is the key of the growth functions of the usage values of a
+	// resource in the usage file. They are used to forecast the usage of each
+	// month, e.g. 'standard.storage_gb: {percentage: 8}' grows the storage by 8%
+	// each month.
+	UsageGrowthKey = "growth"
+)
+
+// UsageScenarios are the names of the usage scenarios that can be set for a
+// resource in the usage file.
+var UsageScenarios = []string{"low", ExpectedUsageScenario, "high"}
+
 type UsageData struct {
 	Address    string
 	Attributes map[string]gjson.Result
@@ -53,6 +73,156 @@ func (u *UsageData) Merge(other *UsageData) *UsageData {
 	return newU
 }
 
+// ScenarioNames returns the names of the usage scenarios of the usage data in
+// the order of UsageScenarios.
+func (u *UsageData) ScenarioNames() []string {
+	names := make([]string, 0)
+	if u == nil {
+		return names
+	}
+
+	scenarios := u.Attributes[UsageScenariosKey]
+	for _, name := range UsageScenarios {
+		if scenarios.Get(name).IsObject() {
+			names = append(names, name)
+		}
+	}
+
+	return names
+}
+
+// Scenario returns a copy of the usage data with the values of the usage
+// scenario replacing its values. The copy doesn't have any usage scenarios.
+func (u *UsageData) Scenario(name string) *UsageData {
+	if u == nil {
+		return nil
+	}
+
+	attributes := make(map[string]gjson.Result, len(u.Attributes))
+	for k, v := range u.Attributes {
+		if k != UsageScenariosKey {
+			attributes[k] = v
+		}
+	}
+
+	for k, v := range u.Attributes[UsageScenariosKey].Get(name).Map() {
+		attributes[k] = v
+	}
+
+	return NewUsageData(u.Address, attributes)
+}
+
+// HasGrowth returns true if the usage data has growth functions for any of
+// its usage values.
+func (u *UsageData) HasGrowth() bool {
+	if u == nil {
+		return false
+	}
+
+	return len(u.Attributes[UsageGrowthKey].Map()) > 0
+}
+
+// ForecastMonth returns a copy of the usage data with the growth functions
+// applied to its usage values for the month, where month 0 is the first month
+// of the forecast. The usage values of the expected usage scenario are used
+// if it is set. The copy doesn't have any growth functions or usage scenarios.
+//
+// The supported growth functions are:
+//   - linear: the amount added to the value each month
+//   - percentage: the percentage the value grows by each month, compounded
+//   - monthly: an explicit series of values for each month, the last value is
+//     used for the months after the end of the series
+func (u *UsageData) ForecastMonth(month int) *UsageData {
+	if u == nil {
+		return nil
+	}
+
+	forecast := u.Scenario(ExpectedUsageScenario)
+	growth := forecast.Attributes[UsageGrowthKey]
+	delete(forecast.Attributes, UsageGrowthKey)
+
+	for key, fn := range growth.Map() {
+		top, path, _ := strings.Cut(key, ".")
+
+		base := forecast.Attributes[top]
+		if path != "" {
+			base = base.Get(path)
+		}
+
+		v, ok := growUsageValue(fn, base, month)
+		if !ok {
+			log.Debugf("Ignoring growth of usage value %s for %s", key, u.Address)
+			continue
+		}
+
+		setUsageValue(forecast.Attributes, top, path, v)
+	}
+
+	return forecast
+}
+
+// growUsageValue returns the value of the growth function for the month. It
+// returns false if the growth function is invalid or there is no value to grow.
+func growUsageValue(fn gjson.Result, base gjson.Result, month int) (float64, bool) {
+	if series := fn.Get("monthly"); series.IsArray() {
+		values := series.Array()
+		if len(values) == 0 {
+			return 0, false
+		}
+
+		if month >= len(values) {
+			month = len(values) - 1
+		}
+
+		return values[month].Float(), true
+	}
+
+	if base.Type != gjson.Number {
+		return 0, false
+	}
+
+	if linear := fn.Get("linear"); linear.Type == gjson.Number {
+		return base.Float() + linear.Float()*float64(month), true
+	}
+
+	if percentage := fn.Get("percentage"); percentage.Type == gjson.Number {
+		return base.Float() * math.Pow(1+percentage.Float()/100, float64(month)), true
+	}
+
+	return 0, false
+}
+
+// setUsageValue sets the value of the usage attribute, or of the nested path
+// of the attribute if path is set.
+func setUsageValue(attributes map[string]gjson.Result, key, path string, v float64) {
+	value := strconv.FormatFloat(v, 'f', -1, 64)
+
+	if path == "" {
+		attributes[key] = gjson.Parse(value)
+		return
+	}
+
+	m, ok := attributes[key].Value().(map[string]interface{})
+	if !ok {
+		m = make(map[string]interface{})
+	}
+
+	parent := m
+	parts := strings.Split(path, ".")
+	for _, part := range parts[:len(parts)-1] {
+		child, ok := parent[part].(map[string]interface{})
+		if !ok {
+			child = make(map[string]interface{})
+			parent[part] = child
+		}
+		parent = child
+	}
+	parent[parts[len(parts)-1]] = json.Number(value)
+
+	j, _ := jsoniter.Marshal(m)
+	attributes[key] = gjson.ParseBytes(j)
+}
+
 func (u *UsageData) Get(key string) gjson.Result {
 	if u.Attributes[key].Type != gjson.Null {
 		return u.Attributes[key]
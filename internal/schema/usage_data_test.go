@@ -114,3 +114,62 @@ func TestParseAttributes(t *testing.T) {
 		})
 	}
 }
+
+func TestUsageDataScenario(t *testing.T) {
+	u := NewUsageData("aws_s3_bucket.bucket", map[string]gjson.Result{
+		"standard":  gjson.Parse(`{"storage_gb": 100}`),
+		"scenarios": gjson.Parse(`{"high": {"standard": {"storage_gb": 1000}, "monthly_egress_data_transfer_gb": 50}, "low": 1}`),
+	})
+
+	assert.Equal(t, []string{"high"}, u.ScenarioNames())
+
+	high := u.Scenario("high")
+	assert.Equal(t, int64(1000), high.Get("standard").Get("storage_gb").Int())
+	assert.Equal(t, int64(50), high.Get("monthly_egress_data_transfer_gb").Int())
+	assert.Empty(t, high.ScenarioNames())
+
+	// The usage data isn't modified
+	assert.Equal(t, int64(100), u.Get("standard").Get("storage_gb").Int())
+
+	var nilUsage *UsageData
+	assert.Empty(t, nilUsage.ScenarioNames())
+}
+
+func TestUsageDataForecastMonth(t *testing.T) {
+	u := NewUsageData("aws_s3_bucket.bucket", ParseAttributes(map[string]interface{}{
+		"monthly_requests":                1000,
+		"standard":                        map[string]interface{}{"storage_gb": 100, "monthly_tier_1_requests": 10},
+		"monthly_egress_data_transfer_gb": 50,
+		"growth": map[string]interface{}{
+			"monthly_requests":                map[string]interface{}{"linear": 500},
+			"standard.storage_gb":             map[string]interface{}{"percentage": 10},
+			"monthly_egress_data_transfer_gb": map[string]interface{}{"monthly": []interface{}{50, 80, 200}},
+			"missing_key":                     map[string]interface{}{"linear": 1},
+		},
+		"scenarios": map[string]interface{}{
+			"expected": map[string]interface{}{"monthly_requests": 2000},
+		},
+	}))
+	require.True(t, u.HasGrowth())
+
+	first := u.ForecastMonth(0)
+	assert.Equal(t, float64(2000), first.Get("monthly_requests").Float())
+	assert.Equal(t, float64(100), first.Get("standard").Get("storage_gb").Float())
+	assert.Equal(t, float64(50), first.Get("monthly_egress_data_transfer_gb").Float())
+	assert.False(t, first.HasGrowth())
+	assert.Empty(t, first.ScenarioNames())
+	assert.Equal(t, gjson.Null, first.Get("missing_key").Type)
+
+	third := u.ForecastMonth(2)
+	assert.Equal(t, float64(3000), third.Get("monthly_requests").Float())
+	assert.InDelta(t, 121, third.Get("standard").Get("storage_gb").Float(), 0.0001)
+	assert.Equal(t, float64(10), third.Get("standard").Get("monthly_tier_1_requests").Float())
+	assert.Equal(t, float64(200), third.Get("monthly_egress_data_transfer_gb").Float())
+
+	// The last value of a monthly series is used after the end of the series
+	assert.Equal(t, float64(200), u.ForecastMonth(5).Get("monthly_egress_data_transfer_gb").Float())
+
+	// The usage data isn't modified
+	assert.Equal(t, float64(100), u.Get("standard").Get("storage_gb").Float())
+	assert.False(t, NewUsageData("aws_s3_bucket.bucket", nil).HasGrowth())
+}
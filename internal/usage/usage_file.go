@@ -27,6 +27,8 @@ type UsageFile struct { // nolint:revive
 	RawResourceUsage yamlv3.Node `yaml:"resource_usage"`
 	// The raw usage is then parsed into this struct
 	ResourceUsages []*ResourceUsage `yaml:"-"`
+	// Commitments are the Reserved Instances and Savings Plans that apply to the resources
+	Commitments *schema.Commitments `yaml:"commitments,omitempty"`
 }
 
 // CreateUsageFile creates a blank usage file if it does not exists
@@ -98,6 +100,11 @@ func LoadUsageFileFromString(s string) (*UsageFile, error) {
 		return usageFile, errors.Wrap(err, "Error loading YAML file")
 	}
 
+	err = usageFile.Commitments.Validate()
+	if err != nil {
+		return usageFile, errors.Wrap(err, "Invalid commitments")
+	}
+
 	return usageFile, nil
 }
 
@@ -143,6 +150,22 @@ See https://infracost.io/usage-file/ for docs`,
 		&u.RawResourceUsage,
 	)
 
+	if u.Commitments != nil {
+		commitmentsNode := &yamlv3.Node{}
+		err := commitmentsNode.Encode(u.Commitments)
+		if err != nil {
+			return err
+		}
+
+		root.Content = append(root.Content,
+			&yamlv3.Node{
+				Kind:  yamlv3.ScalarNode,
+				Value: "commitments",
+			},
+			commitmentsNode,
+		)
+	}
+
 	// Add a comment to the first commented-out resource
 	for _, node := range u.RawResourceTypeUsage.Content {
 		if isNodeMarkedAsCommented(node) {
@@ -267,6 +290,14 @@ func removeDuplicateStr(strSlice []string) []string {
 func findInvalidKeys(item *schema.UsageItem, refMap map[string]interface{}) []string {
 	invalidKeys := make([]string, 0)
 
+	if item.Key == schema.UsageScenariosKey {
+		return findInvalidScenarioKeys(item, refMap)
+	}
+
+	if item.Key == schema.UsageGrowthKey {
+		return findInvalidGrowthKeys(item, refMap)
+	}
+
 	if refVal, ok := refMap[item.Key]; !ok {
 		invalidKeys = append(invalidKeys, item.Key)
 	} else if item.ValueType == schema.SubResourceUsage && item.Value != nil {
@@ -278,6 +309,87 @@ func findInvalidKeys(item *schema.UsageItem, refMap map[string]interface{}) []st
 	return invalidKeys
 }
 
+// findInvalidScenarioKeys searches for invalid keys in the usage scenarios of a
+// resource. The names of the scenarios must be one of schema.UsageScenarios and
+// their keys are checked against the reference usage of the resource.
+func findInvalidScenarioKeys(item *schema.UsageItem, refMap map[string]interface{}) []string {
+	invalidKeys := make([]string, 0)
+
+	if item.ValueType != schema.SubResourceUsage || item.Value == nil {
+		return append(invalidKeys, item.Key)
+	}
+
+	for _, scenario := range item.Value.(*ResourceUsage).Items {
+		if !isUsageScenario(scenario.Key) || scenario.ValueType != schema.SubResourceUsage || scenario.Value == nil {
+			invalidKeys = append(invalidKeys, fmt.Sprintf("%s.%s", item.Key, scenario.Key))
+			continue
+		}
+
+		for _, subItem := range scenario.Value.(*ResourceUsage).Items {
+			invalidKeys = append(invalidKeys, findInvalidKeys(subItem, refMap)...)
+		}
+	}
+
+	return invalidKeys
+}
+
+// findInvalidGrowthKeys searches for invalid keys in the growth functions of a
+// resource. Each growth function must be for a key of the reference usage of
+// the resource, using dots for nested keys, and be one of linear, percentage
+// or monthly.
+func findInvalidGrowthKeys(item *schema.UsageItem, refMap map[string]interface{}) []string {
+	invalidKeys := make([]string, 0)
+
+	if item.ValueType != schema.SubResourceUsage || item.Value == nil {
+		return append(invalidKeys, item.Key)
+	}
+
+	for _, growth := range item.Value.(*ResourceUsage).Items {
+		key := fmt.Sprintf("%s.%s", item.Key, growth.Key)
+
+		if !hasReferenceKey(refMap, strings.Split(growth.Key, ".")) || growth.ValueType != schema.SubResourceUsage || growth.Value == nil {
+			invalidKeys = append(invalidKeys, key)
+			continue
+		}
+
+		for _, fn := range growth.Value.(*ResourceUsage).Items {
+			if fn.Key != "linear" && fn.Key != "percentage" && fn.Key != "monthly" {
+				invalidKeys = append(invalidKeys, fmt.Sprintf("%s.%s", key, fn.Key))
+			}
+		}
+	}
+
+	return invalidKeys
+}
+
+func hasReferenceKey(refMap map[string]interface{}, path []string) bool {
+	refVal, ok := refMap[path[0]]
+	if !ok {
+		return false
+	}
+
+	if len(path) == 1 {
+		return true
+	}
+
+	nested, ok := refVal.(map[string]interface{})
+	if !ok {
+		return false
+	}
+
+	return hasReferenceKey(nested, path[1:])
+}
+
+func isUsageScenario(name string) bool {
+	for _, s := range schema.UsageScenarios {
+		if s == name {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (u *UsageFile) parseResourceUsages() error {
 	var err error
 	u.ResourceUsages, err = ResourceUsagesFromYAML(u.RawResourceUsage)
@@ -3,6 +3,7 @@ package usage_test
 import (
 	"github.com/infracost/infracost/internal/usage"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"testing"
 
 	"github.com/infracost/infracost/internal/providers/terraform/tftest"
@@ -105,3 +106,100 @@ resource_usage:
 	}
 
 }
+
+func TestLoadUsageFileCommitments(t *testing.T) {
+	usageFile, err := usage.LoadUsageFileFromString(`
+version: 0.1
+commitments:
+  reserved_instances:
+    - service: ec2
+      instance_family: m5
+      region: us-east-1
+      term: 1_year
+      payment_option: no_upfront
+      count: 2
+  savings_plans:
+    - type: compute
+      hourly_commitment: 1.5
+      discount_perc: 0.2
+`)
+	require.NoError(t, err)
+	require.NotNil(t, usageFile.Commitments)
+	require.Len(t, usageFile.Commitments.ReservedInstances, 1)
+	assert.Equal(t, int64(2), usageFile.Commitments.ReservedInstances[0].Count)
+	require.Len(t, usageFile.Commitments.SavingsPlans, 1)
+	assert.Equal(t, 0.2, usageFile.Commitments.SavingsPlans[0].DiscountPerc)
+
+	_, err = usage.LoadUsageFileFromString(`
+version: 0.1
+commitments:
+  reserved_instances:
+    - service: ec2
+      instance_type: m5.large
+      region: us-east-1
+      term: 2_year
+      payment_option: no_upfront
+`)
+	assert.ErrorContains(t, err, "invalid term")
+}
+
+func TestUsageFileScenarios(t *testing.T) {
+	usageFile, err := usage.LoadUsageFileFromString(`
+version: 0.1
+resource_type_default_usage:
+  aws_lambda_function:
+    request_duration_ms: 500
+    scenarios:
+      low:
+        monthly_requests: 1000
+      high:
+        monthly_requests: 1000000
+resource_usage:
+  aws_lambda_function.my_function:
+    monthly_requests: 100000
+    scenarios:
+      high:
+        monthly_requests: 5000000
+        invalid_key: 1
+      peak:
+        monthly_requests: 1
+`)
+	require.NoError(t, err)
+
+	u := usageFile.ToUsageDataMap()["aws_lambda_function.my_function"]
+	assert.Equal(t, []string{"high"}, u.ScenarioNames())
+	assert.Equal(t, int64(5000000), u.Scenario("high").Get("monthly_requests").Int())
+	assert.Equal(t, int64(100000), u.Get("monthly_requests").Int())
+
+	invalidKeys, err := usageFile.InvalidKeys()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"invalid_key", "scenarios.peak"}, invalidKeys)
+}
+
+func TestUsageFileGrowth(t *testing.T) {
+	usageFile, err := usage.LoadUsageFileFromString(`
+version: 0.1
+resource_usage:
+  aws_s3_bucket.my_bucket:
+    standard:
+      storage_gb: 1000
+    growth:
+      standard.storage_gb:
+        percentage: 8
+      monthly_outbound_data_transfer_gb:
+        monthly: [10, 20, 40]
+      standard.missing_gb:
+        linear: 10
+      standard.monthly_tier_1_requests:
+        exponential: 2
+`)
+	require.NoError(t, err)
+
+	u := usageFile.ToUsageDataMap()["aws_s3_bucket.my_bucket"]
+	assert.True(t, u.HasGrowth())
+	assert.InDelta(t, 1080, u.ForecastMonth(1).Get("standard").Get("storage_gb").Float(), 0.0001)
+
+	invalidKeys, err := usageFile.InvalidKeys()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"growth.monthly_outbound_data_transfer_gb", "growth.standard.missing_gb", "growth.standard.monthly_tier_1_requests.exponential"}, invalidKeys)
+}